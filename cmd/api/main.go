@@ -4,9 +4,17 @@ package main
 // @version 1.0
 // @description REST API for disciplines, teams, tournaments, matches, and reports.
 // @BasePath /api
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Token from POST /auth/login, sent as "Bearer <token>".
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
 
 import (
 	"context"
+	"crypto/rand"
 	"log"
 	"net/http"
 	"os"
@@ -16,6 +24,7 @@ import (
 
 	"db_course_project/docs"
 	"db_course_project/internal/api"
+	"db_course_project/internal/auth"
 	"db_course_project/internal/config"
 	"db_course_project/internal/db"
 	"db_course_project/internal/repository"
@@ -37,6 +46,18 @@ func main() {
 	}
 	defer sqlxDB.Close()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(sqlxDB, os.Args[2:]); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+	if cfg.AutoMigrate {
+		if err := autoMigrate(sqlxDB); err != nil {
+			log.Fatalf("failed to migrate db: %v", err)
+		}
+	}
+
 	disciplineRepo := repository.NewDisciplineRepository(sqlxDB)
 	teamRepo := repository.NewTeamRepository(sqlxDB)
 	playerRepo := repository.NewPlayerRepository(sqlxDB)
@@ -48,20 +69,53 @@ func main() {
 	matchRepo := repository.NewMatchRepository(sqlxDB)
 	matchGameRepo := repository.NewMatchGameRepository(sqlxDB)
 	gamePlayerStatRepo := repository.NewGamePlayerStatRepository(sqlxDB)
+	ratingRepo := repository.NewRatingRepository(sqlxDB)
+	importJobRepo := repository.NewImportJobRepository(sqlxDB)
+	importErrorRepo := repository.NewImportErrorRepository(sqlxDB)
+	exportRepo := repository.NewExportRepository(sqlxDB)
+	userRepo := repository.NewUserRepository(sqlxDB)
+	apiKeyRepo := repository.NewAPIKeyRepository(sqlxDB)
 
-	disciplineSvc := service.NewDisciplineService(disciplineRepo)
-	teamSvc := service.NewTeamService(teamRepo)
-	playerSvc := service.NewPlayerService(playerRepo)
-	reportSvc := service.NewReportService(reportRepo)
-	tournamentSvc := service.NewTournamentService(tournamentRepo)
-	teamProfileSvc := service.NewTeamProfileService(teamProfileRepo)
-	squadMemberSvc := service.NewSquadMemberService(squadMemberRepo)
-	tournamentRegistrationSvc := service.NewTournamentRegistrationService(tournamentRegistrationRepo)
-	matchSvc := service.NewMatchService(matchRepo)
-	matchGameSvc := service.NewMatchGameService(matchGameRepo)
-	gamePlayerStatSvc := service.NewGamePlayerStatService(gamePlayerStatRepo)
-	importSvc := service.NewImportService(sqlxDB, disciplineSvc, teamSvc, playerSvc, tournamentSvc, tournamentRegistrationSvc, matchSvc, matchGameSvc, gamePlayerStatSvc, squadMemberSvc, teamProfileSvc)
+	txManager := repository.NewTxManager(sqlxDB)
 
+	secret := []byte(cfg.Auth.JWTSecret)
+	if len(secret) == 0 {
+		log.Println("JWT_SECRET is not set, bearer tokens will not survive a restart")
+		secret = make([]byte, 32)
+		rand.Read(secret)
+	}
+	authSvc := service.NewAuthService(txManager, userRepo, apiKeyRepo, auth.NewTokens(secret, cfg.Auth.TokenTTL))
+	if cfg.Auth.AdminPassword != "" {
+		created, err := authSvc.EnsureAdmin(context.Background(), cfg.Auth.AdminUsername, cfg.Auth.AdminPassword)
+		if err != nil {
+			log.Fatalf("failed to create admin user: %v", err)
+		}
+		if created {
+			log.Printf("created admin user %q", cfg.Auth.AdminUsername)
+		}
+	}
+
+	disciplineSvc := service.NewDisciplineService(txManager, disciplineRepo)
+	teamSvc := service.NewTeamService(txManager, teamRepo, ratingRepo)
+	playerSvc := service.NewPlayerService(txManager, playerRepo, ratingRepo)
+	reportSvc := service.NewReportService(reportRepo, tournamentRepo)
+	tournamentRegistrationSvc := service.NewTournamentRegistrationService(txManager, tournamentRegistrationRepo, tournamentRepo, teamRepo, squadMemberRepo, disciplineRepo)
+	tournamentSvc := service.NewTournamentService(txManager, tournamentRepo, matchRepo, tournamentRegistrationSvc)
+	teamProfileSvc := service.NewTeamProfileService(txManager, teamProfileRepo)
+	squadMemberSvc := service.NewSquadMemberService(txManager, squadMemberRepo, teamRepo, disciplineRepo)
+	ratingSvc := service.NewRatingService(txManager, ratingRepo, tournamentRepo, disciplineRepo, teamRepo, playerRepo)
+	matchSvc := service.NewMatchService(txManager, matchRepo, matchGameRepo, tournamentRepo, ratingSvc)
+	matchGameSvc := service.NewMatchGameService(txManager, matchGameRepo, matchSvc)
+	gamePlayerStatSvc := service.NewGamePlayerStatService(txManager, gamePlayerStatRepo, matchGameRepo, matchRepo, tournamentRegistrationRepo, teamRepo, disciplineRepo)
+	bracketSvc := service.NewBracketService(tournamentRepo, tournamentRegistrationRepo, matchRepo)
+	importSvc := service.NewImportService(txManager, importErrorRepo, disciplineSvc, teamSvc, playerSvc, tournamentSvc, tournamentRegistrationSvc, matchSvc, matchGameSvc, gamePlayerStatSvc, squadMemberSvc, teamProfileSvc)
+	importJobSvc := service.NewImportJobService(txManager, importJobRepo, importSvc)
+	importErrorSvc := service.NewImportErrorService(txManager, importErrorRepo, importSvc, cfg.ImportErrorRetention)
+	exportSvc := service.NewExportService(sqlxDB, exportRepo, tournamentRepo)
+
+	authHandler := api.NewAuthHandler(authSvc)
+	accountHandler := api.NewAccountHandler(authSvc)
+	userHandler := api.NewUserHandler(authSvc)
 	disciplineHandler := api.NewDisciplineHandler(disciplineSvc)
 	teamHandler := api.NewTeamHandler(teamSvc)
 	playerHandler := api.NewPlayerHandler(playerSvc)
@@ -72,15 +126,27 @@ func main() {
 	matchHandler := api.NewMatchHandler(matchSvc)
 	matchGameHandler := api.NewMatchGameHandler(matchGameSvc)
 	gamePlayerStatHandler := api.NewGamePlayerStatHandler(gamePlayerStatSvc)
-	utilityHandler := api.NewUtilityHandler(reportSvc, importSvc)
+	bracketHandler := api.NewBracketHandler(bracketSvc)
+	ratingHandler := api.NewRatingHandler(ratingSvc)
+	importJobHandler := api.NewImportJobHandler(importJobSvc)
+	importErrorHandler := api.NewImportErrorHandler(importErrorSvc)
+	exportHandler := api.NewExportHandler(exportSvc)
+	utilityHandler := api.NewUtilityHandler(reportSvc, importSvc, importJobSvc)
 
-	router := server.NewRouter(disciplineHandler, teamHandler, playerHandler, tournamentHandler, teamProfileHandler, squadMemberHandler, tournamentRegistrationHandler, matchHandler, matchGameHandler, gamePlayerStatHandler, utilityHandler)
+	router := server.NewRouter(api.Authenticate(authSvc), authHandler, accountHandler, userHandler, disciplineHandler, teamHandler, playerHandler, tournamentHandler, teamProfileHandler, squadMemberHandler, tournamentRegistrationHandler, matchHandler, matchGameHandler, gamePlayerStatHandler, bracketHandler, ratingHandler, importJobHandler, importErrorHandler, exportHandler, utilityHandler)
 
 	srv := &http.Server{
 		Addr:    cfg.HTTPAddr,
 		Handler: router,
 	}
 
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	workersDone := make(chan struct{})
+	go func() {
+		defer close(workersDone)
+		importJobSvc.Run(workersCtx, cfg.ImportWorkers)
+	}()
+
 	go func() {
 		log.Printf("starting http server on %s", cfg.HTTPAddr)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -97,5 +163,11 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Printf("graceful shutdown failed: %v", err)
 	}
+	stopWorkers()
+	select {
+	case <-workersDone:
+	case <-ctx.Done():
+		log.Println("import workers did not stop in time")
+	}
 	log.Println("server exited")
 }
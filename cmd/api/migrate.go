@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/jmoiron/sqlx"
+
+	"db_course_project/internal/migrate"
+)
+
+const migrateUsage = "usage: api migrate up | down [steps] | status"
+
+// runMigrate handles the migrate subcommand: up applies every pending
+// migration, down reverts the last one (or the given number), status lists
+// them.
+func runMigrate(db *sqlx.DB, args []string) error {
+	m, err := migrate.New(db)
+	if err != nil {
+		return err
+	}
+	m.Log = log.Printf
+	ctx := context.Background()
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			log.Println("migrate: schema is up to date")
+		}
+		return nil
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid steps %q", args[1])
+			}
+		}
+		_, err := m.Down(ctx, steps)
+		return err
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+		for _, st := range statuses {
+			state, appliedAt := "pending", ""
+			if st.AppliedAt != nil {
+				state, appliedAt = "applied", st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			switch {
+			case st.Unknown:
+				state = "unknown"
+			case st.Modified:
+				state = "modified"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", st.Version, st.Name, state, appliedAt)
+		}
+		return w.Flush()
+	}
+	return errors.New(migrateUsage)
+}
+
+// autoMigrate applies pending migrations at startup.
+func autoMigrate(db *sqlx.DB) error {
+	m, err := migrate.New(db)
+	if err != nil {
+		return err
+	}
+	m.Log = log.Printf
+	_, err = m.Up(context.Background())
+	return err
+}
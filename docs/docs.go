@@ -15,37 +15,36 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/batch-import/disciplines": {
+        "/admin/ratings/recompute": {
             "post": {
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Utility"
+                    "Ratings"
                 ],
-                "summary": "Batch import disciplines",
+                "summary": "Recompute ratings from match history",
                 "parameters": [
                     {
-                        "description": "Disciplines to import",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/service.DisciplineImportInput"
-                            }
-                        }
+                        "type": "integer",
+                        "description": "Discipline ID, all disciplines when omitted",
+                        "name": "discipline_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                            "$ref": "#/definitions/api.RatingRecomputeResponse"
                         }
                     },
                     "400": {
@@ -54,6 +53,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -63,51 +68,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/disciplines/csv": {
-            "post": {
-                "consumes": [
-                    "multipart/form-data"
+        "/auth/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Utility"
-                ],
-                "summary": "Batch import disciplines from CSV",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "CSV file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "Auth"
                 ],
+                "summary": "List own API keys",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.ImportSummaryResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
+                            "$ref": "#/definitions/api.APIKeyListResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/batch-import/game-player-stats": {
+            },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The key is returned once; send it in the X-API-Key header. It acts with the role of its user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -115,28 +117,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Utility"
+                    "Auth"
                 ],
-                "summary": "Batch import game player stats",
+                "summary": "Create an API key",
                 "parameters": [
                     {
-                        "description": "Game player stats to import",
+                        "description": "Key name and optional expiry (RFC 3339)",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/service.GamePlayerStatImportInput"
-                            }
+                            "$ref": "#/definitions/api.apiKeyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                            "$ref": "#/definitions/api.CreatedAPIKeyResponse"
                         }
                     },
                     "400": {
@@ -145,8 +144,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -154,32 +153,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/game-player-stats/csv": {
-            "post": {
-                "consumes": [
-                    "multipart/form-data"
+        "/auth/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Utility"
+                    "Auth"
                 ],
-                "summary": "Batch import game player stats from CSV",
+                "summary": "Revoke an own API key",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "CSV file",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                            "$ref": "#/definitions/api.EmptyResponse"
                         }
                     },
                     "400": {
@@ -188,8 +192,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -197,8 +207,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/match-games": {
+        "/auth/login": {
             "post": {
+                "description": "Returns a bearer token for the Authorization header of the other endpoints.",
                 "consumes": [
                     "application/json"
                 ],
@@ -206,20 +217,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Utility"
+                    "Auth"
                 ],
-                "summary": "Batch import match games",
+                "summary": "Log in",
                 "parameters": [
                     {
-                        "description": "Match games to import",
+                        "description": "Credentials",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/service.MatchGameImportInput"
-                            }
+                            "$ref": "#/definitions/api.loginRequest"
                         }
                     }
                 ],
@@ -227,7 +235,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                            "$ref": "#/definitions/api.LoginResponse"
                         }
                     },
                     "400": {
@@ -236,8 +244,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -245,42 +253,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/match-games/csv": {
-            "post": {
-                "consumes": [
-                    "multipart/form-data"
+        "/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Utility"
-                ],
-                "summary": "Batch import match games from CSV",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "CSV file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "Auth"
                 ],
+                "summary": "Current user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.ImportSummaryResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
+                            "$ref": "#/definitions/api.PrincipalResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -288,8 +286,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/matches": {
+        "/batch-import/disciplines": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -299,19 +305,43 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import matches",
+                "summary": "Batch import disciplines",
                 "parameters": [
                     {
-                        "description": "Matches to import",
+                        "description": "Disciplines to import",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/service.MatchImportInput"
+                                "$ref": "#/definitions/service.DisciplineImportInput"
                             }
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -321,6 +351,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -336,8 +372,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/matches/csv": {
+        "/batch-import/disciplines/csv": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -347,7 +391,7 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import matches from CSV",
+                "summary": "Batch import disciplines from CSV",
                 "parameters": [
                     {
                         "type": "file",
@@ -355,6 +399,30 @@ const docTemplate = `{
                         "name": "file",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -364,6 +432,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -379,8 +453,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/players": {
+        "/batch-import/game-player-stats": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -390,19 +472,43 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import players",
+                "summary": "Batch import game player stats",
                 "parameters": [
                     {
-                        "description": "Players to import",
+                        "description": "Game player stats to import",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/service.PlayerImportInput"
+                                "$ref": "#/definitions/service.GamePlayerStatImportInput"
                             }
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -412,6 +518,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -427,8 +539,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/players/csv": {
+        "/batch-import/game-player-stats/csv": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -438,7 +558,7 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import players from CSV",
+                "summary": "Batch import game player stats from CSV",
                 "parameters": [
                     {
                         "type": "file",
@@ -446,8 +566,32 @@ const docTemplate = `{
                         "name": "file",
                         "in": "formData",
                         "required": true
-                    }
-                ],
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -455,6 +599,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -470,8 +620,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/squad-members": {
+        "/batch-import/match-games": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -481,19 +639,43 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import squad members",
+                "summary": "Batch import match games",
                 "parameters": [
                     {
-                        "description": "Squad members to import",
+                        "description": "Match games to import",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/service.SquadMemberImportInput"
+                                "$ref": "#/definitions/service.MatchGameImportInput"
                             }
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -503,6 +685,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -518,8 +706,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/squad-members/csv": {
+        "/batch-import/match-games/csv": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -529,7 +725,7 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import squad members from CSV",
+                "summary": "Batch import match games from CSV",
                 "parameters": [
                     {
                         "type": "file",
@@ -537,6 +733,30 @@ const docTemplate = `{
                         "name": "file",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -546,6 +766,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -561,8 +787,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/team-profiles": {
+        "/batch-import/matches": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -572,19 +806,37 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import team profiles",
+                "summary": "Batch import matches",
                 "parameters": [
                     {
-                        "description": "Team profiles to import",
+                        "description": "Matches to import",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/service.TeamProfileImportInput"
+                                "$ref": "#/definitions/service.MatchImportInput"
                             }
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -594,6 +846,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -609,8 +867,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/team-profiles/csv": {
+        "/batch-import/matches/csv": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -620,7 +886,7 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import team profiles from CSV",
+                "summary": "Batch import matches from CSV",
                 "parameters": [
                     {
                         "type": "file",
@@ -628,6 +894,24 @@ const docTemplate = `{
                         "name": "file",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -637,6 +921,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -652,8 +942,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/teams": {
+        "/batch-import/matches/nested": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -663,26 +961,23 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import teams",
+                "summary": "Import a match with its games and player stats",
                 "parameters": [
                     {
-                        "description": "Teams to import",
+                        "description": "Match with nested games and player stats",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/service.TeamImportInput"
-                            }
+                            "$ref": "#/definitions/service.MatchDocumentInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                            "$ref": "#/definitions/api.MatchDocumentResponse"
                         }
                     },
                     "400": {
@@ -691,51 +986,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/batch-import/teams/csv": {
-            "post": {
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Utility"
-                ],
-                "summary": "Batch import teams from CSV",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "CSV file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.ImportSummaryResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -743,8 +1001,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/tournament-registrations": {
+        "/batch-import/players": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -754,19 +1020,43 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import tournament registrations",
+                "summary": "Batch import players",
                 "parameters": [
                     {
-                        "description": "Registrations to import",
+                        "description": "Players to import",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/service.TournamentRegistrationImportInput"
+                                "$ref": "#/definitions/service.PlayerImportInput"
                             }
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -776,6 +1066,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -791,8 +1087,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/tournament-registrations/csv": {
+        "/batch-import/players/csv": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -802,7 +1106,7 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import tournament registrations from CSV",
+                "summary": "Batch import players from CSV",
                 "parameters": [
                     {
                         "type": "file",
@@ -810,6 +1114,30 @@ const docTemplate = `{
                         "name": "file",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -819,6 +1147,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -834,9 +1168,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/tournaments": {
+        "/batch-import/squad-members": {
             "post": {
-                "consumes": [
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
                     "application/json"
                 ],
                 "produces": [
@@ -845,19 +1187,37 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import tournaments",
+                "summary": "Batch import squad members",
                 "parameters": [
                     {
-                        "description": "Tournaments to import",
+                        "description": "Squad members to import",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/service.TournamentImportInput"
+                                "$ref": "#/definitions/service.SquadMemberImportInput"
                             }
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -867,6 +1227,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -882,8 +1248,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/batch-import/tournaments/csv": {
+        "/batch-import/squad-members/csv": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -893,7 +1267,7 @@ const docTemplate = `{
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Batch import tournaments from CSV",
+                "summary": "Batch import squad members from CSV",
                 "parameters": [
                     {
                         "type": "file",
@@ -901,6 +1275,24 @@ const docTemplate = `{
                         "name": "file",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -910,6 +1302,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -925,38 +1323,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/disciplines": {
-            "get": {
+        "/batch-import/stream/{entity}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rows are decoded and written in chunks while the upload is read, so memory use does not grow with the file. Send the file as the request body or as the multipart field \"file\". CSV columns match the JSON batch import fields.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
+                    "Utility"
                 ],
-                "summary": "List disciplines",
+                "summary": "Stream a large CSV or NDJSON import",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search by name or code",
-                        "name": "search",
+                        "description": "players, disciplines, teams, tournaments, tournament-registrations, matches, match-games, game-player-stats, squad-members or team-profiles",
+                        "name": "entity",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or ndjson; taken from the content type or file name when omitted",
+                        "name": "format",
                         "in": "query"
                     },
+                    {
+                        "type": "file",
+                        "description": "CSV or NDJSON file",
+                        "name": "file",
+                        "in": "formData"
+                    },
                     {
                         "type": "boolean",
-                        "description": "Filter by active flag",
-                        "name": "is_active",
+                        "description": "Roll back the whole file if any row fails",
+                        "name": "atomic",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "limit",
+                        "type": "boolean",
+                        "description": "Validate the file in a rolled-back transaction",
+                        "name": "dry_run",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
+                        "type": "boolean",
+                        "description": "Keep the exported status of new tournaments and registrations, to load an export back",
+                        "name": "restore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "error (default), skip or update for rows whose natural key exists",
+                        "name": "on_conflict",
                         "in": "query"
                     }
                 ],
@@ -964,7 +1395,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.DisciplineListResponse"
+                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "500": {
@@ -974,8 +1417,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/batch-import/team-profiles": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -983,25 +1436,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
+                    "Utility"
                 ],
-                "summary": "Create discipline",
+                "summary": "Batch import team profiles",
                 "parameters": [
                     {
-                        "description": "Discipline payload",
+                        "description": "Team profiles to import",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.disciplineRequest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.TeamProfileImportInput"
+                            }
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.DisciplineResponse"
+                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
                         }
                     },
                     "400": {
@@ -1009,33 +1495,80 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/disciplines/{id}": {
-            "get": {
+        "/batch-import/team-profiles/csv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
+                    "Utility"
                 ],
-                "summary": "Get discipline",
+                "summary": "Batch import team profiles from CSV",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Discipline ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.DisciplineResponse"
+                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
                         }
                     },
                     "400": {
@@ -1044,15 +1577,25 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/batch-import/teams": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1060,32 +1603,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
+                    "Utility"
                 ],
-                "summary": "Update discipline",
+                "summary": "Batch import teams",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Discipline ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Discipline payload",
+                        "description": "Teams to import",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.disciplineRequest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.TeamImportInput"
+                            }
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.DisciplineResponse"
+                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
                         }
                     },
                     "400": {
@@ -1094,36 +1663,79 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/batch-import/teams/csv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disciplines"
+                    "Utility"
                 ],
-                "summary": "Delete discipline",
+                "summary": "Batch import teams from CSV",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Discipline ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.EmptyResponse"
+                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
                         }
                     },
                     "400": {
@@ -1132,8 +1744,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -1141,50 +1753,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/game-player-stats": {
-            "get": {
+        "/batch-import/tournament-registrations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "GamePlayerStats"
+                    "Utility"
                 ],
-                "summary": "List game player stats",
+                "summary": "Batch import tournament registrations",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Game ID",
-                        "name": "game_id",
-                        "in": "query"
+                        "description": "Registrations to import",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.TournamentRegistrationImportInput"
+                            }
+                        }
                     },
                     {
-                        "type": "integer",
-                        "description": "Player ID",
-                        "name": "player_id",
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Team ID",
-                        "name": "team_id",
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
                         "in": "query"
                     },
                     {
                         "type": "boolean",
-                        "description": "Filter by MVP",
-                        "name": "was_mvp",
+                        "description": "Keep the exported status of new rows, to load an export back",
+                        "name": "restore",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
                         "in": "query"
                     }
                 ],
@@ -1192,44 +1821,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.GamePlayerStatListResponse"
+                            "$ref": "#/definitions/api.ImportSummaryResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "GamePlayerStats"
-                ],
-                "summary": "Create game player stats",
-                "parameters": [
-                    {
-                        "description": "Game player stats payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.gamePlayerStatRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/api.GamePlayerStatResponse"
+                            "$ref": "#/definitions/api.ImportJobResponse"
                         }
                     },
                     "400": {
@@ -1237,33 +1835,86 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/game-player-stats/{id}": {
-            "get": {
+        "/batch-import/tournament-registrations/csv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "GamePlayerStats"
+                    "Utility"
                 ],
-                "summary": "Get game player stats",
+                "summary": "Batch import tournament registrations from CSV",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Stat ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Keep the exported status of new rows, to load an export back",
+                        "name": "restore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Existing rows: error (default), skip or update",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.GamePlayerStatResponse"
+                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
                         }
                     },
                     "400": {
@@ -1272,15 +1923,25 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/batch-import/tournaments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1288,32 +1949,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "GamePlayerStats"
+                    "Utility"
                 ],
-                "summary": "Update game player stats",
+                "summary": "Batch import tournaments",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Stat ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Game player stats payload",
+                        "description": "Tournaments to import",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.gamePlayerStatRequest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.TournamentImportInput"
+                            }
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Keep the exported status of new rows, to load an export back",
+                        "name": "restore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.GamePlayerStatResponse"
+                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
                         }
                     },
                     "400": {
@@ -1322,36 +2009,79 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/batch-import/tournaments/csv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "GamePlayerStats"
+                    "Utility"
                 ],
-                "summary": "Delete game player stats",
+                "summary": "Batch import tournaments from CSV",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Stat ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the whole batch if any row fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the batch without saving anything",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Keep the exported status of new rows, to load an export back",
+                        "name": "restore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Queue the batch as a background import job",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.EmptyResponse"
+                            "$ref": "#/definitions/api.ImportSummaryResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
                         }
                     },
                     "400": {
@@ -1360,8 +2090,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -1369,26 +2099,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/match-games": {
+        "/disciplines": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "MatchGames"
+                    "Disciplines"
                 ],
-                "summary": "List match games",
+                "summary": "List disciplines",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Match ID",
-                        "name": "match_id",
+                        "type": "string",
+                        "description": "Search by name or code",
+                        "name": "search",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Winner team ID",
-                        "name": "winner_team_id",
+                        "type": "boolean",
+                        "description": "Filter by active flag",
+                        "name": "is_active",
                         "in": "query"
                     },
                     {
@@ -1408,7 +2146,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.MatchGameListResponse"
+                            "$ref": "#/definitions/api.DisciplineListResponse"
                         }
                     },
                     "500": {
@@ -1420,6 +2158,14 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1427,17 +2173,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "MatchGames"
+                    "Disciplines"
                 ],
-                "summary": "Create match game",
+                "summary": "Create discipline",
                 "parameters": [
                     {
-                        "description": "Match game payload",
+                        "description": "Discipline payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.matchGameRequest"
+                            "$ref": "#/definitions/api.disciplineRequest"
                         }
                     }
                 ],
@@ -1445,7 +2191,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.MatchGameResponse"
+                            "$ref": "#/definitions/api.DisciplineResponse"
                         }
                     },
                     "400": {
@@ -1453,23 +2199,43 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/match-games/{id}": {
+        "/disciplines/{id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "MatchGames"
+                    "Disciplines"
                 ],
-                "summary": "Get match game",
+                "summary": "Get discipline",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Match game ID",
+                        "description": "Discipline ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1479,7 +2245,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.MatchGameResponse"
+                            "$ref": "#/definitions/api.DisciplineResponse"
                         }
                     },
                     "400": {
@@ -1497,6 +2263,14 @@ const docTemplate = `{
                 }
             },
             "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1504,24 +2278,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "MatchGames"
+                    "Disciplines"
                 ],
-                "summary": "Update match game",
+                "summary": "Update discipline",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Match game ID",
+                        "description": "Discipline ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Match game payload",
+                        "description": "Discipline payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.matchGameRequest"
+                            "$ref": "#/definitions/api.disciplineRequest"
                         }
                     }
                 ],
@@ -1529,7 +2303,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.MatchGameResponse"
+                            "$ref": "#/definitions/api.DisciplineResponse"
                         }
                     },
                     "400": {
@@ -1543,21 +2317,41 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             },
             "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "MatchGames"
+                    "Disciplines"
                 ],
-                "summary": "Delete match game",
+                "summary": "Delete discipline",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Match game ID",
+                        "description": "Discipline ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1581,54 +2375,131 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/matches": {
+        "/export/{entity}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams every row of the entity. CSV and NDJSON rows have the fields of the batch import, with references by code, tag, nickname or name instead of id, so the file can be imported into another instance; import tournaments and registrations with restore=true to keep their status. A SQL dump holds the stored rows, ids included.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/sql"
                 ],
                 "tags": [
-                    "Matches"
+                    "Export"
                 ],
-                "summary": "List matches",
+                "summary": "Export an entity",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "players, disciplines, teams, tournaments, tournament-registrations, matches, match-games, game-player-stats, squad-members or team-profiles",
+                        "name": "entity",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv (default), ndjson or sql",
+                        "name": "format",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Tournament ID",
+                        "description": "Only export the data of this tournament",
                         "name": "tournament_id",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/game-player-stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
                     },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GamePlayerStats"
+                ],
+                "summary": "List game player stats",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "Team ID",
-                        "name": "team_id",
+                        "description": "Game ID",
+                        "name": "game_id",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Stage",
-                        "name": "stage",
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "player_id",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Format",
-                        "name": "format",
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "team_id",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "From datetime (RFC3339)",
-                        "name": "from",
+                        "type": "boolean",
+                        "description": "Filter by MVP",
+                        "name": "was_mvp",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "To datetime (RFC3339)",
-                        "name": "to",
+                        "type": "boolean",
+                        "description": "Filter by substitutes missing from the locked roster",
+                        "name": "is_unregistered_sub",
                         "in": "query"
                     },
                     {
@@ -1648,7 +2519,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.MatchListResponse"
+                            "$ref": "#/definitions/api.GamePlayerStatListResponse"
                         }
                     },
                     "500": {
@@ -1660,6 +2531,14 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1667,17 +2546,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Matches"
+                    "GamePlayerStats"
                 ],
-                "summary": "Create match",
+                "summary": "Create game player stats",
                 "parameters": [
                     {
-                        "description": "Match payload",
+                        "description": "Game player stats payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.matchRequest"
+                            "$ref": "#/definitions/api.gamePlayerStatRequest"
                         }
                     }
                 ],
@@ -1685,7 +2564,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.MatchResponse"
+                            "$ref": "#/definitions/api.GamePlayerStatResponse"
                         }
                     },
                     "400": {
@@ -1693,23 +2572,43 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/matches/{id}": {
+        "/game-player-stats/{id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Matches"
+                    "GamePlayerStats"
                 ],
-                "summary": "Get match",
+                "summary": "Get game player stats",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Match ID",
+                        "description": "Stat ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1719,7 +2618,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.MatchResponse"
+                            "$ref": "#/definitions/api.GamePlayerStatResponse"
                         }
                     },
                     "400": {
@@ -1737,6 +2636,14 @@ const docTemplate = `{
                 }
             },
             "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1744,24 +2651,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Matches"
+                    "GamePlayerStats"
                 ],
-                "summary": "Update match",
+                "summary": "Update game player stats",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Match ID",
+                        "description": "Stat ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Match payload",
+                        "description": "Game player stats payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.matchRequest"
+                            "$ref": "#/definitions/api.gamePlayerStatRequest"
                         }
                     }
                 ],
@@ -1769,7 +2676,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.MatchResponse"
+                            "$ref": "#/definitions/api.GamePlayerStatResponse"
                         }
                     },
                     "400": {
@@ -1783,21 +2690,41 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             },
             "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Matches"
+                    "GamePlayerStats"
                 ],
-                "summary": "Delete match",
+                "summary": "Delete game player stats",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Match ID",
+                        "description": "Stat ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1821,48 +2748,50 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/players": {
+        "/import-errors": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Players"
+                    "Utility"
                 ],
-                "summary": "List players",
+                "summary": "List logged import errors",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search by nickname",
-                        "name": "search",
+                        "description": "Source, e.g. players_csv or game_player_stats_stream",
+                        "name": "source",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Country code",
-                        "name": "country_code",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Retired flag",
-                        "name": "is_retired",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Min MMR",
-                        "name": "min_mmr",
+                        "description": "From (YYYY-MM-DD or RFC 3339)",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "type": "number",
-                        "description": "Max MMR",
-                        "name": "max_mmr",
+                        "type": "string",
+                        "description": "To (YYYY-MM-DD, inclusive, or RFC 3339)",
+                        "name": "to",
                         "in": "query"
                     },
                     {
@@ -1882,7 +2811,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.PlayerListResponse"
+                            "$ref": "#/definitions/api.ImportErrorListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1892,34 +2827,44 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/import-errors/purge": {
             "post": {
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Players"
+                    "Utility"
                 ],
-                "summary": "Create player",
+                "summary": "Purge old import errors",
                 "parameters": [
                     {
-                        "description": "Player payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.playerRequest"
-                        }
+                        "type": "string",
+                        "description": "Only purge errors of this source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Age in days; the configured retention when omitted",
+                        "name": "older_than_days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.PlayerResponse"
+                            "$ref": "#/definitions/api.ImportPurgeResponse"
                         }
                     },
                     "400": {
@@ -1927,33 +2872,59 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/players/{id}": {
-            "get": {
+        "/import-errors/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Imports the stored rows again. Rows that import are removed from the log; the others keep their row and get the new error. Each error is retried in its own transaction; one whose retry could not run is reported with status error.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Players"
+                    "Utility"
                 ],
-                "summary": "Get player",
+                "summary": "Retry logged import errors",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Player ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Errors to retry",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.importErrorRetryRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "error (default), skip or update for rows whose natural key exists",
+                        "name": "on_conflict",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.PlayerResponse"
+                            "$ref": "#/definitions/api.ImportRetryResponse"
                         }
                     },
                     "400": {
@@ -1962,48 +2933,46 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/import-errors/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Players"
+                    "Utility"
                 ],
-                "summary": "Update player",
+                "summary": "Get a logged import error with its row data",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Player ID",
+                        "description": "Import error ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Player payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.playerRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.PlayerResponse"
+                            "$ref": "#/definitions/api.ImportErrorResponse"
                         }
                     },
                     "400": {
@@ -2020,28 +2989,48 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Players"
+                    "Utility"
                 ],
-                "summary": "Delete player",
+                "summary": "Fix the row data of a logged import error",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Player ID",
+                        "description": "Import error ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Corrected row, in the JSON form of the batch import",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.importErrorFixRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.EmptyResponse"
+                            "$ref": "#/definitions/api.ImportErrorResponse"
                         }
                     },
                     "400": {
@@ -2059,16 +3048,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/reports/active-rosters": {
+        "/import-jobs": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Active roster report",
+                "summary": "List import jobs",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status: queued, running, completed, failed or cancelled",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity, e.g. players or game-player-stats",
+                        "name": "entity",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "Page size",
@@ -2086,7 +3095,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.ActiveRostersResponse"
+                            "$ref": "#/definitions/api.ImportJobListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "500": {
@@ -2098,111 +3113,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/reports/match-results": {
+        "/import-jobs/{id}": {
             "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Utility"
-                ],
-                "summary": "Match results report",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Tournament ID",
-                        "name": "tournament_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.MatchResultsResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/reports/player-career": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Utility"
-                ],
-                "summary": "Player career report",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Search by nickname",
-                        "name": "search",
-                        "in": "query"
-                    },
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "limit",
-                        "in": "query"
+                        "BearerAuth": []
                     },
                     {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
-                        "in": "query"
+                        "ApiKeyAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.PlayerCareerResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/reports/player-kda": {
-            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Player KDA report",
+                "summary": "Get import job progress",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Player ID",
-                        "name": "player_id",
-                        "in": "query",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -2210,7 +3143,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.PlayerKDAResponse"
+                            "$ref": "#/definitions/api.ImportJobResponse"
                         }
                     },
                     "400": {
@@ -2219,8 +3152,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -2228,21 +3161,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/reports/tournament-standings": {
-            "get": {
+        "/import-jobs/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Utility"
                 ],
-                "summary": "Tournament standings report",
+                "summary": "Cancel a queued or running import job",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tournament ID",
-                        "name": "tournament_id",
-                        "in": "query",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -2250,7 +3191,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TournamentStandingsResponse"
+                            "$ref": "#/definitions/api.ImportJobResponse"
                         }
                     },
                     "400": {
@@ -2259,8 +3200,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -2268,32 +3215,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/squad-members": {
+        "/match-games": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SquadMembers"
+                    "MatchGames"
                 ],
-                "summary": "List squad members",
+                "summary": "List match games",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Team ID",
-                        "name": "team_id",
+                        "description": "Match ID",
+                        "name": "match_id",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Player ID",
-                        "name": "player_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Only active",
-                        "name": "active_only",
+                        "description": "Winner team ID",
+                        "name": "winner_team_id",
                         "in": "query"
                     },
                     {
@@ -2313,7 +3262,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.SquadMemberListResponse"
+                            "$ref": "#/definitions/api.MatchGameListResponse"
                         }
                     },
                     "500": {
@@ -2325,6 +3274,14 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -2332,17 +3289,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SquadMembers"
+                    "MatchGames"
                 ],
-                "summary": "Add player to squad",
+                "summary": "Create match game",
                 "parameters": [
                     {
-                        "description": "Squad member payload",
+                        "description": "Match game payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.squadMemberRequest"
+                            "$ref": "#/definitions/api.matchGameRequest"
                         }
                     }
                 ],
@@ -2350,7 +3307,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.SquadMemberResponse"
+                            "$ref": "#/definitions/api.MatchGameResponse"
                         }
                     },
                     "400": {
@@ -2358,23 +3315,43 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/squad-members/{id}": {
+        "/match-games/{id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SquadMembers"
+                    "MatchGames"
                 ],
-                "summary": "Get squad member",
+                "summary": "Get match game",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Squad member ID",
+                        "description": "Match game ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2384,7 +3361,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.SquadMemberResponse"
+                            "$ref": "#/definitions/api.MatchGameResponse"
                         }
                     },
                     "400": {
@@ -2402,6 +3379,14 @@ const docTemplate = `{
                 }
             },
             "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -2409,24 +3394,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SquadMembers"
+                    "MatchGames"
                 ],
-                "summary": "Update squad member",
+                "summary": "Update match game",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Squad member ID",
+                        "description": "Match game ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Squad member payload",
+                        "description": "Match game payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.squadMemberRequest"
+                            "$ref": "#/definitions/api.matchGameRequest"
                         }
                     }
                 ],
@@ -2434,7 +3419,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.SquadMemberResponse"
+                            "$ref": "#/definitions/api.MatchGameResponse"
                         }
                     },
                     "400": {
@@ -2448,21 +3433,41 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             },
             "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SquadMembers"
+                    "MatchGames"
                 ],
-                "summary": "Remove squad member",
+                "summary": "Delete match game",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Squad member ID",
+                        "description": "Match game ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2486,55 +3491,107 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/team-profiles": {
+        "/matches": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TeamProfiles"
+                    "Matches"
                 ],
-                "summary": "List team profiles",
+                "summary": "List matches",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Team ID",
-                        "name": "team_id",
+                        "description": "Tournament ID",
+                        "name": "tournament_id",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Page size",
-                        "name": "limit",
+                        "description": "Team ID",
+                        "name": "team_id",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
+                        "type": "string",
+                        "description": "Stage",
+                        "name": "stage",
                         "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.TeamProfileListResponse"
-                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
+                    {
+                        "type": "string",
+                        "description": "Format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From datetime (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To datetime (RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MatchListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -2542,17 +3599,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TeamProfiles"
+                    "Matches"
                 ],
-                "summary": "Create team profile",
+                "summary": "Create match",
                 "parameters": [
                     {
-                        "description": "Team profile payload",
+                        "description": "Match payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.teamProfileRequest"
+                            "$ref": "#/definitions/api.matchRequest"
                         }
                     }
                 ],
@@ -2560,7 +3617,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.TeamProfileResponse"
+                            "$ref": "#/definitions/api.MatchResponse"
                         }
                     },
                     "400": {
@@ -2568,24 +3625,44 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/team-profiles/{team_id}": {
+        "/matches/{id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TeamProfiles"
+                    "Matches"
                 ],
-                "summary": "Get team profile",
+                "summary": "Get match",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Team ID",
-                        "name": "team_id",
+                        "description": "Match ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -2594,7 +3671,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TeamProfileResponse"
+                            "$ref": "#/definitions/api.MatchResponse"
                         }
                     },
                     "400": {
@@ -2612,6 +3689,14 @@ const docTemplate = `{
                 }
             },
             "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -2619,24 +3704,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TeamProfiles"
+                    "Matches"
                 ],
-                "summary": "Update team profile",
+                "summary": "Update match",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Team ID",
-                        "name": "team_id",
+                        "description": "Match ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Team profile payload",
+                        "description": "Match payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.teamProfileRequest"
+                            "$ref": "#/definitions/api.matchRequest"
                         }
                     }
                 ],
@@ -2644,7 +3729,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TeamProfileResponse"
+                            "$ref": "#/definitions/api.MatchResponse"
                         }
                     },
                     "400": {
@@ -2658,22 +3743,42 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             },
             "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TeamProfiles"
+                    "Matches"
                 ],
-                "summary": "Delete team profile",
+                "summary": "Delete match",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Team ID",
-                        "name": "team_id",
+                        "description": "Match ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -2696,23 +3801,37 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/teams": {
+        "/players": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "Players"
                 ],
-                "summary": "List teams",
+                "summary": "List players",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search by name or tag",
+                        "description": "Search by nickname",
                         "name": "search",
                         "in": "query"
                     },
@@ -2723,15 +3842,21 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Discipline ID",
-                        "name": "discipline_id",
+                        "type": "boolean",
+                        "description": "Retired flag",
+                        "name": "is_retired",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Verification flag",
-                        "name": "is_verified",
+                        "type": "number",
+                        "description": "Min MMR",
+                        "name": "min_mmr",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Max MMR",
+                        "name": "max_mmr",
                         "in": "query"
                     },
                     {
@@ -2751,7 +3876,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TeamListResponse"
+                            "$ref": "#/definitions/api.PlayerListResponse"
                         }
                     },
                     "500": {
@@ -2763,6 +3888,14 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -2770,17 +3903,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "Players"
                 ],
-                "summary": "Create team",
+                "summary": "Create player",
                 "parameters": [
                     {
-                        "description": "Team payload",
+                        "description": "Player payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.teamRequest"
+                            "$ref": "#/definitions/api.playerRequest"
                         }
                     }
                 ],
@@ -2788,7 +3921,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.TeamResponse"
+                            "$ref": "#/definitions/api.PlayerResponse"
                         }
                     },
                     "400": {
@@ -2796,23 +3929,43 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/teams/{id}": {
+        "/players/{id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "Players"
                 ],
-                "summary": "Get team",
+                "summary": "Get player",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Team ID",
+                        "description": "Player ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2822,7 +3975,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TeamResponse"
+                            "$ref": "#/definitions/api.PlayerResponse"
                         }
                     },
                     "400": {
@@ -2840,6 +3993,14 @@ const docTemplate = `{
                 }
             },
             "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -2847,24 +4008,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "Players"
                 ],
-                "summary": "Update team",
+                "summary": "Update player",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Team ID",
+                        "description": "Player ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Team payload",
+                        "description": "Player payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.teamRequest"
+                            "$ref": "#/definitions/api.playerRequest"
                         }
                     }
                 ],
@@ -2872,7 +4033,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TeamResponse"
+                            "$ref": "#/definitions/api.PlayerResponse"
                         }
                     },
                     "400": {
@@ -2886,21 +4047,41 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             },
             "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "Players"
                 ],
-                "summary": "Delete team",
+                "summary": "Delete player",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Team ID",
+                        "description": "Player ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2924,48 +4105,63 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/tournament-registrations": {
+        "/players/{id}/rating-history": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TournamentRegistrations"
+                    "Ratings"
                 ],
-                "summary": "List tournament registrations",
+                "summary": "Player rating history",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tournament ID",
-                        "name": "tournament_id",
-                        "in": "query"
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Team ID",
-                        "name": "team_id",
+                        "description": "Discipline ID",
+                        "name": "discipline_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Status",
-                        "name": "status",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD)",
+                        "name": "to",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
+                        "type": "string",
+                        "description": "Downsampling: daily or weekly",
+                        "name": "interval",
                         "in": "query"
                     }
                 ],
@@ -2973,48 +4169,70 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TournamentRegistrationListResponse"
+                            "$ref": "#/definitions/api.RatingHistoryResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/ratings/players": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TournamentRegistrations"
+                    "Ratings"
                 ],
-                "summary": "Create tournament registration",
+                "summary": "Player rating leaderboard",
                 "parameters": [
                     {
-                        "description": "Registration payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.tournamentRegistrationRequest"
-                        }
+                        "type": "integer",
+                        "description": "Discipline ID",
+                        "name": "discipline_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TournamentRegistrationResponse"
+                            "$ref": "#/definitions/api.PlayerRatingListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -3022,127 +4240,152 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournament-registrations/{id}": {
+        "/ratings/teams": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TournamentRegistrations"
+                    "Ratings"
                 ],
-                "summary": "Get tournament registration",
+                "summary": "Team rating leaderboard",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Registration ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Discipline ID",
+                        "name": "discipline_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TournamentRegistrationResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
+                            "$ref": "#/definitions/api.TeamRatingListResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/reports/active-rosters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TournamentRegistrations"
+                    "Utility"
                 ],
-                "summary": "Update tournament registration",
+                "summary": "Active roster report",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Registration ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Registration payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.tournamentRegistrationRequest"
-                        }
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TournamentRegistrationResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
+                            "$ref": "#/definitions/api.ActiveRostersResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/reports/match-results": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TournamentRegistrations"
+                    "Utility"
                 ],
-                "summary": "Delete tournament registration",
+                "summary": "Match results report",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Registration ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Tournament ID",
+                        "name": "tournament_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "$ref": "#/definitions/api.EmptyResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
+                            "$ref": "#/definitions/api.MatchResultsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -3150,46 +4393,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournaments": {
+        "/reports/player-career": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tournaments"
+                    "Utility"
                 ],
-                "summary": "List tournaments",
+                "summary": "Player career report",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search by name",
+                        "description": "Search by nickname",
                         "name": "search",
                         "in": "query"
                     },
-                    {
-                        "type": "integer",
-                        "description": "Discipline ID",
-                        "name": "discipline_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Tournament status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date from (YYYY-MM-DD)",
-                        "name": "start_from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date to (YYYY-MM-DD)",
-                        "name": "start_to",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
                         "description": "Page size",
@@ -3207,7 +4434,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TournamentListResponse"
+                            "$ref": "#/definitions/api.PlayerCareerResponse"
                         }
                     },
                     "500": {
@@ -3217,34 +4444,104 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/reports/player-kda": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tournaments"
+                    "Utility"
                 ],
-                "summary": "Create tournament",
+                "summary": "Player KDA report",
                 "parameters": [
                     {
-                        "description": "Tournament payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "player_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.tournamentRequest"
+                            "$ref": "#/definitions/api.PlayerKDAResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/roster-health": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Utility"
+                ],
+                "summary": "Roster health report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Discipline ID",
+                        "name": "discipline_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "short_handed, over_staffed or ok; teams with issues by default",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TournamentResponse"
+                            "$ref": "#/definitions/api.RosterHealthResponse"
                         }
                     },
                     "400": {
@@ -3252,33 +4549,71 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/tournaments/{id}": {
+        "/reports/tournament-standings": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tournaments"
+                    "Utility"
                 ],
-                "summary": "Get tournament",
+                "summary": "Tournament standings report",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Tournament ID",
-                        "name": "id",
-                        "in": "path",
+                        "name": "tournament_id",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Match stage",
+                        "name": "stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Points for a win",
+                        "name": "points_win",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Points for a draw",
+                        "name": "points_draw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Points for a loss",
+                        "name": "points_loss",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TournamentResponse"
+                            "$ref": "#/definitions/api.TournamentStandingsResponse"
                         }
                     },
                     "400": {
@@ -3292,43 +4627,116 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/squad-members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "salary_monthly and contract_end_date are null unless the user is an organizer or manages the team.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tournaments"
+                    "SquadMembers"
                 ],
-                "summary": "Update tournament",
+                "summary": "List squad members",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tournament ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Team ID",
+                        "name": "team_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Tournament payload",
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "player_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only active",
+                        "name": "active_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SquadMemberListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SquadMembers"
+                ],
+                "summary": "Add player to squad",
+                "parameters": [
+                    {
+                        "description": "Squad member payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.tournamentRequest"
+                            "$ref": "#/definitions/api.squadMemberRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.TournamentResponse"
+                            "$ref": "#/definitions/api.SquadMemberResponse"
                         }
                     },
                     "400": {
@@ -3337,36 +4745,53 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/squad-members/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "salary_monthly and contract_end_date are null unless the user is an organizer or manages the team.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tournaments"
+                    "SquadMembers"
                 ],
-                "summary": "Delete tournament",
+                "summary": "Get squad member",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tournament ID",
+                        "description": "Squad member ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.EmptyResponse"
+                            "$ref": "#/definitions/api.SquadMemberResponse"
                         }
                     },
                     "400": {
@@ -3382,31 +4807,2869 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "api.ActiveRostersResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.ActiveRosterView"
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
                     }
-                },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SquadMembers"
+                ],
+                "summary": "Update squad member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Squad member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Squad member payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.squadMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SquadMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SquadMembers"
+                ],
+                "summary": "Remove squad member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Squad member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team-profiles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TeamProfiles"
+                ],
+                "summary": "List team profiles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "team_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TeamProfileListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TeamProfiles"
+                ],
+                "summary": "Create team profile",
+                "parameters": [
+                    {
+                        "description": "Team profile payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.teamProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.TeamProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team-profiles/{team_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TeamProfiles"
+                ],
+                "summary": "Get team profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "team_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TeamProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TeamProfiles"
+                ],
+                "summary": "Update team profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "team_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Team profile payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.teamProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TeamProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TeamProfiles"
+                ],
+                "summary": "Delete team profile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "team_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "List teams",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by name or tag",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country code",
+                        "name": "country_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Discipline ID",
+                        "name": "discipline_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Verification flag",
+                        "name": "is_verified",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TeamListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Create team",
+                "parameters": [
+                    {
+                        "description": "Team payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.teamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Get team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Update team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Team payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.teamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Delete team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}/rating-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ratings"
+                ],
+                "summary": "Team rating history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Downsampling: daily or weekly",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RatingHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament-registrations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TournamentRegistrations"
+                ],
+                "summary": "List tournament registrations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "tournament_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "team_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TournamentRegistrationListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TournamentRegistrations"
+                ],
+                "summary": "Create tournament registration",
+                "parameters": [
+                    {
+                        "description": "Registration payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.tournamentRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.TournamentRegistrationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament-registrations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TournamentRegistrations"
+                ],
+                "summary": "Get tournament registration",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TournamentRegistrationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TournamentRegistrations"
+                ],
+                "summary": "Update tournament registration",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Registration payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.tournamentRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TournamentRegistrationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TournamentRegistrations"
+                ],
+                "summary": "Delete tournament registration",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament-registrations/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TournamentRegistrations"
+                ],
+                "summary": "Approve tournament registration",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RegistrationStatusChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament-registrations/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TournamentRegistrations"
+                ],
+                "summary": "Reject tournament registration",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RegistrationStatusChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament-registrations/{id}/roster-snapshot": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TournamentRegistrations"
+                ],
+                "summary": "Recapture registration roster snapshot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TournamentRegistrationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament-registrations/{id}/withdraw": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TournamentRegistrations"
+                ],
+                "summary": "Withdraw tournament registration",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RegistrationStatusChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournaments"
+                ],
+                "summary": "List tournaments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Discipline ID",
+                        "name": "discipline_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tournament status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date from (YYYY-MM-DD)",
+                        "name": "start_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date to (YYYY-MM-DD)",
+                        "name": "start_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TournamentListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournaments"
+                ],
+                "summary": "Create tournament",
+                "parameters": [
+                    {
+                        "description": "Tournament payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.tournamentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.TournamentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournaments"
+                ],
+                "summary": "Get tournament",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TournamentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournaments"
+                ],
+                "summary": "Update tournament",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tournament payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.tournamentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TournamentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournaments"
+                ],
+                "summary": "Delete tournament",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{id}/bracket": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Brackets"
+                ],
+                "summary": "Get tournament bracket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.BracketResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{id}/bracket/generate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Brackets"
+                ],
+                "summary": "Generate tournament bracket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.BracketResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{id}/bracket/next-round": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Brackets"
+                ],
+                "summary": "Generate next swiss round",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.BracketResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Exports the tournament with its discipline, teams, team profiles, players, squad members, registrations, matches, games and player stats from one snapshot. CSV and NDJSON come as a zip with one file per entity, named in import order, to be imported with restore=true; sql is a single dump.",
+                "produces": [
+                    "application/zip",
+                    "application/sql"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Export a whole tournament",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv (default), ndjson or sql",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{id}/status-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournaments"
+                ],
+                "summary": "Tournament status history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TournamentStatusHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{id}/transition": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tournaments"
+                ],
+                "summary": "Change tournament status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tournament ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target status",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.tournamentTransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TournamentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Roles: viewer, team_manager (with the team_id it manages), organizer, admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create user",
+                "parameters": [
+                    {
+                        "description": "User payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.userRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.userRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/api.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.APIKeyListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.APIKey"
+                    }
+                },
+                "meta": {}
+            }
+        },
+        "api.ActiveRostersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ActiveRosterView"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.BracketResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.BracketView"
+                },
+                "meta": {}
+            }
+        },
+        "api.CreatedAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/service.CreatedAPIKey"
+                },
+                "meta": {}
+            }
+        },
+        "api.DisciplineListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Discipline"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.DisciplineResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Discipline"
+                },
+                "meta": {}
+            }
+        },
+        "api.EmptyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "meta": {}
+            }
+        },
+        "api.ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code, Field and Constraint describe a rejected value, e.g. a unique or\nforeign key violation.",
+                    "type": "string"
+                },
+                "constraint": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/api.ErrorDetail"
+                }
+            }
+        },
+        "api.GamePlayerStatListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GamePlayerStat"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.GamePlayerStatResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.GamePlayerStat"
+                },
+                "meta": {}
+            }
+        },
+        "api.ImportErrorListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportError"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.ImportErrorResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ImportError"
+                },
+                "meta": {}
+            }
+        },
+        "api.ImportJobListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportJob"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.ImportJobResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ImportJob"
+                },
+                "meta": {}
+            }
+        },
+        "api.ImportPurgeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/service.ImportPurgeResult"
+                },
+                "meta": {}
+            }
+        },
+        "api.ImportRetryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ImportRetryResult"
+                    }
+                },
+                "meta": {}
+            }
+        },
+        "api.ImportSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/service.ImportSummary"
+                },
+                "meta": {}
+            }
+        },
+        "api.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/service.LoginResult"
+                },
+                "meta": {}
+            }
+        },
+        "api.MatchDocumentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/service.MatchDocumentResult"
+                },
+                "meta": {}
+            }
+        },
+        "api.MatchGameListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MatchGame"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.MatchGameResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.MatchGame"
+                },
+                "meta": {}
+            }
+        },
+        "api.MatchListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Match"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.MatchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Match"
+                },
+                "meta": {}
+            }
+        },
+        "api.MatchResultsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MatchResultView"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.PlayerCareerResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlayerCareerStats"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.PlayerKDAData": {
+            "type": "object",
+            "properties": {
+                "kda": {
+                    "type": "number"
+                },
+                "player_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.PlayerKDAResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/api.PlayerKDAData"
+                },
+                "meta": {}
+            }
+        },
+        "api.PlayerListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Player"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.PlayerRatingListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlayerRating"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.PlayerResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Player"
+                },
+                "meta": {}
+            }
+        },
+        "api.PrincipalResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/auth.Principal"
+                },
+                "meta": {}
+            }
+        },
+        "api.RatingHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RatingHistoryPoint"
+                    }
+                },
+                "meta": {}
+            }
+        },
+        "api.RatingRecomputeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RatingRecompute"
+                    }
+                },
+                "meta": {}
+            }
+        },
+        "api.RegistrationStatusChangeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.RegistrationStatusChange"
+                },
+                "meta": {}
+            }
+        },
+        "api.RosterHealthResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RosterHealthView"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.SquadMemberListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SquadMember"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.SquadMemberResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.SquadMember"
+                },
+                "meta": {}
+            }
+        },
+        "api.TeamListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Team"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.TeamProfileListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamProfile"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.TeamProfileResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.TeamProfile"
+                },
+                "meta": {}
+            }
+        },
+        "api.TeamRatingListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamRating"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.TeamResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Team"
+                },
+                "meta": {}
+            }
+        },
+        "api.TournamentListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tournament"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
+                }
+            }
+        },
+        "api.TournamentRegistrationListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TournamentRegistration"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/api.PaginationMeta"
                 }
             }
         },
-        "api.DisciplineListResponse": {
+        "api.TournamentRegistrationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.TournamentRegistration"
+                },
+                "meta": {}
+            }
+        },
+        "api.TournamentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Tournament"
+                },
+                "meta": {}
+            }
+        },
+        "api.TournamentStandingsResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Discipline"
+                        "$ref": "#/definitions/models.TournamentStanding"
+                    }
+                },
+                "meta": {}
+            }
+        },
+        "api.TournamentStatusHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TournamentStatusChange"
+                    }
+                },
+                "meta": {}
+            }
+        },
+        "api.UserListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
                     }
                 },
                 "meta": {
@@ -3414,404 +7677,818 @@ const docTemplate = `{
                 }
             }
         },
-        "api.DisciplineResponse": {
+        "api.UserResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/models.Discipline"
+                    "$ref": "#/definitions/models.User"
                 },
                 "meta": {}
             }
         },
-        "api.EmptyResponse": {
+        "api.apiKeyRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "data": {},
-                "meta": {}
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
             }
         },
-        "api.ErrorDetail": {
+        "api.disciplineRequest": {
             "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
             "properties": {
-                "message": {
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "icon_url": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "metadata": {
+                    "type": "object"
+                },
+                "name": {
                     "type": "string"
+                },
+                "team_size": {
+                    "type": "integer"
                 }
             }
         },
-        "api.ErrorResponse": {
+        "api.gamePlayerStatRequest": {
             "type": "object",
+            "required": [
+                "game_id",
+                "player_id"
+            ],
             "properties": {
-                "error": {
-                    "$ref": "#/definitions/api.ErrorDetail"
+                "assists": {
+                    "type": "integer"
+                },
+                "damage_dealt": {
+                    "type": "integer"
+                },
+                "deaths": {
+                    "type": "integer"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "gold_earned": {
+                    "type": "integer"
+                },
+                "hero_name": {
+                    "type": "string"
+                },
+                "kills": {
+                    "type": "integer"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "was_mvp": {
+                    "type": "boolean"
                 }
             }
         },
-        "api.GamePlayerStatListResponse": {
+        "api.importErrorFixRequest": {
+            "type": "object",
+            "required": [
+                "row_data"
+            ],
+            "properties": {
+                "row_data": {
+                    "type": "object"
+                }
+            }
+        },
+        "api.importErrorRetryRequest": {
             "type": "object",
+            "required": [
+                "ids"
+            ],
             "properties": {
-                "data": {
+                "ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.GamePlayerStat"
+                        "type": "integer"
                     }
+                }
+            }
+        },
+        "api.loginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.matchGameRequest": {
+            "type": "object",
+            "required": [
+                "game_number",
+                "map_name",
+                "match_id"
+            ],
+            "properties": {
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "game_number": {
+                    "type": "integer"
+                },
+                "had_technical_pause": {
+                    "type": "boolean"
+                },
+                "map_name": {
+                    "type": "string"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "pick_ban_phase": {
+                    "type": "object"
+                },
+                "score_team1": {
+                    "type": "integer"
+                },
+                "score_team2": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "winner_team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.matchRequest": {
+            "type": "object",
+            "required": [
+                "start_time",
+                "tournament_id"
+            ],
+            "properties": {
+                "format": {
+                    "type": "string"
+                },
+                "is_forfeit": {
+                    "type": "boolean"
+                },
+                "match_notes": {
+                    "type": "object"
+                },
+                "stage": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "team1_id": {
+                    "type": "integer"
+                },
+                "team2_id": {
+                    "type": "integer"
+                },
+                "tournament_id": {
+                    "type": "integer"
+                },
+                "winner_team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.playerRequest": {
+            "type": "object",
+            "required": [
+                "nickname"
+            ],
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "birth_date": {
+                    "type": "string"
+                },
+                "country_code": {
+                    "type": "string"
+                },
+                "is_retired": {
+                    "type": "boolean"
+                },
+                "mmr_rating": {
+                    "type": "number"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "real_name": {
+                    "type": "string"
+                },
+                "steam_id": {
+                    "type": "string"
                 }
             }
         },
-        "api.GamePlayerStatResponse": {
+        "api.squadMemberRequest": {
             "type": "object",
+            "required": [
+                "player_id",
+                "team_id"
+            ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.GamePlayerStat"
+                "contract_end_date": {
+                    "type": "string"
                 },
-                "meta": {}
+                "is_standin": {
+                    "type": "boolean"
+                },
+                "join_date": {
+                    "type": "string"
+                },
+                "leave_date": {
+                    "type": "string"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "salary_monthly": {
+                    "type": "number"
+                },
+                "team_id": {
+                    "type": "integer"
+                }
             }
         },
-        "api.ImportSummaryResponse": {
+        "api.teamProfileRequest": {
             "type": "object",
+            "required": [
+                "team_id"
+            ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/service.ImportSummary"
+                "coach_name": {
+                    "type": "string"
                 },
-                "meta": {}
+                "contact_email": {
+                    "type": "string"
+                },
+                "headquarters": {
+                    "type": "string"
+                },
+                "sponsor_info": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "website": {
+                    "type": "string"
+                }
             }
         },
-        "api.MatchGameListResponse": {
+        "api.teamRequest": {
             "type": "object",
+            "required": [
+                "country_code",
+                "discipline_id",
+                "name",
+                "tag"
+            ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.MatchGame"
-                    }
+                "country_code": {
+                    "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "is_verified": {
+                    "type": "boolean"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string"
+                },
+                "world_ranking": {
+                    "type": "number"
                 }
             }
         },
-        "api.MatchGameResponse": {
+        "api.tournamentRegistrationRequest": {
             "type": "object",
+            "required": [
+                "team_id",
+                "tournament_id"
+            ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.MatchGame"
+                "is_invited": {
+                    "type": "boolean"
                 },
-                "meta": {}
+                "manager_contact": {
+                    "type": "string"
+                },
+                "seed_number": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "tournament_id": {
+                    "type": "integer"
+                }
             }
         },
-        "api.MatchListResponse": {
+        "api.tournamentRequest": {
             "type": "object",
+            "required": [
+                "discipline_id",
+                "end_date",
+                "name",
+                "start_date"
+            ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Match"
-                    }
+                "bracket_config": {
+                    "type": "object"
                 },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                "currency": {
+                    "type": "string"
+                },
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "is_online": {
+                    "type": "boolean"
+                },
+                "max_teams": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prize_pool": {
+                    "type": "number"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "api.MatchResponse": {
+        "api.tournamentTransitionRequest": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.Match"
-                },
-                "meta": {}
+                "status": {
+                    "type": "string"
+                }
             }
         },
-        "api.MatchResultsResponse": {
+        "api.userRequest": {
             "type": "object",
+            "required": [
+                "role",
+                "username"
+            ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.MatchResultView"
-                    }
+                "is_active": {
+                    "type": "boolean"
                 },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                "password": {
+                    "description": "Password is required for new users; on update an empty password keeps\nthe current one.",
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "api.PaginationMeta": {
+        "auth.Principal": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer"
+                "api_key": {
+                    "description": "APIKey is the name of the key the request was authenticated with.",
+                    "type": "string"
                 },
-                "offset": {
+                "role": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "description": "TeamID is the team a team manager manages.",
                     "type": "integer"
                 },
-                "total": {
+                "user_id": {
                     "type": "integer"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "api.PlayerCareerResponse": {
+        "models.APIKey": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.PlayerCareerStats"
-                    }
+                "created_at": {
+                    "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "api.PlayerKDAData": {
+        "models.ActiveRosterView": {
             "type": "object",
             "properties": {
-                "kda": {
-                    "type": "number"
+                "country_code": {
+                    "type": "string"
+                },
+                "join_date": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
                 },
                 "player_id": {
                     "type": "integer"
-                }
-            }
-        },
-        "api.PlayerKDAResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/api.PlayerKDAData"
                 },
-                "meta": {}
+                "role": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                }
             }
         },
-        "api.PlayerListResponse": {
+        "models.BracketNode": {
             "type": "object",
             "properties": {
-                "data": {
+                "children": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Player"
+                        "$ref": "#/definitions/models.BracketNode"
                     }
                 },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                "match": {
+                    "$ref": "#/definitions/models.Match"
                 }
             }
         },
-        "api.PlayerResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.Player"
-                },
-                "meta": {}
-            }
-        },
-        "api.SquadMemberListResponse": {
+        "models.BracketRound": {
             "type": "object",
             "properties": {
-                "data": {
+                "matches": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.SquadMember"
+                        "$ref": "#/definitions/models.Match"
                     }
                 },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                "round": {
+                    "type": "integer"
+                },
+                "section": {
+                    "type": "string"
                 }
             }
         },
-        "api.SquadMemberResponse": {
+        "models.BracketView": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.SquadMember"
+                "format": {
+                    "type": "string"
                 },
-                "meta": {}
-            }
-        },
-        "api.TeamListResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
+                "roots": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Team"
+                        "$ref": "#/definitions/models.BracketNode"
                     }
                 },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
-                }
-            }
-        },
-        "api.TeamProfileListResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
+                "rounds": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.TeamProfile"
+                        "$ref": "#/definitions/models.BracketRound"
                     }
                 },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                "tournament_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "api.TeamProfileResponse": {
+        "models.Discipline": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.TeamProfile"
+                "code": {
+                    "type": "string"
                 },
-                "meta": {}
-            }
-        },
-        "api.TeamResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.Team"
+                "description": {
+                    "type": "string"
                 },
-                "meta": {}
-            }
-        },
-        "api.TournamentListResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Tournament"
-                    }
+                "icon_url": {
+                    "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "metadata": {
+                    "type": "object"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "team_size": {
+                    "type": "integer"
                 }
             }
         },
-        "api.TournamentRegistrationListResponse": {
+        "models.GamePlayerStat": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.TournamentRegistration"
-                    }
+                "assists": {
+                    "type": "integer"
                 },
-                "meta": {
-                    "$ref": "#/definitions/api.PaginationMeta"
+                "damage_dealt": {
+                    "type": "integer"
+                },
+                "deaths": {
+                    "type": "integer"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "gold_earned": {
+                    "type": "integer"
+                },
+                "hero_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_unregistered_sub": {
+                    "type": "boolean"
+                },
+                "kda_ratio": {
+                    "type": "number"
+                },
+                "kills": {
+                    "type": "integer"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "was_mvp": {
+                    "type": "boolean"
                 }
             }
         },
-        "api.TournamentRegistrationResponse": {
+        "models.ImportError": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.TournamentRegistration"
+                "error_code": {
+                    "type": "string"
                 },
-                "meta": {}
-            }
-        },
-        "api.TournamentResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.Tournament"
+                "error_message": {
+                    "type": "string"
                 },
-                "meta": {}
+                "field": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "line_number": {
+                    "type": "integer"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "retried_at": {
+                    "type": "string"
+                },
+                "retry_count": {
+                    "type": "integer"
+                },
+                "row_data": {
+                    "type": "object"
+                },
+                "row_index": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                }
             }
         },
-        "api.TournamentStandingsResponse": {
+        "models.ImportJob": {
             "type": "object",
             "properties": {
-                "data": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "errors": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.TournamentStanding"
+                        "type": "object"
                     }
                 },
-                "meta": {}
-            }
-        },
-        "api.disciplineRequest": {
-            "type": "object",
-            "required": [
-                "code",
-                "name"
-            ],
-            "properties": {
-                "code": {
+                "failed": {
+                    "type": "integer"
+                },
+                "finished_at": {
                     "type": "string"
                 },
-                "description": {
+                "id": {
+                    "type": "integer"
+                },
+                "inserted": {
+                    "type": "integer"
+                },
+                "is_atomic": {
+                    "type": "boolean"
+                },
+                "is_dry_run": {
+                    "type": "boolean"
+                },
+                "is_restore": {
+                    "type": "boolean"
+                },
+                "last_error": {
                     "type": "string"
                 },
-                "icon_url": {
+                "on_conflict": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "processed": {
+                    "type": "integer"
                 },
-                "metadata": {
-                    "type": "object"
+                "request_id": {
+                    "type": "string"
                 },
-                "name": {
+                "skipped": {
+                    "type": "integer"
+                },
+                "source": {
                     "type": "string"
                 },
-                "team_size": {
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updated": {
                     "type": "integer"
                 }
             }
         },
-        "api.gamePlayerStatRequest": {
+        "models.Match": {
             "type": "object",
-            "required": [
-                "game_id",
-                "player_id"
-            ],
             "properties": {
-                "assists": {
+                "bracket_position": {
+                    "type": "integer"
+                },
+                "bracket_round": {
+                    "type": "integer"
+                },
+                "bracket_section": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "damage_dealt": {
+                "is_forfeit": {
+                    "type": "boolean"
+                },
+                "loser_next_match_id": {
                     "type": "integer"
                 },
-                "deaths": {
+                "loser_next_match_slot": {
                     "type": "integer"
                 },
-                "game_id": {
+                "match_notes": {
+                    "type": "object"
+                },
+                "next_match_id": {
                     "type": "integer"
                 },
-                "gold_earned": {
+                "next_match_slot": {
                     "type": "integer"
                 },
-                "hero_name": {
+                "stage": {
                     "type": "string"
                 },
-                "kills": {
+                "start_time": {
+                    "type": "string"
+                },
+                "team1_id": {
                     "type": "integer"
                 },
-                "player_id": {
+                "team2_id": {
                     "type": "integer"
                 },
-                "team_id": {
+                "tournament_id": {
                     "type": "integer"
                 },
-                "was_mvp": {
-                    "type": "boolean"
+                "winner_team_id": {
+                    "type": "integer"
                 }
             }
         },
-        "api.matchGameRequest": {
+        "models.MatchGame": {
             "type": "object",
-            "required": [
-                "game_number",
-                "map_name",
-                "match_id"
-            ],
             "properties": {
                 "duration_seconds": {
                     "type": "integer"
@@ -3822,6 +8499,9 @@ const docTemplate = `{
                 "had_technical_pause": {
                     "type": "boolean"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "map_name": {
                     "type": "string"
                 },
@@ -3845,21 +8525,26 @@ const docTemplate = `{
                 }
             }
         },
-        "api.matchRequest": {
+        "models.MatchResultView": {
             "type": "object",
-            "required": [
-                "start_time",
-                "tournament_id"
-            ],
             "properties": {
                 "format": {
                     "type": "string"
                 },
-                "is_forfeit": {
-                    "type": "boolean"
+                "games_played": {
+                    "type": "integer"
                 },
-                "match_notes": {
-                    "type": "object"
+                "match_id": {
+                    "type": "integer"
+                },
+                "series_score": {
+                    "type": "string"
+                },
+                "series_score_team1": {
+                    "type": "integer"
+                },
+                "series_score_team2": {
+                    "type": "integer"
                 },
                 "stage": {
                     "type": "string"
@@ -3873,6 +8558,12 @@ const docTemplate = `{
                 "team2_id": {
                     "type": "integer"
                 },
+                "total_score_team1": {
+                    "type": "integer"
+                },
+                "total_score_team2": {
+                    "type": "integer"
+                },
                 "tournament_id": {
                     "type": "integer"
                 },
@@ -3881,11 +8572,8 @@ const docTemplate = `{
                 }
             }
         },
-        "api.playerRequest": {
+        "models.Player": {
             "type": "object",
-            "required": [
-                "nickname"
-            ],
             "properties": {
                 "avatar_url": {
                     "type": "string"
@@ -3896,6 +8584,12 @@ const docTemplate = `{
                 "country_code": {
                     "type": "string"
                 },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
                 "is_retired": {
                     "type": "boolean"
                 },
@@ -3913,80 +8607,203 @@ const docTemplate = `{
                 }
             }
         },
-        "api.squadMemberRequest": {
+        "models.PlayerCareerStats": {
             "type": "object",
-            "required": [
-                "player_id",
-                "team_id"
-            ],
             "properties": {
-                "contract_end_date": {
+                "assists": {
+                    "type": "integer"
+                },
+                "damage": {
+                    "type": "integer"
+                },
+                "deaths": {
+                    "type": "integer"
+                },
+                "gold": {
+                    "type": "integer"
+                },
+                "kda": {
+                    "type": "number"
+                },
+                "kills": {
+                    "type": "integer"
+                },
+                "nickname": {
                     "type": "string"
                 },
-                "is_standin": {
-                    "type": "boolean"
+                "player_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlayerRating": {
+            "type": "object",
+            "properties": {
+                "discipline_id": {
+                    "type": "integer"
                 },
-                "join_date": {
+                "last_match_id": {
+                    "type": "integer"
+                },
+                "last_played_at": {
                     "type": "string"
                 },
-                "leave_date": {
+                "matches_played": {
+                    "type": "integer"
+                },
+                "nickname": {
                     "type": "string"
                 },
                 "player_id": {
                     "type": "integer"
                 },
-                "role": {
+                "rating": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RatingHistoryPoint": {
+            "type": "object",
+            "properties": {
+                "cause": {
                     "type": "string"
                 },
-                "salary_monthly": {
+                "changes": {
+                    "type": "integer"
+                },
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "match_id": {
+                    "type": "integer"
+                },
+                "max_rating": {
                     "type": "number"
                 },
-                "team_id": {
+                "min_rating": {
+                    "type": "number"
+                },
+                "previous_rating": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "recorded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RatingRecompute": {
+            "type": "object",
+            "properties": {
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "type": "integer"
+                },
+                "players": {
+                    "type": "integer"
+                },
+                "teams": {
                     "type": "integer"
                 }
             }
         },
-        "api.teamProfileRequest": {
+        "models.RegistrationStatusChange": {
             "type": "object",
-            "required": [
-                "team_id"
-            ],
             "properties": {
-                "coach_name": {
+                "promoted": {
+                    "$ref": "#/definitions/models.TournamentRegistration"
+                },
+                "registration": {
+                    "$ref": "#/definitions/models.TournamentRegistration"
+                }
+            }
+        },
+        "models.RosterHealthView": {
+            "type": "object",
+            "properties": {
+                "active_players": {
+                    "type": "integer"
+                },
+                "discipline_id": {
+                    "type": "integer"
+                },
+                "standins": {
+                    "type": "integer"
+                },
+                "starters": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 },
-                "contact_email": {
+                "tag": {
                     "type": "string"
                 },
-                "headquarters": {
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
                     "type": "string"
                 },
-                "sponsor_info": {
+                "team_size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SquadMember": {
+            "type": "object",
+            "properties": {
+                "contract_end_date": {
                     "type": "string"
                 },
-                "team_id": {
+                "id": {
                     "type": "integer"
                 },
-                "website": {
+                "is_standin": {
+                    "type": "boolean"
+                },
+                "join_date": {
+                    "type": "string"
+                },
+                "leave_date": {
+                    "type": "string"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "role": {
                     "type": "string"
+                },
+                "salary_monthly": {
+                    "type": "number"
+                },
+                "team_id": {
+                    "type": "integer"
                 }
             }
         },
-        "api.teamRequest": {
+        "models.Team": {
             "type": "object",
-            "required": [
-                "country_code",
-                "discipline_id",
-                "name",
-                "tag"
-            ],
             "properties": {
                 "country_code": {
                     "type": "string"
                 },
+                "created_at": {
+                    "type": "string"
+                },
                 "discipline_id": {
                     "type": "integer"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "is_verified": {
                     "type": "boolean"
                 },
@@ -4004,44 +8821,60 @@ const docTemplate = `{
                 }
             }
         },
-        "api.tournamentRegistrationRequest": {
+        "models.TeamProfile": {
+            "type": "object",
+            "properties": {
+                "coach_name": {
+                    "type": "string"
+                },
+                "contact_email": {
+                    "type": "string"
+                },
+                "headquarters": {
+                    "type": "string"
+                },
+                "sponsor_info": {
+                    "type": "string"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TeamRating": {
             "type": "object",
-            "required": [
-                "team_id",
-                "tournament_id"
-            ],
             "properties": {
-                "is_invited": {
-                    "type": "boolean"
+                "discipline_id": {
+                    "type": "integer"
                 },
-                "manager_contact": {
-                    "type": "string"
+                "last_match_id": {
+                    "type": "integer"
                 },
-                "roster_snapshot": {
-                    "type": "object"
+                "last_played_at": {
+                    "type": "string"
                 },
-                "seed_number": {
+                "matches_played": {
                     "type": "integer"
                 },
-                "status": {
-                    "type": "string"
+                "rating": {
+                    "type": "number"
                 },
                 "team_id": {
                     "type": "integer"
                 },
-                "tournament_id": {
-                    "type": "integer"
+                "team_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "api.tournamentRequest": {
+        "models.Tournament": {
             "type": "object",
-            "required": [
-                "discipline_id",
-                "end_date",
-                "name",
-                "start_date"
-            ],
             "properties": {
                 "bracket_config": {
                     "type": "object"
@@ -4055,9 +8888,15 @@ const docTemplate = `{
                 "end_date": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "is_online": {
                     "type": "boolean"
                 },
+                "max_teams": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -4072,522 +8911,484 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ActiveRosterView": {
+        "models.TournamentRegistration": {
             "type": "object",
             "properties": {
-                "country_code": {
+                "id": {
+                    "type": "integer"
+                },
+                "is_invited": {
+                    "type": "boolean"
+                },
+                "manager_contact": {
                     "type": "string"
                 },
-                "join_date": {
+                "registered_at": {
                     "type": "string"
                 },
-                "nickname": {
+                "roster_locked_at": {
                     "type": "string"
                 },
-                "player_id": {
-                    "type": "integer"
+                "roster_snapshot": {
+                    "type": "object"
                 },
-                "role": {
-                    "type": "string"
+                "seed_number": {
+                    "type": "integer"
                 },
-                "tag": {
+                "status": {
                     "type": "string"
                 },
                 "team_id": {
                     "type": "integer"
                 },
-                "team_name": {
-                    "type": "string"
+                "tournament_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Discipline": {
+        "models.TournamentStanding": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "icon_url": {
-                    "type": "string"
-                },
-                "id": {
+                "buchholz": {
                     "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "metadata": {
-                    "type": "object"
+                "draws": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "forfeits": {
+                    "type": "integer"
                 },
-                "team_size": {
+                "head_to_head": {
                     "type": "integer"
-                }
-            }
-        },
-        "models.GamePlayerStat": {
-            "type": "object",
-            "properties": {
-                "assists": {
+                },
+                "losses": {
                     "type": "integer"
                 },
-                "damage_dealt": {
+                "map_diff": {
                     "type": "integer"
                 },
-                "deaths": {
+                "maps_lost": {
                     "type": "integer"
                 },
-                "game_id": {
+                "maps_won": {
                     "type": "integer"
                 },
-                "gold_earned": {
+                "matches_played": {
                     "type": "integer"
                 },
-                "hero_name": {
-                    "type": "string"
+                "points": {
+                    "type": "integer"
                 },
-                "id": {
+                "rank": {
                     "type": "integer"
                 },
-                "kda_ratio": {
-                    "type": "number"
+                "round_diff": {
+                    "type": "integer"
                 },
-                "kills": {
+                "rounds_lost": {
                     "type": "integer"
                 },
-                "player_id": {
+                "rounds_won": {
                     "type": "integer"
                 },
                 "team_id": {
                     "type": "integer"
                 },
-                "was_mvp": {
-                    "type": "boolean"
+                "team_name": {
+                    "type": "string"
+                },
+                "wins": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Match": {
+        "models.TournamentStatusChange": {
             "type": "object",
             "properties": {
-                "format": {
+                "changed_at": {
+                    "type": "string"
+                },
+                "from_status": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_forfeit": {
-                    "type": "boolean"
-                },
-                "match_notes": {
-                    "type": "object"
-                },
-                "stage": {
+                "to_status": {
                     "type": "string"
                 },
-                "start_time": {
+                "tournament_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "team1_id": {
+                "id": {
                     "type": "integer"
                 },
-                "team2_id": {
-                    "type": "integer"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "tournament_id": {
-                    "type": "integer"
+                "role": {
+                    "type": "string"
                 },
-                "winner_team_id": {
+                "team_id": {
                     "type": "integer"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "models.MatchGame": {
+        "service.CreatedAPIKey": {
             "type": "object",
             "properties": {
-                "duration_seconds": {
-                    "type": "integer"
-                },
-                "game_number": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
                 },
-                "had_technical_pause": {
-                    "type": "boolean"
+                "expires_at": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "map_name": {
+                "key": {
                     "type": "string"
                 },
-                "match_id": {
-                    "type": "integer"
-                },
-                "pick_ban_phase": {
-                    "type": "object"
+                "last_used_at": {
+                    "type": "string"
                 },
-                "score_team1": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "score_team2": {
-                    "type": "integer"
+                "prefix": {
+                    "type": "string"
                 },
-                "started_at": {
+                "revoked_at": {
                     "type": "string"
                 },
-                "winner_team_id": {
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "models.MatchResultView": {
+        "service.DisciplineImportInput": {
             "type": "object",
             "properties": {
-                "format": {
+                "code": {
                     "type": "string"
                 },
-                "games_played": {
-                    "type": "integer"
-                },
-                "match_id": {
-                    "type": "integer"
-                },
-                "stage": {
+                "description": {
                     "type": "string"
                 },
-                "start_time": {
+                "icon_url": {
                     "type": "string"
                 },
-                "total_score_team1": {
-                    "type": "integer"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "total_score_team2": {
-                    "type": "integer"
+                "metadata": {
+                    "type": "object"
                 },
-                "tournament_id": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "winner_team_id": {
+                "team_size": {
                     "type": "integer"
                 }
             }
         },
-        "models.Player": {
+        "service.GameDocumentInput": {
             "type": "object",
             "properties": {
-                "avatar_url": {
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "game_number": {
+                    "type": "integer"
+                },
+                "had_technical_pause": {
+                    "type": "boolean"
+                },
+                "map_name": {
                     "type": "string"
                 },
-                "birth_date": {
+                "match_id": {
+                    "type": "integer"
+                },
+                "match_start_time": {
                     "type": "string"
                 },
-                "country_code": {
+                "match_team1_tag": {
                     "type": "string"
                 },
-                "created_at": {
+                "match_team2_tag": {
                     "type": "string"
                 },
-                "id": {
+                "pick_ban_phase": {
+                    "type": "object"
+                },
+                "player_stats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.GamePlayerStatImportInput"
+                    }
+                },
+                "score_team1": {
                     "type": "integer"
                 },
-                "is_retired": {
-                    "type": "boolean"
+                "score_team2": {
+                    "type": "integer"
                 },
-                "mmr_rating": {
-                    "type": "number"
+                "started_at": {
+                    "type": "string"
                 },
-                "nickname": {
+                "tournament_name": {
                     "type": "string"
                 },
-                "real_name": {
+                "winner_team_id": {
+                    "type": "integer"
+                },
+                "winner_team_tag": {
                     "type": "string"
+                }
+            }
+        },
+        "service.GameDocumentResult": {
+            "type": "object",
+            "properties": {
+                "game_id": {
+                    "type": "integer"
+                },
+                "game_number": {
+                    "type": "integer"
                 },
-                "steam_id": {
-                    "type": "string"
+                "player_stat_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "models.PlayerCareerStats": {
+        "service.GamePlayerStatImportInput": {
             "type": "object",
             "properties": {
                 "assists": {
                     "type": "integer"
                 },
-                "damage": {
+                "damage_dealt": {
                     "type": "integer"
                 },
                 "deaths": {
                     "type": "integer"
                 },
-                "gold": {
+                "game_id": {
                     "type": "integer"
                 },
-                "kda": {
-                    "type": "number"
-                },
-                "kills": {
+                "game_number": {
                     "type": "integer"
                 },
-                "nickname": {
-                    "type": "string"
-                },
-                "player_id": {
+                "gold_earned": {
                     "type": "integer"
-                }
-            }
-        },
-        "models.SquadMember": {
-            "type": "object",
-            "properties": {
-                "contract_end_date": {
+                },
+                "hero_name": {
                     "type": "string"
                 },
-                "id": {
+                "kills": {
                     "type": "integer"
                 },
-                "is_standin": {
-                    "type": "boolean"
+                "match_start_time": {
+                    "type": "string"
                 },
-                "join_date": {
+                "match_team1_tag": {
                     "type": "string"
                 },
-                "leave_date": {
+                "match_team2_tag": {
                     "type": "string"
                 },
                 "player_id": {
                     "type": "integer"
                 },
-                "role": {
-                    "type": "string"
-                },
-                "salary_monthly": {
-                    "type": "number"
-                },
-                "team_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "models.Team": {
-            "type": "object",
-            "properties": {
-                "country_code": {
+                "player_nickname": {
                     "type": "string"
                 },
-                "created_at": {
+                "player_steam_id": {
                     "type": "string"
                 },
-                "discipline_id": {
-                    "type": "integer"
-                },
-                "id": {
+                "team_id": {
                     "type": "integer"
                 },
-                "is_verified": {
-                    "type": "boolean"
-                },
-                "logo_url": {
-                    "type": "string"
-                },
-                "name": {
+                "team_tag": {
                     "type": "string"
                 },
-                "tag": {
+                "tournament_name": {
                     "type": "string"
                 },
-                "world_ranking": {
-                    "type": "number"
+                "was_mvp": {
+                    "type": "boolean"
                 }
             }
         },
-        "models.TeamProfile": {
+        "service.ImportPurgeResult": {
             "type": "object",
             "properties": {
-                "coach_name": {
-                    "type": "string"
-                },
-                "contact_email": {
-                    "type": "string"
-                },
-                "headquarters": {
-                    "type": "string"
-                },
-                "sponsor_info": {
+                "before": {
                     "type": "string"
                 },
-                "team_id": {
+                "deleted": {
                     "type": "integer"
-                },
-                "website": {
-                    "type": "string"
                 }
             }
         },
-        "models.Tournament": {
+        "service.ImportRetryResult": {
             "type": "object",
             "properties": {
-                "bracket_config": {
-                    "type": "object"
-                },
-                "currency": {
-                    "type": "string"
-                },
-                "discipline_id": {
-                    "type": "integer"
-                },
-                "end_date": {
-                    "type": "string"
+                "error": {
+                    "$ref": "#/definitions/service.ImportRowError"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_online": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "prize_pool": {
-                    "type": "number"
-                },
-                "start_date": {
-                    "type": "string"
-                },
                 "status": {
                     "type": "string"
                 }
             }
         },
-        "models.TournamentRegistration": {
+        "service.ImportRowError": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "is_invited": {
-                    "type": "boolean"
-                },
-                "manager_contact": {
+                "code": {
                     "type": "string"
                 },
-                "registered_at": {
+                "field": {
                     "type": "string"
                 },
-                "roster_snapshot": {
-                    "type": "object"
-                },
-                "seed_number": {
+                "line": {
                     "type": "integer"
                 },
-                "status": {
+                "message": {
                     "type": "string"
                 },
-                "team_id": {
-                    "type": "integer"
-                },
-                "tournament_id": {
+                "row": {
                     "type": "integer"
                 }
             }
         },
-        "models.TournamentStanding": {
+        "service.ImportSummary": {
             "type": "object",
             "properties": {
-                "forfeits": {
-                    "type": "integer"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "losses": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ImportRowError"
+                    }
+                },
+                "failed": {
                     "type": "integer"
                 },
-                "matches_played": {
+                "inserted": {
                     "type": "integer"
                 },
-                "team_id": {
+                "rolled_back": {
+                    "type": "boolean"
+                },
+                "skipped": {
                     "type": "integer"
                 },
-                "wins": {
+                "updated": {
                     "type": "integer"
                 }
             }
         },
-        "service.DisciplineImportInput": {
+        "service.LoginResult": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "description": {
+                "expires_at": {
                     "type": "string"
                 },
-                "icon_url": {
+                "token": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "metadata": {
-                    "type": "object"
-                },
-                "name": {
+                "token_type": {
                     "type": "string"
                 },
-                "team_size": {
-                    "type": "integer"
+                "user": {
+                    "$ref": "#/definitions/models.User"
                 }
             }
         },
-        "service.GamePlayerStatImportInput": {
+        "service.MatchDocumentInput": {
             "type": "object",
             "properties": {
-                "assists": {
-                    "type": "integer"
+                "format": {
+                    "type": "string"
                 },
-                "damage_dealt": {
-                    "type": "integer"
+                "games": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.GameDocumentInput"
+                    }
                 },
-                "deaths": {
-                    "type": "integer"
+                "is_forfeit": {
+                    "type": "boolean"
                 },
-                "game_id": {
-                    "type": "integer"
+                "match_notes": {
+                    "type": "object"
                 },
-                "gold_earned": {
+                "stage": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "team1_id": {
                     "type": "integer"
                 },
-                "hero_name": {
+                "team1_tag": {
                     "type": "string"
                 },
-                "kills": {
+                "team2_id": {
                     "type": "integer"
                 },
-                "player_id": {
+                "team2_tag": {
+                    "type": "string"
+                },
+                "tournament_id": {
                     "type": "integer"
                 },
-                "team_id": {
+                "tournament_name": {
+                    "type": "string"
+                },
+                "winner_team_id": {
                     "type": "integer"
                 },
-                "was_mvp": {
-                    "type": "boolean"
+                "winner_team_tag": {
+                    "type": "string"
                 }
             }
         },
-        "service.ImportSummary": {
+        "service.MatchDocumentResult": {
             "type": "object",
             "properties": {
-                "errors": {
+                "games": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/service.GameDocumentResult"
                     }
                 },
-                "failed": {
+                "match_id": {
                     "type": "integer"
                 },
-                "inserted": {
+                "winner_team_id": {
                     "type": "integer"
                 }
             }
@@ -4610,6 +9411,15 @@ const docTemplate = `{
                 "match_id": {
                     "type": "integer"
                 },
+                "match_start_time": {
+                    "type": "string"
+                },
+                "match_team1_tag": {
+                    "type": "string"
+                },
+                "match_team2_tag": {
+                    "type": "string"
+                },
                 "pick_ban_phase": {
                     "type": "object"
                 },
@@ -4622,8 +9432,14 @@ const docTemplate = `{
                 "started_at": {
                     "type": "string"
                 },
+                "tournament_name": {
+                    "type": "string"
+                },
                 "winner_team_id": {
                     "type": "integer"
+                },
+                "winner_team_tag": {
+                    "type": "string"
                 }
             }
         },
@@ -4648,14 +9464,26 @@ const docTemplate = `{
                 "team1_id": {
                     "type": "integer"
                 },
+                "team1_tag": {
+                    "type": "string"
+                },
                 "team2_id": {
                     "type": "integer"
                 },
+                "team2_tag": {
+                    "type": "string"
+                },
                 "tournament_id": {
                     "type": "integer"
                 },
+                "tournament_name": {
+                    "type": "string"
+                },
                 "winner_team_id": {
                     "type": "integer"
+                },
+                "winner_team_tag": {
+                    "type": "string"
                 }
             }
         },
@@ -4694,6 +9522,9 @@ const docTemplate = `{
                 "contract_end_date": {
                     "type": "string"
                 },
+                "discipline_code": {
+                    "type": "string"
+                },
                 "is_standin": {
                     "type": "boolean"
                 },
@@ -4706,6 +9537,12 @@ const docTemplate = `{
                 "player_id": {
                     "type": "integer"
                 },
+                "player_nickname": {
+                    "type": "string"
+                },
+                "player_steam_id": {
+                    "type": "string"
+                },
                 "role": {
                     "type": "string"
                 },
@@ -4714,6 +9551,9 @@ const docTemplate = `{
                 },
                 "team_id": {
                     "type": "integer"
+                },
+                "team_tag": {
+                    "type": "string"
                 }
             }
         },
@@ -4723,6 +9563,9 @@ const docTemplate = `{
                 "country_code": {
                     "type": "string"
                 },
+                "discipline_code": {
+                    "type": "string"
+                },
                 "discipline_id": {
                     "type": "integer"
                 },
@@ -4752,6 +9595,9 @@ const docTemplate = `{
                 "contact_email": {
                     "type": "string"
                 },
+                "discipline_code": {
+                    "type": "string"
+                },
                 "headquarters": {
                     "type": "string"
                 },
@@ -4761,6 +9607,9 @@ const docTemplate = `{
                 "team_id": {
                     "type": "integer"
                 },
+                "team_tag": {
+                    "type": "string"
+                },
                 "website": {
                     "type": "string"
                 }
@@ -4775,6 +9624,9 @@ const docTemplate = `{
                 "currency": {
                     "type": "string"
                 },
+                "discipline_code": {
+                    "type": "string"
+                },
                 "discipline_id": {
                     "type": "integer"
                 },
@@ -4784,6 +9636,9 @@ const docTemplate = `{
                 "is_online": {
                     "type": "boolean"
                 },
+                "max_teams": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -4807,9 +9662,6 @@ const docTemplate = `{
                 "manager_contact": {
                     "type": "string"
                 },
-                "roster_snapshot": {
-                    "type": "object"
-                },
                 "seed_number": {
                     "type": "integer"
                 },
@@ -4819,11 +9671,30 @@ const docTemplate = `{
                 "team_id": {
                     "type": "integer"
                 },
+                "team_tag": {
+                    "type": "string"
+                },
                 "tournament_id": {
                     "type": "integer"
+                },
+                "tournament_name": {
+                    "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "Token from POST /auth/login, sent as \"Bearer \u003ctoken\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
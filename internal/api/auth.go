@@ -0,0 +1,177 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"db_course_project/internal/auth"
+	"db_course_project/internal/repository"
+	"db_course_project/internal/service"
+)
+
+type AuthHandler struct {
+	svc *service.AuthService
+}
+
+func NewAuthHandler(svc *service.AuthService) *AuthHandler {
+	return &AuthHandler{svc: svc}
+}
+
+// Register adds the routes that need no authentication.
+func (h *AuthHandler) Register(rg *gin.RouterGroup) {
+	rg.POST("/auth/login", h.Login)
+}
+
+// AccountHandler serves the signed-in user's own account.
+type AccountHandler struct {
+	svc *service.AuthService
+}
+
+func NewAccountHandler(svc *service.AuthService) *AccountHandler {
+	return &AccountHandler{svc: svc}
+}
+
+func (h *AccountHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/auth/me", h.Me)
+	rg.GET("/auth/api-keys", h.ListAPIKeys)
+	rg.POST("/auth/api-keys", h.CreateAPIKey)
+	rg.DELETE("/auth/api-keys/:id", h.RevokeAPIKey)
+}
+
+type loginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type apiKeyRequest struct {
+	Name      string  `json:"name" binding:"required"`
+	ExpiresAt *string `json:"expires_at"`
+}
+
+// @Summary Log in
+// @Description Returns a bearer token for the Authorization header of the other endpoints.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param payload body loginRequest true "Credentials"
+// @Success 200 {object} LoginResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req loginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	res, err := h.svc.Login(c.Request.Context(), req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			RespondError(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	RespondData(c, http.StatusOK, res, nil)
+}
+
+// @Summary Current user
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Success 200 {object} PrincipalResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /auth/me [get]
+func (h *AccountHandler) Me(c *gin.Context) {
+	p, _ := auth.FromContext(c.Request.Context())
+	RespondData(c, http.StatusOK, p, nil)
+}
+
+// @Summary List own API keys
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Success 200 {object} APIKeyListResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /auth/api-keys [get]
+func (h *AccountHandler) ListAPIKeys(c *gin.Context) {
+	p, _ := auth.FromContext(c.Request.Context())
+	keys, err := h.svc.ListAPIKeys(c.Request.Context(), p.UserID)
+	if err != nil {
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	RespondData(c, http.StatusOK, keys, nil)
+}
+
+// @Summary Create an API key
+// @Description The key is returned once; send it in the X-API-Key header. It acts with the role of its user.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param payload body apiKeyRequest true "Key name and optional expiry (RFC 3339)"
+// @Success 201 {object} CreatedAPIKeyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /auth/api-keys [post]
+func (h *AccountHandler) CreateAPIKey(c *gin.Context) {
+	var req apiKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		t, err := time.Parse(time.RFC3339, *req.ExpiresAt)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid expires_at")
+			return
+		}
+		expiresAt = &t
+	}
+	p, _ := auth.FromContext(c.Request.Context())
+	key, err := h.svc.CreateAPIKey(c.Request.Context(), p.UserID, req.Name, expiresAt)
+	if err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	RespondData(c, http.StatusCreated, key, nil)
+}
+
+// @Summary Revoke an own API key
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "API key ID"
+// @Success 204 {object} EmptyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /auth/api-keys/{id} [delete]
+func (h *AccountHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	p, _ := auth.FromContext(c.Request.Context())
+	if err := h.svc.RevokeAPIKey(c.Request.Context(), id, p.UserID); err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			RespondError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	RespondData(c, http.StatusNoContent, nil, nil)
+}
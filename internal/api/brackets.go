@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"db_course_project/internal/repository"
+	"db_course_project/internal/service"
+)
+
+type BracketHandler struct {
+	svc *service.BracketService
+}
+
+func NewBracketHandler(svc *service.BracketService) *BracketHandler {
+	return &BracketHandler{svc: svc}
+}
+
+func (h *BracketHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/tournaments/:id/bracket", h.Get)
+	rg.POST("/tournaments/:id/bracket/generate", h.Generate)
+	rg.POST("/tournaments/:id/bracket/next-round", h.NextRound)
+}
+
+// @Summary Get tournament bracket
+// @Tags Brackets
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Tournament ID"
+// @Success 200 {object} BracketResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /tournaments/{id}/bracket [get]
+func (h *BracketHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	view, err := h.svc.Get(c.Request.Context(), id)
+	if err != nil {
+		respondBracketError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, view, nil)
+}
+
+// @Summary Generate tournament bracket
+// @Tags Brackets
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Tournament ID"
+// @Success 201 {object} BracketResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /tournaments/{id}/bracket/generate [post]
+func (h *BracketHandler) Generate(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	view, err := h.svc.Generate(c.Request.Context(), id)
+	if err != nil {
+		respondBracketError(c, err)
+		return
+	}
+	RespondData(c, http.StatusCreated, view, nil)
+}
+
+// @Summary Generate next swiss round
+// @Tags Brackets
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Tournament ID"
+// @Success 201 {object} BracketResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /tournaments/{id}/bracket/next-round [post]
+func (h *BracketHandler) NextRound(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	view, err := h.svc.NextSwissRound(c.Request.Context(), id)
+	if err != nil {
+		respondBracketError(c, err)
+		return
+	}
+	RespondData(c, http.StatusCreated, view, nil)
+}
+
+func respondBracketError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTournamentNotFound):
+		RespondErr(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrBracketHasResults),
+		errors.Is(err, service.ErrSwissRoundOpen),
+		errors.Is(err, service.ErrSwissRoundsPlayed):
+		RespondErr(c, http.StatusConflict, err)
+	default:
+		RespondErr(c, http.StatusBadRequest, err)
+	}
+}
@@ -43,14 +43,18 @@ func (h *DisciplineHandler) Register(rg *gin.RouterGroup) {
 // @Tags Disciplines
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body disciplineRequest true "Discipline payload"
 // @Success 201 {object} DisciplineResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /disciplines [post]
 func (h *DisciplineHandler) Create(c *gin.Context) {
 	var req disciplineRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -72,7 +76,7 @@ func (h *DisciplineHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.svc.Create(c.Request.Context(), d); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusCreated, d, nil)
@@ -81,6 +85,8 @@ func (h *DisciplineHandler) Create(c *gin.Context) {
 // @Summary Get discipline
 // @Tags Disciplines
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Discipline ID"
 // @Success 200 {object} DisciplineResponse
 // @Failure 400 {object} ErrorResponse
@@ -95,10 +101,10 @@ func (h *DisciplineHandler) Get(c *gin.Context) {
 	d, err := h.svc.Get(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrDisciplineNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusOK, d, nil)
@@ -107,6 +113,8 @@ func (h *DisciplineHandler) Get(c *gin.Context) {
 // @Summary List disciplines
 // @Tags Disciplines
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param search query string false "Search by name or code"
 // @Param is_active query bool false "Filter by active flag"
 // @Param limit query int false "Page size"
@@ -134,7 +142,7 @@ func (h *DisciplineHandler) List(c *gin.Context) {
 
 	rows, total, err := h.svc.List(c.Request.Context(), filter)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -146,11 +154,15 @@ func (h *DisciplineHandler) List(c *gin.Context) {
 // @Tags Disciplines
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Discipline ID"
 // @Param payload body disciplineRequest true "Discipline payload"
 // @Success 200 {object} DisciplineResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /disciplines/{id} [put]
 func (h *DisciplineHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -160,7 +172,7 @@ func (h *DisciplineHandler) Update(c *gin.Context) {
 	}
 	var req disciplineRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -184,10 +196,10 @@ func (h *DisciplineHandler) Update(c *gin.Context) {
 
 	if err := h.svc.Update(c.Request.Context(), d); err != nil {
 		if errors.Is(err, repository.ErrDisciplineNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusOK, d, nil)
@@ -196,10 +208,13 @@ func (h *DisciplineHandler) Update(c *gin.Context) {
 // @Summary Delete discipline
 // @Tags Disciplines
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Discipline ID"
 // @Success 204 {object} EmptyResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /disciplines/{id} [delete]
 func (h *DisciplineHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -209,10 +224,10 @@ func (h *DisciplineHandler) Delete(c *gin.Context) {
 	}
 	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
 		if errors.Is(err, repository.ErrDisciplineNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusNoContent, nil, nil)
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"db_course_project/internal/auth"
+	"db_course_project/internal/repository"
+)
+
+const internalErrorMessage = "internal server error"
+
+// RespondErr responds with err. Database errors get a status of their own and
+// a message that does not leak the statement: unique violations are 409,
+// foreign key and check violations 422. Forbidden changes are 403. Other
+// errors are sent with status; those of status 500 and above are logged and
+// hidden from the client.
+func RespondErr(c *gin.Context, status int, err error) {
+	if v, ok := repository.ConstraintViolation(err); ok {
+		respondViolation(c, v)
+		return
+	}
+	if errors.Is(err, auth.ErrForbidden) {
+		RespondError(c, http.StatusForbidden, err.Error())
+		return
+	}
+	if repository.IsRetryable(err) {
+		RespondError(c, http.StatusConflict, "the request conflicted with a concurrent change, try again")
+		return
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Data exceptions such as a malformed or too long value name the type,
+		// not the statement.
+		if strings.HasPrefix(pgErr.Code, "22") {
+			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: ErrorDetail{
+				Message: pgErr.Message,
+				Code:    "invalid_value",
+				Field:   pgErr.ColumnName,
+			}})
+			return
+		}
+		status = http.StatusInternalServerError
+	}
+	if status >= http.StatusInternalServerError {
+		log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		_ = c.Error(err)
+		RespondError(c, status, internalErrorMessage)
+		return
+	}
+	RespondError(c, status, err.Error())
+}
+
+func respondViolation(c *gin.Context, v *repository.Violation) {
+	status := http.StatusUnprocessableEntity
+	if v.Code == repository.ViolationUnique || v.InUse {
+		status = http.StatusConflict
+	}
+	detail := ErrorDetail{Message: v.Message(), Code: v.Code, Field: v.Field}
+	if v.Code != repository.ViolationNotNull {
+		detail.Constraint = v.Constraint
+	}
+	c.JSON(status, ErrorResponse{Error: detail})
+}
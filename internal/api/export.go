@@ -0,0 +1,260 @@
+package api
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gocarina/gocsv"
+
+	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
+	"db_course_project/internal/service"
+)
+
+// exportChunk is the number of rows marshalled to CSV at a time.
+const exportChunk = 1000
+
+var errInvalidExportFormat = errors.New("format must be csv, ndjson or sql")
+
+// entityExporter writes the rows of one entity as CSV or NDJSON.
+type entityExporter func(ctx context.Context, w io.Writer, format string, filter models.ExportFilter) error
+
+func exportAs[T any](rowsFn func(context.Context, models.ExportFilter) iter.Seq2[T, error]) entityExporter {
+	return func(ctx context.Context, w io.Writer, format string, filter models.ExportFilter) error {
+		if format == models.ExportFormatNDJSON {
+			return writeNDJSON(w, rowsFn(ctx, filter))
+		}
+		return writeCSV(w, rowsFn(ctx, filter))
+	}
+}
+
+type ExportHandler struct {
+	svc       *service.ExportService
+	exporters map[string]entityExporter
+}
+
+func NewExportHandler(svc *service.ExportService) *ExportHandler {
+	return &ExportHandler{
+		svc: svc,
+		exporters: map[string]entityExporter{
+			models.ImportEntityPlayers:                 exportAs(svc.ExportPlayers),
+			models.ImportEntityDisciplines:             exportAs(svc.ExportDisciplines),
+			models.ImportEntityTeams:                   exportAs(svc.ExportTeams),
+			models.ImportEntityTournaments:             exportAs(svc.ExportTournaments),
+			models.ImportEntityTournamentRegistrations: exportAs(svc.ExportTournamentRegistrations),
+			models.ImportEntityMatches:                 exportAs(svc.ExportMatches),
+			models.ImportEntityMatchGames:              exportAs(svc.ExportMatchGames),
+			models.ImportEntityGamePlayerStats:         exportAs(svc.ExportGamePlayerStats),
+			models.ImportEntitySquadMembers:            exportAs(svc.ExportSquadMembers),
+			models.ImportEntityTeamProfiles:            exportAs(svc.ExportTeamProfiles),
+		},
+	}
+}
+
+func (h *ExportHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/export/:entity", h.ExportEntity)
+	rg.GET("/tournaments/:id/export", h.ExportTournament)
+}
+
+// @Summary Export an entity
+// @Description Streams every row of the entity. CSV and NDJSON rows have the fields of the batch import, with references by code, tag, nickname or name instead of id, so the file can be imported into another instance; import tournaments and registrations with restore=true to keep their status. A SQL dump holds the stored rows, ids included.
+// @Tags Export
+// @Produce text/csv,application/x-ndjson,application/sql
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param entity path string true "players, disciplines, teams, tournaments, tournament-registrations, matches, match-games, game-player-stats, squad-members or team-profiles"
+// @Param format query string false "csv (default), ndjson or sql"
+// @Param tournament_id query int false "Only export the data of this tournament"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /export/{entity} [get]
+func (h *ExportHandler) ExportEntity(c *gin.Context) {
+	entity := c.Param("entity")
+	exporter, ok := h.exporters[entity]
+	if !ok {
+		RespondError(c, http.StatusNotFound, repository.ErrUnknownExportEntity.Error())
+		return
+	}
+	format, err := exportFormat(c.Query("format"))
+	if err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	var filter models.ExportFilter
+	if v := c.Query("tournament_id"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid tournament_id")
+			return
+		}
+		filter.TournamentID = &parsed
+	}
+	if err := h.svc.CheckFilter(c.Request.Context(), filter); err != nil {
+		respondExportError(c, err)
+		return
+	}
+
+	startExport(c, entity+"."+format, format)
+	ctx := c.Request.Context()
+	if format == models.ExportFormatSQL {
+		err = h.svc.Dump(ctx, c.Writer, []string{entity}, filter)
+	} else {
+		err = exporter(ctx, c.Writer, format, filter)
+	}
+	abortExport(c, err)
+}
+
+// @Summary Export a whole tournament
+// @Description Exports the tournament with its discipline, teams, team profiles, players, squad members, registrations, matches, games and player stats from one snapshot. CSV and NDJSON come as a zip with one file per entity, named in import order, to be imported with restore=true; sql is a single dump.
+// @Tags Export
+// @Produce application/zip,application/sql
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Tournament ID"
+// @Param format query string false "csv (default), ndjson or sql"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tournaments/{id}/export [get]
+func (h *ExportHandler) ExportTournament(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	format, err := exportFormat(c.Query("format"))
+	if err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	filter := models.ExportFilter{TournamentID: &id}
+	if err := h.svc.CheckFilter(c.Request.Context(), filter); err != nil {
+		respondExportError(c, err)
+		return
+	}
+
+	name := fmt.Sprintf("tournament-%d", id)
+	err = h.svc.Snapshot(c.Request.Context(), func(ctx context.Context) error {
+		if format == models.ExportFormatSQL {
+			startExport(c, name+".sql", format)
+			return h.svc.Dump(ctx, c.Writer, service.ExportEntities, filter)
+		}
+		startExport(c, name+".zip", "zip")
+		zw := zip.NewWriter(c.Writer)
+		for i, entity := range service.ExportEntities {
+			f, err := zw.Create(fmt.Sprintf("%02d-%s.%s", i+1, entity, format))
+			if err != nil {
+				return err
+			}
+			if err := h.exporters[entity](ctx, f, format, filter); err != nil {
+				return err
+			}
+		}
+		return zw.Close()
+	})
+	abortExport(c, err)
+}
+
+func exportFormat(format string) (string, error) {
+	switch format {
+	case "":
+		return models.ExportFormatCSV, nil
+	case models.ExportFormatCSV, models.ExportFormatNDJSON, models.ExportFormatSQL:
+		return format, nil
+	}
+	return "", errInvalidExportFormat
+}
+
+var exportContentTypes = map[string]string{
+	models.ExportFormatCSV:    "text/csv; charset=utf-8",
+	models.ExportFormatNDJSON: "application/x-ndjson",
+	models.ExportFormatSQL:    "application/sql",
+	"zip":                     "application/zip",
+}
+
+func startExport(c *gin.Context, filename, format string) {
+	c.Header("Content-Type", exportContentTypes[format])
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+}
+
+// abortExport reports an export error. Once the file has started the client
+// only gets it truncated.
+func abortExport(c *gin.Context, err error) {
+	if err == nil {
+		return
+	}
+	if !c.Writer.Written() {
+		c.Header("Content-Disposition", "")
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	log.Printf("export %s: %v", c.Request.URL.Path, err)
+	_ = c.Error(err)
+}
+
+func writeCSV[T any](w io.Writer, rows iter.Seq2[T, error]) error {
+	cw := gocsv.NewSafeCSVWriter(csv.NewWriter(w))
+	chunk := make([]T, 0, exportChunk)
+	header := true
+	flush := func() error {
+		marshal := gocsv.MarshalCSVWithoutHeaders
+		if header {
+			marshal = gocsv.MarshalCSV
+		}
+		header = false
+		err := marshal(chunk, cw)
+		chunk = chunk[:0]
+		return err
+	}
+	for row, err := range rows {
+		if err != nil {
+			return err
+		}
+		chunk = append(chunk, row)
+		if len(chunk) == exportChunk {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(chunk) > 0 || header {
+		return flush()
+	}
+	return nil
+}
+
+func writeNDJSON[T any](w io.Writer, rows iter.Seq2[T, error]) error {
+	enc := json.NewEncoder(w)
+	for row, err := range rows {
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func respondExportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTournamentNotFound):
+		RespondErr(c, http.StatusNotFound, err)
+	default:
+		RespondErr(c, http.StatusInternalServerError, err)
+	}
+}
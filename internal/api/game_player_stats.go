@@ -45,14 +45,18 @@ type gamePlayerStatRequest struct {
 // @Tags GamePlayerStats
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body gamePlayerStatRequest true "Game player stats payload"
 // @Success 201 {object} GamePlayerStatResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /game-player-stats [post]
 func (h *GamePlayerStatHandler) Create(c *gin.Context) {
 	var req gamePlayerStatRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	wasMVP := false
@@ -72,7 +76,11 @@ func (h *GamePlayerStatHandler) Create(c *gin.Context) {
 		WasMVP:      wasMVP,
 	}
 	if err := h.svc.Create(c.Request.Context(), st); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		if errors.Is(err, service.ErrLineupOversize) {
+			RespondErr(c, http.StatusConflict, err)
+			return
+		}
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusCreated, st, nil)
@@ -81,6 +89,8 @@ func (h *GamePlayerStatHandler) Create(c *gin.Context) {
 // @Summary Get game player stats
 // @Tags GamePlayerStats
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Stat ID"
 // @Success 200 {object} GamePlayerStatResponse
 // @Failure 400 {object} ErrorResponse
@@ -95,10 +105,10 @@ func (h *GamePlayerStatHandler) Get(c *gin.Context) {
 	st, err := h.svc.Get(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrGamePlayerStatNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusOK, st, nil)
@@ -107,10 +117,13 @@ func (h *GamePlayerStatHandler) Get(c *gin.Context) {
 // @Summary List game player stats
 // @Tags GamePlayerStats
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param game_id query int false "Game ID"
 // @Param player_id query int false "Player ID"
 // @Param team_id query int false "Team ID"
 // @Param was_mvp query bool false "Filter by MVP"
+// @Param is_unregistered_sub query bool false "Filter by substitutes missing from the locked roster"
 // @Param limit query int false "Page size"
 // @Param offset query int false "Offset"
 // @Success 200 {object} GamePlayerStatListResponse
@@ -147,10 +160,21 @@ func (h *GamePlayerStatHandler) List(c *gin.Context) {
 			wasMVP = &b
 		}
 	}
-	filter := models.GamePlayerStatFilter{GameID: gameID, PlayerID: playerID, TeamID: teamID, WasMVP: wasMVP, Limit: limit, Offset: offset}
+	var unregisteredSub *bool
+	if v := c.Query("is_unregistered_sub"); v != "" {
+		switch v {
+		case "true":
+			b := true
+			unregisteredSub = &b
+		case "false":
+			b := false
+			unregisteredSub = &b
+		}
+	}
+	filter := models.GamePlayerStatFilter{GameID: gameID, PlayerID: playerID, TeamID: teamID, WasMVP: wasMVP, UnregisteredSub: unregisteredSub, Limit: limit, Offset: offset}
 	rows, total, err := h.svc.List(c.Request.Context(), filter)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -161,11 +185,15 @@ func (h *GamePlayerStatHandler) List(c *gin.Context) {
 // @Tags GamePlayerStats
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Stat ID"
 // @Param payload body gamePlayerStatRequest true "Game player stats payload"
 // @Success 200 {object} GamePlayerStatResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /game-player-stats/{id} [put]
 func (h *GamePlayerStatHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -175,7 +203,7 @@ func (h *GamePlayerStatHandler) Update(c *gin.Context) {
 	}
 	var req gamePlayerStatRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	wasMVP := false
@@ -197,10 +225,14 @@ func (h *GamePlayerStatHandler) Update(c *gin.Context) {
 	}
 	if err := h.svc.Update(c.Request.Context(), st); err != nil {
 		if errors.Is(err, repository.ErrGamePlayerStatNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, service.ErrLineupOversize) {
+			RespondErr(c, http.StatusConflict, err)
 			return
 		}
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusOK, st, nil)
@@ -209,10 +241,13 @@ func (h *GamePlayerStatHandler) Update(c *gin.Context) {
 // @Summary Delete game player stats
 // @Tags GamePlayerStats
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Stat ID"
 // @Success 204 {object} EmptyResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /game-player-stats/{id} [delete]
 func (h *GamePlayerStatHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -222,10 +257,10 @@ func (h *GamePlayerStatHandler) Delete(c *gin.Context) {
 	}
 	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
 		if errors.Is(err, repository.ErrGamePlayerStatNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusNoContent, nil, nil)
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
+	"db_course_project/internal/service"
+)
+
+type ImportErrorHandler struct {
+	svc *service.ImportErrorService
+}
+
+func NewImportErrorHandler(svc *service.ImportErrorService) *ImportErrorHandler {
+	return &ImportErrorHandler{svc: svc}
+}
+
+func (h *ImportErrorHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/import-errors", h.List)
+	rg.GET("/import-errors/:id", h.Get)
+	rg.PUT("/import-errors/:id", h.Fix)
+	rg.POST("/import-errors/retry", h.Retry)
+	rg.POST("/import-errors/purge", h.Purge)
+}
+
+type importErrorFixRequest struct {
+	RowData json.RawMessage `json:"row_data" binding:"required" swaggertype:"object"`
+}
+
+type importErrorRetryRequest struct {
+	IDs []int64 `json:"ids" binding:"required"`
+}
+
+// @Summary List logged import errors
+// @Tags Utility
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param source query string false "Source, e.g. players_csv or game_player_stats_stream"
+// @Param from query string false "From (YYYY-MM-DD or RFC 3339)"
+// @Param to query string false "To (YYYY-MM-DD, inclusive, or RFC 3339)"
+// @Param limit query int false "Page size"
+// @Param offset query int false "Offset"
+// @Success 200 {object} ImportErrorListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /import-errors [get]
+func (h *ImportErrorHandler) List(c *gin.Context) {
+	limit, offset := ParsePagination(c)
+	filter := models.ImportErrorFilter{Source: c.Query("source"), Limit: limit, Offset: offset}
+	var ok bool
+	if filter.From, ok = parseTimeQuery(c, "from", false); !ok {
+		return
+	}
+	if filter.To, ok = parseTimeQuery(c, "to", true); !ok {
+		return
+	}
+	rows, total, err := h.svc.List(c.Request.Context(), filter)
+	if err != nil {
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
+	RespondData(c, http.StatusOK, rows, meta)
+}
+
+// @Summary Get a logged import error with its row data
+// @Tags Utility
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Import error ID"
+// @Success 200 {object} ImportErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /import-errors/{id} [get]
+func (h *ImportErrorHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	row, err := h.svc.Get(c.Request.Context(), id)
+	if err != nil {
+		respondImportErrorError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, row, nil)
+}
+
+// @Summary Fix the row data of a logged import error
+// @Tags Utility
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Import error ID"
+// @Param payload body importErrorFixRequest true "Corrected row, in the JSON form of the batch import"
+// @Success 200 {object} ImportErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /import-errors/{id} [put]
+func (h *ImportErrorHandler) Fix(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	var req importErrorFixRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	row, err := h.svc.Fix(c.Request.Context(), id, req.RowData)
+	if err != nil {
+		respondImportErrorError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, row, nil)
+}
+
+// @Summary Retry logged import errors
+// @Description Imports the stored rows again. Rows that import are removed from the log; the others keep their row and get the new error. Each error is retried in its own transaction; one whose retry could not run is reported with status error.
+// @Tags Utility
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param payload body importErrorRetryRequest true "Errors to retry"
+// @Param on_conflict query string false "error (default), skip or update for rows whose natural key exists"
+// @Success 200 {object} ImportRetryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /import-errors/retry [post]
+func (h *ImportErrorHandler) Retry(c *gin.Context) {
+	var req importErrorRetryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	results, err := h.svc.Retry(c.Request.Context(), req.IDs, c.Query("on_conflict"))
+	if err != nil {
+		respondImportErrorError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, results, nil)
+}
+
+// @Summary Purge old import errors
+// @Tags Utility
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param source query string false "Only purge errors of this source"
+// @Param older_than_days query int false "Age in days; the configured retention when omitted"
+// @Success 200 {object} ImportPurgeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /import-errors/purge [post]
+func (h *ImportErrorHandler) Purge(c *gin.Context) {
+	var olderThanDays *int
+	if v := c.Query("older_than_days"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid older_than_days")
+			return
+		}
+		olderThanDays = &parsed
+	}
+	res, err := h.svc.Purge(c.Request.Context(), c.Query("source"), olderThanDays)
+	if err != nil {
+		respondImportErrorError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, res, nil)
+}
+
+// parseTimeQuery reads a date or RFC 3339 time. A bare date given as an upper
+// bound includes the whole day.
+func parseTimeQuery(c *gin.Context, key string, upper bool) (*time.Time, bool) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, true
+	}
+	if parsed, err := time.Parse(time.RFC3339, v); err == nil {
+		return &parsed, true
+	}
+	parsed, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid "+key)
+		return nil, false
+	}
+	if upper {
+		parsed = parsed.AddDate(0, 0, 1)
+	}
+	return &parsed, true
+}
+
+func respondImportErrorError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrImportErrorNotFound):
+		RespondErr(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidRowData), errors.Is(err, service.ErrNoRetryIDs),
+		errors.Is(err, service.ErrInvalidRetention), errors.Is(err, service.ErrInvalidOnConflict):
+		RespondErr(c, http.StatusBadRequest, err)
+	default:
+		RespondErr(c, http.StatusInternalServerError, err)
+	}
+}
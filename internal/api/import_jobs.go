@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
+	"db_course_project/internal/service"
+)
+
+type ImportJobHandler struct {
+	svc *service.ImportJobService
+}
+
+func NewImportJobHandler(svc *service.ImportJobService) *ImportJobHandler {
+	return &ImportJobHandler{svc: svc}
+}
+
+func (h *ImportJobHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/import-jobs", h.List)
+	rg.GET("/import-jobs/:id", h.Get)
+	rg.POST("/import-jobs/:id/cancel", h.Cancel)
+}
+
+// @Summary List import jobs
+// @Tags Utility
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param status query string false "Status: queued, running, completed, failed or cancelled"
+// @Param entity query string false "Entity, e.g. players or game-player-stats"
+// @Param limit query int false "Page size"
+// @Param offset query int false "Offset"
+// @Success 200 {object} ImportJobListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /import-jobs [get]
+func (h *ImportJobHandler) List(c *gin.Context) {
+	limit, offset := ParsePagination(c)
+	filter := models.ImportJobFilter{
+		Status: c.Query("status"),
+		Entity: c.Query("entity"),
+		Limit:  limit,
+		Offset: offset,
+	}
+	jobs, total, err := h.svc.List(c.Request.Context(), filter)
+	if err != nil {
+		respondImportJobError(c, err)
+		return
+	}
+	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
+	RespondData(c, http.StatusOK, jobs, meta)
+}
+
+// @Summary Get import job progress
+// @Tags Utility
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Import job ID"
+// @Success 200 {object} ImportJobResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /import-jobs/{id} [get]
+func (h *ImportJobHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	job, err := h.svc.Get(c.Request.Context(), id)
+	if err != nil {
+		respondImportJobError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, job, nil)
+}
+
+// @Summary Cancel a queued or running import job
+// @Tags Utility
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Import job ID"
+// @Success 200 {object} ImportJobResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /import-jobs/{id}/cancel [post]
+func (h *ImportJobHandler) Cancel(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	job, err := h.svc.Cancel(c.Request.Context(), id)
+	if err != nil {
+		respondImportJobError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, job, nil)
+}
+
+func respondImportJobError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrImportJobNotFound):
+		RespondErr(c, http.StatusNotFound, err)
+	case errors.Is(err, repository.ErrImportJobFinished):
+		RespondErr(c, http.StatusConflict, err)
+	case errors.Is(err, service.ErrInvalidJobStatus):
+		RespondErr(c, http.StatusBadRequest, err)
+	default:
+		RespondErr(c, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"db_course_project/internal/models"
+	"db_course_project/internal/service"
+)
+
+const (
+	streamFormatCSV    = "csv"
+	streamFormatNDJSON = "ndjson"
+)
+
+// streamImporter imports a CSV or NDJSON stream of one entity.
+type streamImporter func(ctx context.Context, source, format string, r io.Reader, opts service.ImportOptions) (service.ImportSummary, error)
+
+func streamImport[T any](importFn func(context.Context, string, iter.Seq2[T, error], service.ImportOptions) (service.ImportSummary, error)) streamImporter {
+	return func(ctx context.Context, source, format string, r io.Reader, opts service.ImportOptions) (service.ImportSummary, error) {
+		rows := csvRows[T](r)
+		if format == streamFormatNDJSON {
+			rows = ndjsonRows[T](r)
+		}
+		return importFn(ctx, source, rows, opts)
+	}
+}
+
+func newStreamImporters(importer *service.ImportService) map[string]streamImporter {
+	return map[string]streamImporter{
+		models.ImportEntityPlayers:                 streamImport(importer.ImportPlayers),
+		models.ImportEntityDisciplines:             streamImport(importer.ImportDisciplines),
+		models.ImportEntityTeams:                   streamImport(importer.ImportTeams),
+		models.ImportEntityTournaments:             streamImport(importer.ImportTournaments),
+		models.ImportEntityTournamentRegistrations: streamImport(importer.ImportTournamentRegistrations),
+		models.ImportEntityMatches:                 streamImport(importer.ImportMatches),
+		models.ImportEntityMatchGames:              streamImport(importer.ImportMatchGames),
+		models.ImportEntityGamePlayerStats:         streamImport(importer.ImportGamePlayerStats),
+		models.ImportEntitySquadMembers:            streamImport(importer.ImportSquadMembers),
+		models.ImportEntityTeamProfiles:            streamImport(importer.ImportTeamProfiles),
+	}
+}
+
+// @Summary Stream a large CSV or NDJSON import
+// @Description Rows are decoded and written in chunks while the upload is read, so memory use does not grow with the file. Send the file as the request body or as the multipart field "file". CSV columns match the JSON batch import fields.
+// @Tags Utility
+// @Accept text/csv,application/x-ndjson,mpfd
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param entity path string true "players, disciplines, teams, tournaments, tournament-registrations, matches, match-games, game-player-stats, squad-members or team-profiles"
+// @Param format query string false "csv or ndjson; taken from the content type or file name when omitted"
+// @Param file formData file false "CSV or NDJSON file"
+// @Param atomic query bool false "Roll back the whole file if any row fails"
+// @Param dry_run query bool false "Validate the file in a rolled-back transaction"
+// @Param restore query bool false "Keep the exported status of new tournaments and registrations, to load an export back"
+// @Param on_conflict query string false "error (default), skip or update for rows whose natural key exists"
+// @Success 200 {object} ImportSummaryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /batch-import/stream/{entity} [post]
+func (h *UtilityHandler) BatchImportStream(c *gin.Context) {
+	entity := c.Param("entity")
+	importFn, ok := h.streams[entity]
+	if !ok {
+		RespondError(c, http.StatusNotFound, service.ErrUnknownImportEntity.Error())
+		return
+	}
+	opts, ok := parseImportOptions(c)
+	if !ok {
+		return
+	}
+	body, contentType, filename, err := streamBody(c)
+	if err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	format, err := streamFormat(c.Query("format"), contentType, filename)
+	if err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	source := strings.ReplaceAll(entity, "-", "_") + "_stream"
+	summary, err := importFn(c.Request.Context(), source, format, body, opts)
+	if err != nil {
+		respondImportError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, summary, nil)
+}
+
+// streamBody returns the uploaded file without buffering it: the "file" part of
+// a multipart form, or else the request body.
+func streamBody(c *gin.Context) (io.Reader, string, string, error) {
+	contentType := c.ContentType()
+	if contentType != "multipart/form-data" {
+		return c.Request.Body, contentType, "", nil
+	}
+	mr, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, "", "", err
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil, "", "", errors.New("multipart field file is required")
+		}
+		if err != nil {
+			return nil, "", "", err
+		}
+		if part.FormName() == "file" {
+			partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			return part, partType, part.FileName(), nil
+		}
+	}
+}
+
+func streamFormat(format, contentType, filename string) (string, error) {
+	switch format {
+	case streamFormatCSV, streamFormatNDJSON:
+		return format, nil
+	case "":
+	default:
+		return "", errors.New("format must be csv or ndjson")
+	}
+	switch contentType {
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return streamFormatNDJSON, nil
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".ndjson", ".jsonl":
+		return streamFormatNDJSON, nil
+	}
+	return streamFormatCSV, nil
+}
+
+// csvRows decodes CSV one record at a time. A record that does not fit T is
+// yielded as a service.RowDecodeError and decoding goes on with the next one.
+func csvRows[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		reader := csv.NewReader(r)
+		header, err := reader.Read()
+		if err != nil {
+			if err != io.EOF {
+				yield(zero, &service.RowDecodeError{Line: 1, Err: err})
+			}
+			return
+		}
+		header = append([]string(nil), header...)
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				var parseErr *csv.ParseError
+				if !errors.As(err, &parseErr) {
+					yield(zero, err)
+					return
+				}
+				if !yield(zero, &service.RowDecodeError{Line: parseErr.Line, Row: csvRowData(header, record), Err: parseErr.Err}) {
+					return
+				}
+				continue
+			}
+			line, _ := reader.FieldPos(0)
+			row, err := service.DecodeCSVRecord[T](header, record)
+			if err != nil {
+				err = &service.RowDecodeError{Line: line, Row: csvRowData(header, record), Err: err}
+			}
+			if !yield(row, err) {
+				return
+			}
+		}
+	}
+}
+
+func csvRowData(header, record []string) map[string]string {
+	data := make(map[string]string, len(record))
+	for i, value := range record {
+		key := "column_" + strconv.Itoa(i+1)
+		if i < len(header) {
+			key = header[i]
+		}
+		data[key] = value
+	}
+	return data
+}
+
+// ndjsonRows decodes one JSON object per line. Blank lines are skipped; a line
+// that does not fit T is yielded as a service.RowDecodeError.
+func ndjsonRows[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		reader := bufio.NewReader(r)
+		for line := 1; ; line++ {
+			raw, err := reader.ReadBytes('\n')
+			if err != nil && err != io.EOF {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 {
+				var row T
+				var rowErr error
+				if decodeErr := json.Unmarshal(trimmed, &row); decodeErr != nil {
+					rowErr = &service.RowDecodeError{Line: line, Row: string(trimmed), Err: decodeErr}
+				}
+				if !yield(row, rowErr) {
+					return
+				}
+			}
+			if err == io.EOF {
+				return
+			}
+		}
+	}
+}
@@ -58,14 +58,18 @@ func parseDateTimePtr(value *string) (*time.Time, error) {
 // @Tags MatchGames
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body matchGameRequest true "Match game payload"
 // @Success 201 {object} MatchGameResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /match-games [post]
 func (h *MatchGameHandler) Create(c *gin.Context) {
 	var req matchGameRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	startedAt, err := parseDateTimePtr(req.StartedAt)
@@ -90,7 +94,11 @@ func (h *MatchGameHandler) Create(c *gin.Context) {
 		PickBanPhase:      req.PickBanPhase,
 	}
 	if err := h.svc.Create(c.Request.Context(), g); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		if errors.Is(err, service.ErrResultsNotAllowed) {
+			RespondErr(c, http.StatusConflict, err)
+			return
+		}
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusCreated, g, nil)
@@ -99,6 +107,8 @@ func (h *MatchGameHandler) Create(c *gin.Context) {
 // @Summary Get match game
 // @Tags MatchGames
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Match game ID"
 // @Success 200 {object} MatchGameResponse
 // @Failure 400 {object} ErrorResponse
@@ -113,10 +123,10 @@ func (h *MatchGameHandler) Get(c *gin.Context) {
 	g, err := h.svc.Get(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrMatchGameNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusOK, g, nil)
@@ -125,6 +135,8 @@ func (h *MatchGameHandler) Get(c *gin.Context) {
 // @Summary List match games
 // @Tags MatchGames
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param match_id query int false "Match ID"
 // @Param winner_team_id query int false "Winner team ID"
 // @Param limit query int false "Page size"
@@ -149,7 +161,7 @@ func (h *MatchGameHandler) List(c *gin.Context) {
 	filter := models.MatchGameFilter{MatchID: matchID, WinnerTeamID: winnerID, Limit: limit, Offset: offset}
 	rows, total, err := h.svc.List(c.Request.Context(), filter)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -160,11 +172,15 @@ func (h *MatchGameHandler) List(c *gin.Context) {
 // @Tags MatchGames
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Match game ID"
 // @Param payload body matchGameRequest true "Match game payload"
 // @Success 200 {object} MatchGameResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /match-games/{id} [put]
 func (h *MatchGameHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -174,7 +190,7 @@ func (h *MatchGameHandler) Update(c *gin.Context) {
 	}
 	var req matchGameRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	startedAt, err := parseDateTimePtr(req.StartedAt)
@@ -201,10 +217,14 @@ func (h *MatchGameHandler) Update(c *gin.Context) {
 	}
 	if err := h.svc.Update(c.Request.Context(), g); err != nil {
 		if errors.Is(err, repository.ErrMatchGameNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, service.ErrResultsNotAllowed) {
+			RespondErr(c, http.StatusConflict, err)
 			return
 		}
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusOK, g, nil)
@@ -213,10 +233,13 @@ func (h *MatchGameHandler) Update(c *gin.Context) {
 // @Summary Delete match game
 // @Tags MatchGames
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Match game ID"
 // @Success 204 {object} EmptyResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /match-games/{id} [delete]
 func (h *MatchGameHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -226,10 +249,14 @@ func (h *MatchGameHandler) Delete(c *gin.Context) {
 	}
 	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
 		if errors.Is(err, repository.ErrMatchGameNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, service.ErrResultsNotAllowed) {
+			RespondErr(c, http.StatusConflict, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusNoContent, nil, nil)
@@ -50,14 +50,18 @@ func parseDateTime(value string) (time.Time, error) {
 // @Tags Matches
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body matchRequest true "Match payload"
 // @Success 201 {object} MatchResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /matches [post]
 func (h *MatchHandler) Create(c *gin.Context) {
 	var req matchRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	start, err := parseDateTime(req.StartTime)
@@ -86,7 +90,11 @@ func (h *MatchHandler) Create(c *gin.Context) {
 		MatchNotes:   matchNotes,
 	}
 	if err := h.svc.Create(c.Request.Context(), m); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		if errors.Is(err, service.ErrResultsNotAllowed) {
+			RespondErr(c, http.StatusConflict, err)
+			return
+		}
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusCreated, m, nil)
@@ -95,6 +103,8 @@ func (h *MatchHandler) Create(c *gin.Context) {
 // @Summary Get match
 // @Tags Matches
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Match ID"
 // @Success 200 {object} MatchResponse
 // @Failure 400 {object} ErrorResponse
@@ -109,10 +119,10 @@ func (h *MatchHandler) Get(c *gin.Context) {
 	m, err := h.svc.Get(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrMatchNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusOK, m, nil)
@@ -121,6 +131,8 @@ func (h *MatchHandler) Get(c *gin.Context) {
 // @Summary List matches
 // @Tags Matches
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param tournament_id query int false "Tournament ID"
 // @Param team_id query int false "Team ID"
 // @Param stage query string false "Stage"
@@ -170,7 +182,7 @@ func (h *MatchHandler) List(c *gin.Context) {
 	}
 	rows, total, err := h.svc.List(c.Request.Context(), filter)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -181,11 +193,15 @@ func (h *MatchHandler) List(c *gin.Context) {
 // @Tags Matches
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Match ID"
 // @Param payload body matchRequest true "Match payload"
 // @Success 200 {object} MatchResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /matches/{id} [put]
 func (h *MatchHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -195,7 +211,7 @@ func (h *MatchHandler) Update(c *gin.Context) {
 	}
 	var req matchRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	start, err := parseDateTime(req.StartTime)
@@ -226,10 +242,14 @@ func (h *MatchHandler) Update(c *gin.Context) {
 	}
 	if err := h.svc.Update(c.Request.Context(), m); err != nil {
 		if errors.Is(err, repository.ErrMatchNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, repository.ErrNextMatchHasResult) || errors.Is(err, service.ErrResultsNotAllowed) {
+			RespondErr(c, http.StatusConflict, err)
 			return
 		}
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusOK, m, nil)
@@ -238,10 +258,13 @@ func (h *MatchHandler) Update(c *gin.Context) {
 // @Summary Delete match
 // @Tags Matches
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Match ID"
 // @Success 204 {object} EmptyResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /matches/{id} [delete]
 func (h *MatchHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -251,10 +274,10 @@ func (h *MatchHandler) Delete(c *gin.Context) {
 	}
 	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
 		if errors.Is(err, repository.ErrMatchNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusNoContent, nil, nil)
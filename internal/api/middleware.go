@@ -0,0 +1,98 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"db_course_project/internal/audit"
+	"db_course_project/internal/auth"
+	"db_course_project/internal/service"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// RequestID takes the request id from the X-Request-ID header, or makes one
+// up, echoes it in the response and binds it to the request context so that
+// audit logs can be traced back to the request.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if id == "" || len(id) > 64 {
+			id = newRequestID()
+		}
+		c.Header(requestIDHeader, id)
+		c.Request = c.Request.WithContext(audit.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Authenticate requires a bearer token from POST /auth/login or an API key in
+// the X-API-Key header, and binds the principal to the request context, where
+// it is also the actor of the audit logs.
+func Authenticate(svc *service.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, err := authenticate(c, svc)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="api"`)
+			RespondErr(c, http.StatusUnauthorized, err)
+			c.Abort()
+			return
+		}
+		ctx := auth.WithPrincipal(c.Request.Context(), p)
+		c.Request = c.Request.WithContext(audit.WithActor(ctx, p.Actor()))
+		c.Next()
+	}
+}
+
+func authenticate(c *gin.Context, svc *service.AuthService) (*auth.Principal, error) {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return svc.AuthenticateKey(c.Request.Context(), key)
+	}
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, auth.ErrUnauthenticated
+	}
+	return svc.Authenticate(c.Request.Context(), strings.TrimSpace(token))
+}
+
+// RequireRole lets only the given roles through. Admins are always let
+// through.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok := auth.FromContext(c.Request.Context())
+		if !ok {
+			RespondError(c, http.StatusUnauthorized, auth.ErrUnauthenticated.Error())
+			c.Abort()
+			return
+		}
+		if p.Role != auth.RoleAdmin && !p.HasRole(roles...) {
+			RespondError(c, http.StatusForbidden, auth.ErrForbidden.Error())
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireRoleToWrite lets every authenticated user read and only the given
+// roles, and admins, change anything.
+func RequireRoleToWrite(roles ...string) gin.HandlerFunc {
+	require := RequireRole(roles...)
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+		require(c)
+	}
+}
@@ -55,14 +55,18 @@ func parseDatePtr(value *string) (*time.Time, error) {
 // @Tags Players
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body playerRequest true "Player payload"
 // @Success 201 {object} PlayerResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /players [post]
 func (h *PlayerHandler) Create(c *gin.Context) {
 	var req playerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	birth, err := parseDatePtr(req.BirthDate)
@@ -87,7 +91,7 @@ func (h *PlayerHandler) Create(c *gin.Context) {
 		player.IsRetired = *req.IsRetired
 	}
 	if err := h.svc.Create(c.Request.Context(), player); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusCreated, player, nil)
@@ -96,6 +100,8 @@ func (h *PlayerHandler) Create(c *gin.Context) {
 // @Summary Get player
 // @Tags Players
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Player ID"
 // @Success 200 {object} PlayerResponse
 // @Failure 400 {object} ErrorResponse
@@ -110,10 +116,10 @@ func (h *PlayerHandler) Get(c *gin.Context) {
 	player, err := h.svc.Get(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrPlayerNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusOK, player, nil)
@@ -122,6 +128,8 @@ func (h *PlayerHandler) Get(c *gin.Context) {
 // @Summary List players
 // @Tags Players
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param search query string false "Search by nickname"
 // @Param country_code query string false "Country code"
 // @Param is_retired query bool false "Retired flag"
@@ -163,7 +171,7 @@ func (h *PlayerHandler) List(c *gin.Context) {
 	}
 	rows, total, err := h.svc.List(c.Request.Context(), filter)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -174,11 +182,15 @@ func (h *PlayerHandler) List(c *gin.Context) {
 // @Tags Players
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Player ID"
 // @Param payload body playerRequest true "Player payload"
 // @Success 200 {object} PlayerResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /players/{id} [put]
 func (h *PlayerHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -188,7 +200,7 @@ func (h *PlayerHandler) Update(c *gin.Context) {
 	}
 	var req playerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	birth, err := parseDatePtr(req.BirthDate)
@@ -215,10 +227,10 @@ func (h *PlayerHandler) Update(c *gin.Context) {
 	}
 	if err := h.svc.Update(c.Request.Context(), player); err != nil {
 		if errors.Is(err, repository.ErrPlayerNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusOK, player, nil)
@@ -227,10 +239,13 @@ func (h *PlayerHandler) Update(c *gin.Context) {
 // @Summary Delete player
 // @Tags Players
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Player ID"
 // @Success 204 {object} EmptyResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /players/{id} [delete]
 func (h *PlayerHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -240,10 +255,10 @@ func (h *PlayerHandler) Delete(c *gin.Context) {
 	}
 	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
 		if errors.Is(err, repository.ErrPlayerNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusNoContent, nil, nil)
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
+	"db_course_project/internal/service"
+)
+
+type RatingHandler struct {
+	svc *service.RatingService
+}
+
+func NewRatingHandler(svc *service.RatingService) *RatingHandler {
+	return &RatingHandler{svc: svc}
+}
+
+func (h *RatingHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/ratings/teams", h.TeamRatings)
+	rg.GET("/ratings/players", h.PlayerRatings)
+	rg.GET("/teams/:id/rating-history", h.TeamHistory)
+	rg.GET("/players/:id/rating-history", h.PlayerHistory)
+}
+
+// RegisterAdmin adds the routes that rewrite stored ratings.
+func (h *RatingHandler) RegisterAdmin(rg *gin.RouterGroup) {
+	rg.POST("/admin/ratings/recompute", h.Recompute)
+}
+
+// @Summary Team rating leaderboard
+// @Tags Ratings
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param discipline_id query int false "Discipline ID"
+// @Param limit query int false "Page size"
+// @Param offset query int false "Offset"
+// @Success 200 {object} TeamRatingListResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /ratings/teams [get]
+func (h *RatingHandler) TeamRatings(c *gin.Context) {
+	limit, offset := ParsePagination(c)
+	filter := models.RatingFilter{DisciplineID: queryDisciplineID(c), Limit: limit, Offset: offset}
+	rows, total, err := h.svc.TeamRatings(c.Request.Context(), filter)
+	if err != nil {
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
+	RespondData(c, http.StatusOK, rows, meta)
+}
+
+// @Summary Player rating leaderboard
+// @Tags Ratings
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param discipline_id query int false "Discipline ID"
+// @Param limit query int false "Page size"
+// @Param offset query int false "Offset"
+// @Success 200 {object} PlayerRatingListResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /ratings/players [get]
+func (h *RatingHandler) PlayerRatings(c *gin.Context) {
+	limit, offset := ParsePagination(c)
+	filter := models.RatingFilter{DisciplineID: queryDisciplineID(c), Limit: limit, Offset: offset}
+	rows, total, err := h.svc.PlayerRatings(c.Request.Context(), filter)
+	if err != nil {
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
+	RespondData(c, http.StatusOK, rows, meta)
+}
+
+// @Summary Recompute ratings from match history
+// @Tags Ratings
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param discipline_id query int false "Discipline ID, all disciplines when omitted"
+// @Success 200 {object} RatingRecomputeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/ratings/recompute [post]
+func (h *RatingHandler) Recompute(c *gin.Context) {
+	var disciplineID *int64
+	if v := c.Query("discipline_id"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid discipline_id")
+			return
+		}
+		disciplineID = &parsed
+	}
+	results, err := h.svc.Recompute(c.Request.Context(), disciplineID)
+	if err != nil {
+		if errors.Is(err, repository.ErrDisciplineNotFound) {
+			RespondErr(c, http.StatusNotFound, err)
+			return
+		}
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	RespondData(c, http.StatusOK, results, nil)
+}
+
+// @Summary Team rating history
+// @Tags Ratings
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Team ID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date, inclusive (YYYY-MM-DD)"
+// @Param interval query string false "Downsampling: daily or weekly"
+// @Success 200 {object} RatingHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /teams/{id}/rating-history [get]
+func (h *RatingHandler) TeamHistory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	filter, ok := parseRatingHistoryFilter(c)
+	if !ok {
+		return
+	}
+	rows, err := h.svc.TeamHistory(c.Request.Context(), id, filter)
+	if err != nil {
+		respondRatingHistoryError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, rows, nil)
+}
+
+// @Summary Player rating history
+// @Tags Ratings
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Player ID"
+// @Param discipline_id query int false "Discipline ID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date, inclusive (YYYY-MM-DD)"
+// @Param interval query string false "Downsampling: daily or weekly"
+// @Success 200 {object} RatingHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /players/{id}/rating-history [get]
+func (h *RatingHandler) PlayerHistory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	filter, ok := parseRatingHistoryFilter(c)
+	if !ok {
+		return
+	}
+	filter.DisciplineID = queryDisciplineID(c)
+	rows, err := h.svc.PlayerHistory(c.Request.Context(), id, filter)
+	if err != nil {
+		respondRatingHistoryError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, rows, nil)
+}
+
+func parseRatingHistoryFilter(c *gin.Context) (models.RatingHistoryFilter, bool) {
+	filter := models.RatingHistoryFilter{Interval: c.Query("interval")}
+	if v := c.Query("from"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid from")
+			return filter, false
+		}
+		filter.From = &parsed
+	}
+	if v := c.Query("to"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid to")
+			return filter, false
+		}
+		parsed = parsed.AddDate(0, 0, 1)
+		filter.To = &parsed
+	}
+	return filter, true
+}
+
+func respondRatingHistoryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTeamNotFound), errors.Is(err, repository.ErrPlayerNotFound):
+		RespondErr(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidRatingInterval), errors.Is(err, service.ErrInvalidRatingRange):
+		RespondErr(c, http.StatusBadRequest, err)
+	default:
+		RespondErr(c, http.StatusInternalServerError, err)
+	}
+}
+
+func queryDisciplineID(c *gin.Context) *int64 {
+	if v := c.Query("discipline_id"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return &parsed
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 
+	"db_course_project/internal/auth"
 	"db_course_project/internal/models"
 	"db_course_project/internal/service"
 )
@@ -16,6 +17,11 @@ type PaginationMeta struct {
 // swagger:model
 type ErrorDetail struct {
 	Message string `json:"message"`
+	// Code, Field and Constraint describe a rejected value, e.g. a unique or
+	// foreign key violation.
+	Code       string `json:"code,omitempty"`
+	Field      string `json:"field,omitempty"`
+	Constraint string `json:"constraint,omitempty"`
 }
 
 // swagger:model
@@ -77,6 +83,12 @@ type TournamentListResponse struct {
 	Meta PaginationMeta      `json:"meta"`
 }
 
+// swagger:model
+type TournamentStatusHistoryResponse struct {
+	Data []models.TournamentStatusChange `json:"data"`
+	Meta interface{}                     `json:"meta"`
+}
+
 // swagger:model
 type TournamentRegistrationResponse struct {
 	Data models.TournamentRegistration `json:"data"`
@@ -89,6 +101,12 @@ type TournamentRegistrationListResponse struct {
 	Meta PaginationMeta                  `json:"meta"`
 }
 
+// swagger:model
+type RegistrationStatusChangeResponse struct {
+	Data models.RegistrationStatusChange `json:"data"`
+	Meta interface{}                     `json:"meta"`
+}
+
 // swagger:model
 type MatchResponse struct {
 	Data models.Match `json:"data"`
@@ -149,18 +167,72 @@ type TeamProfileListResponse struct {
 	Meta PaginationMeta       `json:"meta"`
 }
 
+// swagger:model
+type BracketResponse struct {
+	Data models.BracketView `json:"data"`
+	Meta interface{}        `json:"meta"`
+}
+
 // swagger:model
 type ImportSummaryResponse struct {
 	Data service.ImportSummary `json:"data"`
 	Meta interface{}           `json:"meta"`
 }
 
+// swagger:model
+type ImportJobResponse struct {
+	Data models.ImportJob `json:"data"`
+	Meta interface{}      `json:"meta"`
+}
+
+// swagger:model
+type ImportJobListResponse struct {
+	Data []models.ImportJob `json:"data"`
+	Meta PaginationMeta     `json:"meta"`
+}
+
+// swagger:model
+type ImportErrorResponse struct {
+	Data models.ImportError `json:"data"`
+	Meta interface{}        `json:"meta"`
+}
+
+// swagger:model
+type ImportErrorListResponse struct {
+	Data []models.ImportError `json:"data"`
+	Meta PaginationMeta       `json:"meta"`
+}
+
+// swagger:model
+type ImportRetryResponse struct {
+	Data []service.ImportRetryResult `json:"data"`
+	Meta interface{}                 `json:"meta"`
+}
+
+// swagger:model
+type ImportPurgeResponse struct {
+	Data service.ImportPurgeResult `json:"data"`
+	Meta interface{}               `json:"meta"`
+}
+
+// swagger:model
+type MatchDocumentResponse struct {
+	Data service.MatchDocumentResult `json:"data"`
+	Meta interface{}                 `json:"meta"`
+}
+
 // swagger:model
 type ActiveRostersResponse struct {
 	Data []models.ActiveRosterView `json:"data"`
 	Meta PaginationMeta            `json:"meta"`
 }
 
+// swagger:model
+type RosterHealthResponse struct {
+	Data []models.RosterHealthView `json:"data"`
+	Meta PaginationMeta            `json:"meta"`
+}
+
 // swagger:model
 type MatchResultsResponse struct {
 	Data []models.MatchResultView `json:"data"`
@@ -191,6 +263,66 @@ type PlayerKDAResponse struct {
 	Meta interface{}   `json:"meta"`
 }
 
+// swagger:model
+type TeamRatingListResponse struct {
+	Data []models.TeamRating `json:"data"`
+	Meta PaginationMeta      `json:"meta"`
+}
+
+// swagger:model
+type PlayerRatingListResponse struct {
+	Data []models.PlayerRating `json:"data"`
+	Meta PaginationMeta        `json:"meta"`
+}
+
+// swagger:model
+type RatingHistoryResponse struct {
+	Data []models.RatingHistoryPoint `json:"data"`
+	Meta interface{}                 `json:"meta"`
+}
+
+// swagger:model
+type RatingRecomputeResponse struct {
+	Data []models.RatingRecompute `json:"data"`
+	Meta interface{}              `json:"meta"`
+}
+
+// swagger:model
+type LoginResponse struct {
+	Data service.LoginResult `json:"data"`
+	Meta interface{}         `json:"meta"`
+}
+
+// swagger:model
+type PrincipalResponse struct {
+	Data auth.Principal `json:"data"`
+	Meta interface{}    `json:"meta"`
+}
+
+// swagger:model
+type APIKeyListResponse struct {
+	Data []models.APIKey `json:"data"`
+	Meta interface{}     `json:"meta"`
+}
+
+// swagger:model
+type CreatedAPIKeyResponse struct {
+	Data service.CreatedAPIKey `json:"data"`
+	Meta interface{}           `json:"meta"`
+}
+
+// swagger:model
+type UserResponse struct {
+	Data models.User `json:"data"`
+	Meta interface{} `json:"meta"`
+}
+
+// swagger:model
+type UserListResponse struct {
+	Data []models.User  `json:"data"`
+	Meta PaginationMeta `json:"meta"`
+}
+
 func RespondData(c *gin.Context, status int, data any, meta any) {
 	c.JSON(status, gin.H{
 		"data": data,
@@ -59,14 +59,18 @@ func parseDateValue(value string) (time.Time, error) {
 // @Tags SquadMembers
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body squadMemberRequest true "Squad member payload"
 // @Success 201 {object} SquadMemberResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /squad-members [post]
 func (h *SquadMemberHandler) Create(c *gin.Context) {
 	var req squadMemberRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	joinDate := time.Now().UTC().Truncate(24 * time.Hour)
@@ -103,15 +107,22 @@ func (h *SquadMemberHandler) Create(c *gin.Context) {
 		SalaryMonthly:   req.SalaryMonthly,
 	}
 	if err := h.svc.Create(c.Request.Context(), m); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		if errors.Is(err, service.ErrRosterOversize) {
+			RespondErr(c, http.StatusConflict, err)
+			return
+		}
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusCreated, m, nil)
 }
 
 // @Summary Get squad member
+// @Description salary_monthly and contract_end_date are null unless the user is an organizer or manages the team.
 // @Tags SquadMembers
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Squad member ID"
 // @Success 200 {object} SquadMemberResponse
 // @Failure 400 {object} ErrorResponse
@@ -126,18 +137,21 @@ func (h *SquadMemberHandler) Get(c *gin.Context) {
 	m, err := h.svc.Get(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrSquadMemberNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusOK, m, nil)
 }
 
 // @Summary List squad members
+// @Description salary_monthly and contract_end_date are null unless the user is an organizer or manages the team.
 // @Tags SquadMembers
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param team_id query int false "Team ID"
 // @Param player_id query int false "Player ID"
 // @Param active_only query bool false "Only active"
@@ -164,7 +178,7 @@ func (h *SquadMemberHandler) List(c *gin.Context) {
 	filter := models.SquadMemberFilter{TeamID: teamID, PlayerID: playerID, ActiveOnly: activeOnly, Limit: limit, Offset: offset}
 	rows, total, err := h.svc.List(c.Request.Context(), filter)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -175,11 +189,15 @@ func (h *SquadMemberHandler) List(c *gin.Context) {
 // @Tags SquadMembers
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Squad member ID"
 // @Param payload body squadMemberRequest true "Squad member payload"
 // @Success 200 {object} SquadMemberResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /squad-members/{id} [put]
 func (h *SquadMemberHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -189,7 +207,7 @@ func (h *SquadMemberHandler) Update(c *gin.Context) {
 	}
 	var req squadMemberRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	if req.JoinDate == "" {
@@ -228,10 +246,14 @@ func (h *SquadMemberHandler) Update(c *gin.Context) {
 	}
 	if err := h.svc.Update(c.Request.Context(), m); err != nil {
 		if errors.Is(err, repository.ErrSquadMemberNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, service.ErrRosterOversize) {
+			RespondErr(c, http.StatusConflict, err)
 			return
 		}
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusOK, m, nil)
@@ -240,10 +262,13 @@ func (h *SquadMemberHandler) Update(c *gin.Context) {
 // @Summary Remove squad member
 // @Tags SquadMembers
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Squad member ID"
 // @Success 204 {object} EmptyResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /squad-members/{id} [delete]
 func (h *SquadMemberHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -253,10 +278,10 @@ func (h *SquadMemberHandler) Delete(c *gin.Context) {
 	}
 	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
 		if errors.Is(err, repository.ErrSquadMemberNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusNoContent, nil, nil)
@@ -41,14 +41,18 @@ type teamProfileRequest struct {
 // @Tags TeamProfiles
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body teamProfileRequest true "Team profile payload"
 // @Success 201 {object} TeamProfileResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /team-profiles [post]
 func (h *TeamProfileHandler) Create(c *gin.Context) {
 	var req teamProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	p := &models.TeamProfile{
@@ -60,7 +64,7 @@ func (h *TeamProfileHandler) Create(c *gin.Context) {
 		ContactEmail: req.ContactEmail,
 	}
 	if err := h.svc.Create(c.Request.Context(), p); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusCreated, p, nil)
@@ -69,6 +73,8 @@ func (h *TeamProfileHandler) Create(c *gin.Context) {
 // @Summary Get team profile
 // @Tags TeamProfiles
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param team_id path int true "Team ID"
 // @Success 200 {object} TeamProfileResponse
 // @Failure 400 {object} ErrorResponse
@@ -83,10 +89,10 @@ func (h *TeamProfileHandler) Get(c *gin.Context) {
 	p, err := h.svc.Get(c.Request.Context(), teamID)
 	if err != nil {
 		if errors.Is(err, repository.ErrTeamProfileNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusOK, p, nil)
@@ -95,6 +101,8 @@ func (h *TeamProfileHandler) Get(c *gin.Context) {
 // @Summary List team profiles
 // @Tags TeamProfiles
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param team_id query int false "Team ID"
 // @Param limit query int false "Page size"
 // @Param offset query int false "Offset"
@@ -112,7 +120,7 @@ func (h *TeamProfileHandler) List(c *gin.Context) {
 	filter := models.TeamProfileFilter{TeamID: teamID, Limit: limit, Offset: offset}
 	rows, total, err := h.svc.List(c.Request.Context(), filter)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -123,11 +131,15 @@ func (h *TeamProfileHandler) List(c *gin.Context) {
 // @Tags TeamProfiles
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param team_id path int true "Team ID"
 // @Param payload body teamProfileRequest true "Team profile payload"
 // @Success 200 {object} TeamProfileResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /team-profiles/{team_id} [put]
 func (h *TeamProfileHandler) Update(c *gin.Context) {
 	teamID, err := strconv.ParseInt(c.Param("team_id"), 10, 64)
@@ -137,7 +149,7 @@ func (h *TeamProfileHandler) Update(c *gin.Context) {
 	}
 	var req teamProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	p := &models.TeamProfile{
@@ -150,10 +162,10 @@ func (h *TeamProfileHandler) Update(c *gin.Context) {
 	}
 	if err := h.svc.Update(c.Request.Context(), p); err != nil {
 		if errors.Is(err, repository.ErrTeamProfileNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusOK, p, nil)
@@ -162,10 +174,13 @@ func (h *TeamProfileHandler) Update(c *gin.Context) {
 // @Summary Delete team profile
 // @Tags TeamProfiles
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param team_id path int true "Team ID"
 // @Success 204 {object} EmptyResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /team-profiles/{team_id} [delete]
 func (h *TeamProfileHandler) Delete(c *gin.Context) {
 	teamID, err := strconv.ParseInt(c.Param("team_id"), 10, 64)
@@ -175,10 +190,10 @@ func (h *TeamProfileHandler) Delete(c *gin.Context) {
 	}
 	if err := h.svc.Delete(c.Request.Context(), teamID); err != nil {
 		if errors.Is(err, repository.ErrTeamProfileNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusNoContent, nil, nil)
@@ -42,14 +42,18 @@ type teamRequest struct {
 // @Tags Teams
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body teamRequest true "Team payload"
 // @Success 201 {object} TeamResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /teams [post]
 func (h *TeamHandler) Create(c *gin.Context) {
 	var req teamRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	team := &models.Team{
@@ -68,7 +72,7 @@ func (h *TeamHandler) Create(c *gin.Context) {
 		team.IsVerified = *req.IsVerified
 	}
 	if err := h.svc.Create(c.Request.Context(), team); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusCreated, team, nil)
@@ -77,6 +81,8 @@ func (h *TeamHandler) Create(c *gin.Context) {
 // @Summary Get team
 // @Tags Teams
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Team ID"
 // @Success 200 {object} TeamResponse
 // @Failure 400 {object} ErrorResponse
@@ -91,10 +97,10 @@ func (h *TeamHandler) Get(c *gin.Context) {
 	team, err := h.svc.Get(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrTeamNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusOK, team, nil)
@@ -103,6 +109,8 @@ func (h *TeamHandler) Get(c *gin.Context) {
 // @Summary List teams
 // @Tags Teams
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param search query string false "Search by name or tag"
 // @Param country_code query string false "Country code"
 // @Param discipline_id query int false "Discipline ID"
@@ -136,7 +144,7 @@ func (h *TeamHandler) List(c *gin.Context) {
 	}
 	rows, total, err := h.svc.List(c.Request.Context(), filter)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -147,11 +155,15 @@ func (h *TeamHandler) List(c *gin.Context) {
 // @Tags Teams
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Team ID"
 // @Param payload body teamRequest true "Team payload"
 // @Success 200 {object} TeamResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /teams/{id} [put]
 func (h *TeamHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -161,7 +173,7 @@ func (h *TeamHandler) Update(c *gin.Context) {
 	}
 	var req teamRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	team := &models.Team{
@@ -182,10 +194,10 @@ func (h *TeamHandler) Update(c *gin.Context) {
 	}
 	if err := h.svc.Update(c.Request.Context(), team); err != nil {
 		if errors.Is(err, repository.ErrTeamNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusOK, team, nil)
@@ -194,10 +206,13 @@ func (h *TeamHandler) Update(c *gin.Context) {
 // @Summary Delete team
 // @Tags Teams
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Team ID"
 // @Success 204 {object} EmptyResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /teams/{id} [delete]
 func (h *TeamHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -207,10 +222,10 @@ func (h *TeamHandler) Delete(c *gin.Context) {
 	}
 	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
 		if errors.Is(err, repository.ErrTeamNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusNoContent, nil, nil)
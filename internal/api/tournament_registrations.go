@@ -1,7 +1,6 @@
 package api
 
 import (
-	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
@@ -27,30 +26,37 @@ func (h *TournamentRegistrationHandler) Register(rg *gin.RouterGroup) {
 	rg.GET("/tournament-registrations/:id", h.Get)
 	rg.PUT("/tournament-registrations/:id", h.Update)
 	rg.DELETE("/tournament-registrations/:id", h.Delete)
+	rg.POST("/tournament-registrations/:id/approve", h.Approve)
+	rg.POST("/tournament-registrations/:id/reject", h.Reject)
+	rg.POST("/tournament-registrations/:id/withdraw", h.Withdraw)
+	rg.POST("/tournament-registrations/:id/roster-snapshot", h.RefreshRoster)
 }
 
 type tournamentRegistrationRequest struct {
-	TournamentID   int64           `json:"tournament_id" binding:"required"`
-	TeamID         int64           `json:"team_id" binding:"required"`
-	SeedNumber     *int            `json:"seed_number"`
-	Status         string          `json:"status"`
-	ManagerContact *string         `json:"manager_contact"`
-	RosterSnapshot json.RawMessage `json:"roster_snapshot" swaggertype:"object"`
-	IsInvited      *bool           `json:"is_invited"`
+	TournamentID   int64   `json:"tournament_id" binding:"required"`
+	TeamID         int64   `json:"team_id" binding:"required"`
+	SeedNumber     *int    `json:"seed_number"`
+	Status         string  `json:"status"`
+	ManagerContact *string `json:"manager_contact"`
+	IsInvited      *bool   `json:"is_invited"`
 }
 
 // @Summary Create tournament registration
 // @Tags TournamentRegistrations
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body tournamentRegistrationRequest true "Registration payload"
 // @Success 201 {object} TournamentRegistrationResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /tournament-registrations [post]
 func (h *TournamentRegistrationHandler) Create(c *gin.Context) {
 	var req tournamentRegistrationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	isInvited := false
@@ -63,11 +69,14 @@ func (h *TournamentRegistrationHandler) Create(c *gin.Context) {
 		SeedNumber:     req.SeedNumber,
 		Status:         req.Status,
 		ManagerContact: req.ManagerContact,
-		RosterSnapshot: req.RosterSnapshot,
 		IsInvited:      isInvited,
 	}
 	if err := h.svc.Create(c.Request.Context(), reg); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		if errors.Is(err, service.ErrRegistrationClosed) || errors.Is(err, service.ErrRosterShortHanded) {
+			RespondErr(c, http.StatusConflict, err)
+			return
+		}
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusCreated, reg, nil)
@@ -76,6 +85,8 @@ func (h *TournamentRegistrationHandler) Create(c *gin.Context) {
 // @Summary Get tournament registration
 // @Tags TournamentRegistrations
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Registration ID"
 // @Success 200 {object} TournamentRegistrationResponse
 // @Failure 400 {object} ErrorResponse
@@ -90,10 +101,10 @@ func (h *TournamentRegistrationHandler) Get(c *gin.Context) {
 	reg, err := h.svc.Get(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrTournamentRegistrationNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusOK, reg, nil)
@@ -102,6 +113,8 @@ func (h *TournamentRegistrationHandler) Get(c *gin.Context) {
 // @Summary List tournament registrations
 // @Tags TournamentRegistrations
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param tournament_id query int false "Tournament ID"
 // @Param team_id query int false "Team ID"
 // @Param status query string false "Status"
@@ -133,7 +146,7 @@ func (h *TournamentRegistrationHandler) List(c *gin.Context) {
 	}
 	rows, total, err := h.svc.List(c.Request.Context(), filter)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -144,11 +157,15 @@ func (h *TournamentRegistrationHandler) List(c *gin.Context) {
 // @Tags TournamentRegistrations
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Registration ID"
 // @Param payload body tournamentRegistrationRequest true "Registration payload"
 // @Success 200 {object} TournamentRegistrationResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /tournament-registrations/{id} [put]
 func (h *TournamentRegistrationHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -158,7 +175,7 @@ func (h *TournamentRegistrationHandler) Update(c *gin.Context) {
 	}
 	var req tournamentRegistrationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	isInvited := false
@@ -172,15 +189,18 @@ func (h *TournamentRegistrationHandler) Update(c *gin.Context) {
 		SeedNumber:     req.SeedNumber,
 		Status:         req.Status,
 		ManagerContact: req.ManagerContact,
-		RosterSnapshot: req.RosterSnapshot,
 		IsInvited:      isInvited,
 	}
 	if err := h.svc.Update(c.Request.Context(), reg); err != nil {
 		if errors.Is(err, repository.ErrTournamentRegistrationNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusBadRequest, err.Error())
+		if errors.Is(err, service.ErrRegistrationStatusManaged) || errors.Is(err, service.ErrRegistrationLocked) || errors.Is(err, service.ErrRosterShortHanded) {
+			RespondErr(c, http.StatusConflict, err)
+			return
+		}
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusOK, reg, nil)
@@ -189,10 +209,13 @@ func (h *TournamentRegistrationHandler) Update(c *gin.Context) {
 // @Summary Delete tournament registration
 // @Tags TournamentRegistrations
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Registration ID"
 // @Success 204 {object} EmptyResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /tournament-registrations/{id} [delete]
 func (h *TournamentRegistrationHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -201,12 +224,129 @@ func (h *TournamentRegistrationHandler) Delete(c *gin.Context) {
 		return
 	}
 	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
-		if errors.Is(err, repository.ErrTournamentRegistrationNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
-			return
-		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		respondRegistrationActionError(c, err)
 		return
 	}
 	RespondData(c, http.StatusNoContent, nil, nil)
 }
+
+// @Summary Approve tournament registration
+// @Tags TournamentRegistrations
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Registration ID"
+// @Success 200 {object} RegistrationStatusChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /tournament-registrations/{id}/approve [post]
+func (h *TournamentRegistrationHandler) Approve(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	change, err := h.svc.Approve(c.Request.Context(), id)
+	if err != nil {
+		respondRegistrationActionError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, change, nil)
+}
+
+// @Summary Reject tournament registration
+// @Tags TournamentRegistrations
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Registration ID"
+// @Success 200 {object} RegistrationStatusChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /tournament-registrations/{id}/reject [post]
+func (h *TournamentRegistrationHandler) Reject(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	change, err := h.svc.Reject(c.Request.Context(), id)
+	if err != nil {
+		respondRegistrationActionError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, change, nil)
+}
+
+// @Summary Withdraw tournament registration
+// @Tags TournamentRegistrations
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Registration ID"
+// @Success 200 {object} RegistrationStatusChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /tournament-registrations/{id}/withdraw [post]
+func (h *TournamentRegistrationHandler) Withdraw(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	change, err := h.svc.Withdraw(c.Request.Context(), id)
+	if err != nil {
+		respondRegistrationActionError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, change, nil)
+}
+
+// @Summary Recapture registration roster snapshot
+// @Tags TournamentRegistrations
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Registration ID"
+// @Success 200 {object} TournamentRegistrationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /tournament-registrations/{id}/roster-snapshot [post]
+func (h *TournamentRegistrationHandler) RefreshRoster(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	reg, err := h.svc.RefreshRoster(c.Request.Context(), id)
+	if err != nil {
+		respondRegistrationActionError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, reg, nil)
+}
+
+func respondRegistrationActionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTournamentRegistrationNotFound),
+		errors.Is(err, repository.ErrTournamentNotFound):
+		RespondErr(c, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrRegistrationAction),
+		errors.Is(err, service.ErrRegistrationLocked),
+		errors.Is(err, repository.ErrTournamentFull),
+		errors.Is(err, repository.ErrRosterLocked),
+		errors.Is(err, service.ErrRosterShortHanded),
+		errors.Is(err, repository.ErrRegistrationStatusChanged):
+		RespondErr(c, http.StatusConflict, err)
+	default:
+		RespondErr(c, http.StatusInternalServerError, err)
+	}
+}
@@ -28,6 +28,8 @@ func (h *TournamentHandler) Register(rg *gin.RouterGroup) {
 	rg.GET("/tournaments/:id", h.Get)
 	rg.PUT("/tournaments/:id", h.Update)
 	rg.DELETE("/tournaments/:id", h.Delete)
+	rg.POST("/tournaments/:id/transition", h.Transition)
+	rg.GET("/tournaments/:id/status-history", h.StatusHistory)
 }
 
 type tournamentRequest struct {
@@ -40,6 +42,11 @@ type tournamentRequest struct {
 	Status        string          `json:"status"`
 	IsOnline      *bool           `json:"is_online"`
 	BracketConfig json.RawMessage `json:"bracket_config" swaggertype:"object"`
+	MaxTeams      *int            `json:"max_teams"`
+}
+
+type tournamentTransitionRequest struct {
+	Status string `json:"status" binding:"required"`
 }
 
 func parseDate(value string) (time.Time, error) {
@@ -50,14 +57,18 @@ func parseDate(value string) (time.Time, error) {
 // @Tags Tournaments
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body tournamentRequest true "Tournament payload"
 // @Success 201 {object} TournamentResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /tournaments [post]
 func (h *TournamentHandler) Create(c *gin.Context) {
 	var req tournamentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	start, err := parseDate(req.StartDate)
@@ -84,9 +95,14 @@ func (h *TournamentHandler) Create(c *gin.Context) {
 		Status:        req.Status,
 		IsOnline:      isOnline,
 		BracketConfig: req.BracketConfig,
+		MaxTeams:      req.MaxTeams,
 	}
 	if err := h.svc.Create(c.Request.Context(), t); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		if errors.Is(err, service.ErrStatusViaTransition) {
+			RespondErr(c, http.StatusConflict, err)
+			return
+		}
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusCreated, t, nil)
@@ -95,6 +111,8 @@ func (h *TournamentHandler) Create(c *gin.Context) {
 // @Summary Get tournament
 // @Tags Tournaments
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Tournament ID"
 // @Success 200 {object} TournamentResponse
 // @Failure 400 {object} ErrorResponse
@@ -109,10 +127,10 @@ func (h *TournamentHandler) Get(c *gin.Context) {
 	t, err := h.svc.Get(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrTournamentNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusOK, t, nil)
@@ -121,6 +139,8 @@ func (h *TournamentHandler) Get(c *gin.Context) {
 // @Summary List tournaments
 // @Tags Tournaments
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param search query string false "Search by name"
 // @Param discipline_id query int false "Discipline ID"
 // @Param status query string false "Tournament status"
@@ -162,7 +182,7 @@ func (h *TournamentHandler) List(c *gin.Context) {
 	}
 	rows, total, err := h.svc.List(c.Request.Context(), filter)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -173,11 +193,15 @@ func (h *TournamentHandler) List(c *gin.Context) {
 // @Tags Tournaments
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Tournament ID"
 // @Param payload body tournamentRequest true "Tournament payload"
 // @Success 200 {object} TournamentResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Router /tournaments/{id} [put]
 func (h *TournamentHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -187,7 +211,7 @@ func (h *TournamentHandler) Update(c *gin.Context) {
 	}
 	var req tournamentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	start, err := parseDate(req.StartDate)
@@ -215,13 +239,18 @@ func (h *TournamentHandler) Update(c *gin.Context) {
 		Status:        req.Status,
 		IsOnline:      isOnline,
 		BracketConfig: req.BracketConfig,
+		MaxTeams:      req.MaxTeams,
 	}
 	if err := h.svc.Update(c.Request.Context(), t); err != nil {
 		if errors.Is(err, repository.ErrTournamentNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, service.ErrStatusViaTransition) {
+			RespondErr(c, http.StatusConflict, err)
 			return
 		}
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
 	RespondData(c, http.StatusOK, t, nil)
@@ -230,10 +259,13 @@ func (h *TournamentHandler) Update(c *gin.Context) {
 // @Summary Delete tournament
 // @Tags Tournaments
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param id path int true "Tournament ID"
 // @Success 204 {object} EmptyResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /tournaments/{id} [delete]
 func (h *TournamentHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -243,11 +275,82 @@ func (h *TournamentHandler) Delete(c *gin.Context) {
 	}
 	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
 		if errors.Is(err, repository.ErrTournamentNotFound) {
-			RespondError(c, http.StatusNotFound, err.Error())
+			RespondErr(c, http.StatusNotFound, err)
 			return
 		}
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusNoContent, nil, nil)
 }
+
+// @Summary Change tournament status
+// @Tags Tournaments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Tournament ID"
+// @Param payload body tournamentTransitionRequest true "Target status"
+// @Success 200 {object} TournamentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /tournaments/{id}/transition [post]
+func (h *TournamentHandler) Transition(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	var req tournamentTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	t, err := h.svc.Transition(c.Request.Context(), id, req.Status)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrTournamentNotFound):
+			RespondErr(c, http.StatusNotFound, err)
+		case errors.Is(err, service.ErrInvalidTransition),
+			errors.Is(err, service.ErrTournamentHasOpenMatches),
+			errors.Is(err, repository.ErrTournamentStatusChanged):
+			RespondErr(c, http.StatusConflict, err)
+		default:
+			RespondErr(c, http.StatusBadRequest, err)
+		}
+		return
+	}
+	RespondData(c, http.StatusOK, t, nil)
+}
+
+// @Summary Tournament status history
+// @Tags Tournaments
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "Tournament ID"
+// @Success 200 {object} TournamentStatusHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tournaments/{id}/status-history [get]
+func (h *TournamentHandler) StatusHistory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	rows, err := h.svc.StatusHistory(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTournamentNotFound) {
+			RespondErr(c, http.StatusNotFound, err)
+			return
+		}
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	RespondData(c, http.StatusOK, rows, nil)
+}
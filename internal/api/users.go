@@ -0,0 +1,199 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
+	"db_course_project/internal/service"
+)
+
+type UserHandler struct {
+	svc *service.AuthService
+}
+
+func NewUserHandler(svc *service.AuthService) *UserHandler {
+	return &UserHandler{svc: svc}
+}
+
+type userRequest struct {
+	Username string `json:"username" binding:"required"`
+	// Password is required for new users; on update an empty password keeps
+	// the current one.
+	Password string `json:"password"`
+	Role     string `json:"role" binding:"required"`
+	TeamID   *int64 `json:"team_id"`
+	IsActive *bool  `json:"is_active"`
+}
+
+func (h *UserHandler) Register(rg *gin.RouterGroup) {
+	rg.POST("/users", h.Create)
+	rg.GET("/users", h.List)
+	rg.GET("/users/:id", h.Get)
+	rg.PUT("/users/:id", h.Update)
+	rg.DELETE("/users/:id", h.Delete)
+}
+
+func (req userRequest) user(id int64) *models.User {
+	u := &models.User{ID: id, Username: req.Username, Role: req.Role, TeamID: req.TeamID, IsActive: true}
+	if req.IsActive != nil {
+		u.IsActive = *req.IsActive
+	}
+	return u
+}
+
+// @Summary Create user
+// @Description Roles: viewer, team_manager (with the team_id it manages), organizer, admin.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param payload body userRequest true "User payload"
+// @Success 201 {object} UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /users [post]
+func (h *UserHandler) Create(c *gin.Context) {
+	var req userRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	u := req.user(0)
+	if err := h.svc.CreateUser(c.Request.Context(), u, req.Password); err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	RespondData(c, http.StatusCreated, u, nil)
+}
+
+// @Summary List users
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param role query string false "Role"
+// @Param limit query int false "Page size"
+// @Param offset query int false "Offset"
+// @Success 200 {object} UserListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users [get]
+func (h *UserHandler) List(c *gin.Context) {
+	limit, offset := ParsePagination(c)
+	filter := models.UserFilter{Role: c.Query("role"), Limit: limit, Offset: offset}
+	rows, total, err := h.svc.ListUsers(c.Request.Context(), filter)
+	if err != nil {
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
+	RespondData(c, http.StatusOK, rows, meta)
+}
+
+// @Summary Get user
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id} [get]
+func (h *UserHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	u, err := h.svc.GetUser(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			RespondErr(c, http.StatusNotFound, err)
+			return
+		}
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	RespondData(c, http.StatusOK, u, nil)
+}
+
+// @Summary Update user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "User ID"
+// @Param payload body userRequest true "User payload"
+// @Success 200 {object} UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /users/{id} [put]
+func (h *UserHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	var req userRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	u := req.user(id)
+	if err := h.svc.UpdateUser(c.Request.Context(), u, req.Password); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			RespondErr(c, http.StatusNotFound, err)
+			return
+		}
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	RespondData(c, http.StatusOK, u, nil)
+}
+
+// @Summary Delete user
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path int true "User ID"
+// @Success 204 {object} EmptyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id} [delete]
+func (h *UserHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+	if err := h.svc.DeleteUser(c.Request.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			RespondErr(c, http.StatusNotFound, err)
+			return
+		}
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	RespondData(c, http.StatusNoContent, nil, nil)
+}
@@ -1,22 +1,30 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"iter"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gocarina/gocsv"
 
+	"db_course_project/internal/bracket"
+	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
 	"db_course_project/internal/service"
 )
 
 type UtilityHandler struct {
 	reports  *service.ReportService
 	importer *service.ImportService
+	jobs     *service.ImportJobService
+	streams  map[string]streamImporter
 }
 
-func NewUtilityHandler(reports *service.ReportService, importer *service.ImportService) *UtilityHandler {
-	return &UtilityHandler{reports: reports, importer: importer}
+func NewUtilityHandler(reports *service.ReportService, importer *service.ImportService, jobs *service.ImportJobService) *UtilityHandler {
+	return &UtilityHandler{reports: reports, importer: importer, jobs: jobs, streams: newStreamImporters(importer)}
 }
 
 func (h *UtilityHandler) Register(rg *gin.RouterGroup) {
@@ -32,6 +40,7 @@ func (h *UtilityHandler) Register(rg *gin.RouterGroup) {
 	rg.POST("/batch-import/tournament-registrations/csv", h.BatchImportTournamentRegistrationsCSV)
 	rg.POST("/batch-import/matches", h.BatchImportMatches)
 	rg.POST("/batch-import/matches/csv", h.BatchImportMatchesCSV)
+	rg.POST("/batch-import/matches/nested", h.BatchImportMatchDocument)
 	rg.POST("/batch-import/match-games", h.BatchImportMatchGames)
 	rg.POST("/batch-import/match-games/csv", h.BatchImportMatchGamesCSV)
 	rg.POST("/batch-import/game-player-stats", h.BatchImportGamePlayerStats)
@@ -40,7 +49,9 @@ func (h *UtilityHandler) Register(rg *gin.RouterGroup) {
 	rg.POST("/batch-import/squad-members/csv", h.BatchImportSquadMembersCSV)
 	rg.POST("/batch-import/team-profiles", h.BatchImportTeamProfiles)
 	rg.POST("/batch-import/team-profiles/csv", h.BatchImportTeamProfilesCSV)
+	rg.POST("/batch-import/stream/:entity", h.BatchImportStream)
 	rg.GET("/reports/active-rosters", h.ActiveRosters)
+	rg.GET("/reports/roster-health", h.RosterHealth)
 	rg.GET("/reports/match-results", h.MatchResults)
 	rg.GET("/reports/player-career", h.PlayerCareer)
 	rg.GET("/reports/tournament-standings", h.TournamentStandings)
@@ -60,469 +71,614 @@ func bindCSV[T any](c *gin.Context, field string, out *[]T) error {
 	return gocsv.Unmarshal(file, out)
 }
 
+func parseImportOptions(c *gin.Context) (service.ImportOptions, bool) {
+	opts := service.ImportOptions{}
+	if v := c.Query("atomic"); v != "" {
+		atomic, err := strconv.ParseBool(v)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid atomic")
+			return opts, false
+		}
+		opts.Atomic = atomic
+	}
+	if v := c.Query("dry_run"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid dry_run")
+			return opts, false
+		}
+		opts.DryRun = dryRun
+	}
+	if v := c.Query("restore"); v != "" {
+		restore, err := strconv.ParseBool(v)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid restore")
+			return opts, false
+		}
+		opts.Restore = restore
+	}
+	opts.OnConflict = c.Query("on_conflict")
+	return opts, true
+}
+
+// runImport imports payload right away, or queues it as a background job when
+// async is set.
+func runImport[T any](c *gin.Context, jobs *service.ImportJobService, entity, source string, payload []T, importFn func(context.Context, string, iter.Seq2[T, error], service.ImportOptions) (service.ImportSummary, error)) {
+	opts, ok := parseImportOptions(c)
+	if !ok {
+		return
+	}
+	async := false
+	if v := c.Query("async"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid async")
+			return
+		}
+		async = parsed
+	}
+	if async {
+		job, err := jobs.Enqueue(c.Request.Context(), entity, source, payload, len(payload), opts)
+		if err != nil {
+			respondImportError(c, err)
+			return
+		}
+		RespondData(c, http.StatusAccepted, job, nil)
+		return
+	}
+	summary, err := importFn(c.Request.Context(), source, service.RowsOf(payload), opts)
+	if err != nil {
+		respondImportError(c, err)
+		return
+	}
+	RespondData(c, http.StatusOK, summary, nil)
+}
+
+func respondImportError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrInvalidOnConflict) || errors.Is(err, service.ErrUnknownImportEntity) {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	RespondErr(c, http.StatusInternalServerError, err)
+}
+
 // @Summary Batch import players
 // @Tags Utility
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body []service.PlayerImportInput true "Players to import"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/players [post]
 func (h *UtilityHandler) BatchImportPlayers(c *gin.Context) {
 	var payload []service.PlayerImportInput
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportPlayers(c.Request.Context(), "players_api", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityPlayers, "players_api", payload, h.importer.ImportPlayers)
 }
 
 // @Summary Batch import players from CSV
 // @Tags Utility
 // @Accept mpfd
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param file formData file true "CSV file"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/players/csv [post]
 func (h *UtilityHandler) BatchImportPlayersCSV(c *gin.Context) {
 	var payload []service.PlayerImportInput
 	if err := bindCSV(c, "file", &payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
-		return
-	}
-	summary, err := h.importer.ImportPlayers(c.Request.Context(), "players_csv", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityPlayers, "players_csv", payload, h.importer.ImportPlayers)
 }
 
 // @Summary Batch import disciplines
 // @Tags Utility
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body []service.DisciplineImportInput true "Disciplines to import"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/disciplines [post]
 func (h *UtilityHandler) BatchImportDisciplines(c *gin.Context) {
 	var payload []service.DisciplineImportInput
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportDisciplines(c.Request.Context(), "disciplines_api", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityDisciplines, "disciplines_api", payload, h.importer.ImportDisciplines)
 }
 
 // @Summary Batch import disciplines from CSV
 // @Tags Utility
 // @Accept mpfd
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param file formData file true "CSV file"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/disciplines/csv [post]
 func (h *UtilityHandler) BatchImportDisciplinesCSV(c *gin.Context) {
 	var payload []service.DisciplineImportInput
 	if err := bindCSV(c, "file", &payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportDisciplines(c.Request.Context(), "disciplines_csv", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityDisciplines, "disciplines_csv", payload, h.importer.ImportDisciplines)
 }
 
 // @Summary Batch import teams
 // @Tags Utility
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body []service.TeamImportInput true "Teams to import"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/teams [post]
 func (h *UtilityHandler) BatchImportTeams(c *gin.Context) {
 	var payload []service.TeamImportInput
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportTeams(c.Request.Context(), "teams_api", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityTeams, "teams_api", payload, h.importer.ImportTeams)
 }
 
 // @Summary Batch import teams from CSV
 // @Tags Utility
 // @Accept mpfd
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param file formData file true "CSV file"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/teams/csv [post]
 func (h *UtilityHandler) BatchImportTeamsCSV(c *gin.Context) {
 	var payload []service.TeamImportInput
 	if err := bindCSV(c, "file", &payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
-		return
-	}
-	summary, err := h.importer.ImportTeams(c.Request.Context(), "teams_csv", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityTeams, "teams_csv", payload, h.importer.ImportTeams)
 }
 
 // @Summary Batch import tournaments
 // @Tags Utility
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body []service.TournamentImportInput true "Tournaments to import"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param restore query bool false "Keep the exported status of new rows, to load an export back"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/tournaments [post]
 func (h *UtilityHandler) BatchImportTournaments(c *gin.Context) {
 	var payload []service.TournamentImportInput
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportTournaments(c.Request.Context(), "tournaments_api", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityTournaments, "tournaments_api", payload, h.importer.ImportTournaments)
 }
 
 // @Summary Batch import tournaments from CSV
 // @Tags Utility
 // @Accept mpfd
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param file formData file true "CSV file"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param restore query bool false "Keep the exported status of new rows, to load an export back"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/tournaments/csv [post]
 func (h *UtilityHandler) BatchImportTournamentsCSV(c *gin.Context) {
 	var payload []service.TournamentImportInput
 	if err := bindCSV(c, "file", &payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
-		return
-	}
-	summary, err := h.importer.ImportTournaments(c.Request.Context(), "tournaments_csv", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityTournaments, "tournaments_csv", payload, h.importer.ImportTournaments)
 }
 
 // @Summary Batch import tournament registrations
 // @Tags Utility
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body []service.TournamentRegistrationImportInput true "Registrations to import"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param restore query bool false "Keep the exported status of new rows, to load an export back"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/tournament-registrations [post]
 func (h *UtilityHandler) BatchImportTournamentRegistrations(c *gin.Context) {
 	var payload []service.TournamentRegistrationImportInput
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
-		return
-	}
-	summary, err := h.importer.ImportTournamentRegistrations(c.Request.Context(), "tournament_registrations_api", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityTournamentRegistrations, "tournament_registrations_api", payload, h.importer.ImportTournamentRegistrations)
 }
 
 // @Summary Batch import tournament registrations from CSV
 // @Tags Utility
 // @Accept mpfd
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param file formData file true "CSV file"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param restore query bool false "Keep the exported status of new rows, to load an export back"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/tournament-registrations/csv [post]
 func (h *UtilityHandler) BatchImportTournamentRegistrationsCSV(c *gin.Context) {
 	var payload []service.TournamentRegistrationImportInput
 	if err := bindCSV(c, "file", &payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
-		return
-	}
-	summary, err := h.importer.ImportTournamentRegistrations(c.Request.Context(), "tournament_registrations_csv", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityTournamentRegistrations, "tournament_registrations_csv", payload, h.importer.ImportTournamentRegistrations)
 }
 
 // @Summary Batch import matches
 // @Tags Utility
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body []service.MatchImportInput true "Matches to import"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/matches [post]
 func (h *UtilityHandler) BatchImportMatches(c *gin.Context) {
 	var payload []service.MatchImportInput
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
-		return
-	}
-	summary, err := h.importer.ImportMatches(c.Request.Context(), "matches_api", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityMatches, "matches_api", payload, h.importer.ImportMatches)
 }
 
 // @Summary Batch import matches from CSV
 // @Tags Utility
 // @Accept mpfd
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param file formData file true "CSV file"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/matches/csv [post]
 func (h *UtilityHandler) BatchImportMatchesCSV(c *gin.Context) {
 	var payload []service.MatchImportInput
 	if err := bindCSV(c, "file", &payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportMatches(c.Request.Context(), "matches_csv", payload)
+	runImport(c, h.jobs, models.ImportEntityMatches, "matches_csv", payload, h.importer.ImportMatches)
+}
+
+// @Summary Import a match with its games and player stats
+// @Tags Utility
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param payload body service.MatchDocumentInput true "Match with nested games and player stats"
+// @Success 201 {object} MatchDocumentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /batch-import/matches/nested [post]
+func (h *UtilityHandler) BatchImportMatchDocument(c *gin.Context) {
+	var payload service.MatchDocumentInput
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		RespondErr(c, http.StatusBadRequest, err)
+		return
+	}
+	res, err := h.importer.ImportMatchDocument(c.Request.Context(), "matches_nested_api", payload)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		switch {
+		case errors.Is(err, repository.ErrTournamentNotFound), errors.Is(err, repository.ErrTeamNotFound),
+			errors.Is(err, repository.ErrPlayerNotFound):
+			RespondErr(c, http.StatusNotFound, err)
+		case errors.Is(err, service.ErrResultsNotAllowed), errors.Is(err, service.ErrSeriesWinnerMismatch):
+			RespondErr(c, http.StatusConflict, err)
+		default:
+			RespondErr(c, http.StatusBadRequest, err)
+		}
 		return
 	}
-	RespondData(c, http.StatusOK, summary, nil)
+	RespondData(c, http.StatusCreated, res, nil)
 }
 
 // @Summary Batch import match games
 // @Tags Utility
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body []service.MatchGameImportInput true "Match games to import"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/match-games [post]
 func (h *UtilityHandler) BatchImportMatchGames(c *gin.Context) {
 	var payload []service.MatchGameImportInput
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportMatchGames(c.Request.Context(), "match_games_api", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityMatchGames, "match_games_api", payload, h.importer.ImportMatchGames)
 }
 
 // @Summary Batch import match games from CSV
 // @Tags Utility
 // @Accept mpfd
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param file formData file true "CSV file"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/match-games/csv [post]
 func (h *UtilityHandler) BatchImportMatchGamesCSV(c *gin.Context) {
 	var payload []service.MatchGameImportInput
 	if err := bindCSV(c, "file", &payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportMatchGames(c.Request.Context(), "match_games_csv", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityMatchGames, "match_games_csv", payload, h.importer.ImportMatchGames)
 }
 
 // @Summary Batch import game player stats
 // @Tags Utility
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body []service.GamePlayerStatImportInput true "Game player stats to import"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/game-player-stats [post]
 func (h *UtilityHandler) BatchImportGamePlayerStats(c *gin.Context) {
 	var payload []service.GamePlayerStatImportInput
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportGamePlayerStats(c.Request.Context(), "game_player_stats_api", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityGamePlayerStats, "game_player_stats_api", payload, h.importer.ImportGamePlayerStats)
 }
 
 // @Summary Batch import game player stats from CSV
 // @Tags Utility
 // @Accept mpfd
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param file formData file true "CSV file"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/game-player-stats/csv [post]
 func (h *UtilityHandler) BatchImportGamePlayerStatsCSV(c *gin.Context) {
 	var payload []service.GamePlayerStatImportInput
 	if err := bindCSV(c, "file", &payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportGamePlayerStats(c.Request.Context(), "game_player_stats_csv", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityGamePlayerStats, "game_player_stats_csv", payload, h.importer.ImportGamePlayerStats)
 }
 
 // @Summary Batch import squad members
 // @Tags Utility
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body []service.SquadMemberImportInput true "Squad members to import"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/squad-members [post]
 func (h *UtilityHandler) BatchImportSquadMembers(c *gin.Context) {
 	var payload []service.SquadMemberImportInput
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
-		return
-	}
-	summary, err := h.importer.ImportSquadMembers(c.Request.Context(), "squad_members_api", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntitySquadMembers, "squad_members_api", payload, h.importer.ImportSquadMembers)
 }
 
 // @Summary Batch import squad members from CSV
 // @Tags Utility
 // @Accept mpfd
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param file formData file true "CSV file"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/squad-members/csv [post]
 func (h *UtilityHandler) BatchImportSquadMembersCSV(c *gin.Context) {
 	var payload []service.SquadMemberImportInput
 	if err := bindCSV(c, "file", &payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportSquadMembers(c.Request.Context(), "squad_members_csv", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntitySquadMembers, "squad_members_csv", payload, h.importer.ImportSquadMembers)
 }
 
 // @Summary Batch import team profiles
 // @Tags Utility
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param payload body []service.TeamProfileImportInput true "Team profiles to import"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/team-profiles [post]
 func (h *UtilityHandler) BatchImportTeamProfiles(c *gin.Context) {
 	var payload []service.TeamProfileImportInput
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportTeamProfiles(c.Request.Context(), "team_profiles_api", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityTeamProfiles, "team_profiles_api", payload, h.importer.ImportTeamProfiles)
 }
 
 // @Summary Batch import team profiles from CSV
 // @Tags Utility
 // @Accept mpfd
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param file formData file true "CSV file"
+// @Param atomic query bool false "Roll back the whole batch if any row fails"
+// @Param dry_run query bool false "Validate the batch without saving anything"
+// @Param on_conflict query string false "Existing rows: error (default), skip or update"
+// @Param async query bool false "Queue the batch as a background import job"
 // @Success 200 {object} ImportSummaryResponse
+// @Success 202 {object} ImportJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /batch-import/team-profiles/csv [post]
 func (h *UtilityHandler) BatchImportTeamProfilesCSV(c *gin.Context) {
 	var payload []service.TeamProfileImportInput
 	if err := bindCSV(c, "file", &payload); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondErr(c, http.StatusBadRequest, err)
 		return
 	}
-	summary, err := h.importer.ImportTeamProfiles(c.Request.Context(), "team_profiles_csv", payload)
-	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-	RespondData(c, http.StatusOK, summary, nil)
+	runImport(c, h.jobs, models.ImportEntityTeamProfiles, "team_profiles_csv", payload, h.importer.ImportTeamProfiles)
 }
 
 // @Summary Active roster report
 // @Tags Utility
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param limit query int false "Page size"
 // @Param offset query int false "Offset"
 // @Success 200 {object} ActiveRostersResponse
@@ -532,7 +688,41 @@ func (h *UtilityHandler) ActiveRosters(c *gin.Context) {
 	limit, offset := ParsePagination(c)
 	rows, total, err := h.reports.ActiveRosters(c.Request.Context(), limit, offset)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
+		return
+	}
+	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
+	RespondData(c, http.StatusOK, rows, meta)
+}
+
+// @Summary Roster health report
+// @Tags Utility
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param discipline_id query int false "Discipline ID"
+// @Param status query string false "short_handed, over_staffed or ok; teams with issues by default"
+// @Param limit query int false "Page size"
+// @Param offset query int false "Offset"
+// @Success 200 {object} RosterHealthResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reports/roster-health [get]
+func (h *UtilityHandler) RosterHealth(c *gin.Context) {
+	limit, offset := ParsePagination(c)
+	filter := models.RosterHealthFilter{Status: c.Query("status"), Limit: limit, Offset: offset}
+	if v := c.Query("discipline_id"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			filter.DisciplineID = &parsed
+		}
+	}
+	rows, total, err := h.reports.RosterHealth(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRosterStatus) {
+			RespondErr(c, http.StatusBadRequest, err)
+			return
+		}
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -542,6 +732,8 @@ func (h *UtilityHandler) ActiveRosters(c *gin.Context) {
 // @Summary Match results report
 // @Tags Utility
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param tournament_id query int false "Tournament ID"
 // @Param limit query int false "Page size"
 // @Param offset query int false "Offset"
@@ -558,7 +750,7 @@ func (h *UtilityHandler) MatchResults(c *gin.Context) {
 	}
 	rows, total, err := h.reports.MatchResults(c.Request.Context(), tournamentID, limit, offset)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -568,6 +760,8 @@ func (h *UtilityHandler) MatchResults(c *gin.Context) {
 // @Summary Player career report
 // @Tags Utility
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param search query string false "Search by nickname"
 // @Param limit query int false "Page size"
 // @Param offset query int false "Offset"
@@ -579,7 +773,7 @@ func (h *UtilityHandler) PlayerCareer(c *gin.Context) {
 	search := c.Query("search")
 	rows, total, err := h.reports.PlayerCareer(c.Request.Context(), search, limit, offset)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	meta := PaginationMeta{Total: total, Limit: limit, Offset: offset}
@@ -589,9 +783,16 @@ func (h *UtilityHandler) PlayerCareer(c *gin.Context) {
 // @Summary Tournament standings report
 // @Tags Utility
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param tournament_id query int true "Tournament ID"
+// @Param stage query string false "Match stage"
+// @Param points_win query int false "Points for a win"
+// @Param points_draw query int false "Points for a draw"
+// @Param points_loss query int false "Points for a loss"
 // @Success 200 {object} TournamentStandingsResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /reports/tournament-standings [get]
 func (h *UtilityHandler) TournamentStandings(c *gin.Context) {
@@ -605,9 +806,39 @@ func (h *UtilityHandler) TournamentStandings(c *gin.Context) {
 		RespondError(c, http.StatusBadRequest, "invalid tournament_id")
 		return
 	}
-	rows, err := h.reports.TournamentStandings(c.Request.Context(), tid)
+	filter := models.StandingsFilter{TournamentID: tid}
+	if v := c.Query("stage"); v != "" {
+		filter.Stage = &v
+	}
+	for _, p := range []struct {
+		name string
+		dst  **int
+	}{
+		{"points_win", &filter.PointsWin},
+		{"points_draw", &filter.PointsDraw},
+		{"points_loss", &filter.PointsLoss},
+	} {
+		v := c.Query(p.name)
+		if v == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid "+p.name)
+			return
+		}
+		*p.dst = &parsed
+	}
+	rows, err := h.reports.TournamentStandings(c.Request.Context(), filter)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		switch {
+		case errors.Is(err, repository.ErrTournamentNotFound):
+			RespondErr(c, http.StatusNotFound, err)
+		case errors.Is(err, bracket.ErrInvalidPoints):
+			RespondErr(c, http.StatusBadRequest, err)
+		default:
+			RespondErr(c, http.StatusInternalServerError, err)
+		}
 		return
 	}
 	RespondData(c, http.StatusOK, rows, nil)
@@ -616,6 +847,8 @@ func (h *UtilityHandler) TournamentStandings(c *gin.Context) {
 // @Summary Player KDA report
 // @Tags Utility
 // @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param player_id query int true "Player ID"
 // @Success 200 {object} PlayerKDAResponse
 // @Failure 400 {object} ErrorResponse
@@ -634,7 +867,7 @@ func (h *UtilityHandler) PlayerKDA(c *gin.Context) {
 	}
 	kda, err := h.reports.PlayerKDA(c.Request.Context(), pid)
 	if err != nil {
-		RespondError(c, http.StatusInternalServerError, err.Error())
+		RespondErr(c, http.StatusInternalServerError, err)
 		return
 	}
 	RespondData(c, http.StatusOK, gin.H{"player_id": pid, "kda": kda}, nil)
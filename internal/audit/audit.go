@@ -0,0 +1,29 @@
+// Package audit carries the actor and request of a change to the
+// audit_log_changes trigger, which records them in audit_logs.
+package audit
+
+import "context"
+
+type actorKey struct{}
+
+type requestIDKey struct{}
+
+// WithActor names who the changes made with ctx are recorded for, e.g. a user
+// or an import job.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,68 @@
+// Package auth holds the roles of API users, the principal a request acts as
+// and the credentials that identify it: bearer tokens and API keys.
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+const (
+	RoleViewer      = "viewer"
+	RoleTeamManager = "team_manager"
+	RoleOrganizer   = "organizer"
+	RoleAdmin       = "admin"
+)
+
+var Roles = []string{RoleViewer, RoleTeamManager, RoleOrganizer, RoleAdmin}
+
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("not allowed for this user")
+)
+
+// Principal is the user a request acts as.
+type Principal struct {
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	// TeamID is the team a team manager manages.
+	TeamID *int64 `json:"team_id"`
+	// APIKey is the name of the key the request was authenticated with.
+	APIKey string `json:"api_key,omitempty"`
+}
+
+func (p *Principal) HasRole(roles ...string) bool {
+	return slices.Contains(roles, p.Role)
+}
+
+// ManagesOnly reports whether p may only change the data of its own team.
+func (p *Principal) ManagesOnly() bool {
+	return p.Role == RoleTeamManager
+}
+
+// Actor names p in audit logs.
+func (p *Principal) Actor() string {
+	if p.APIKey != "" {
+		return p.Username + " (api key " + p.APIKey + ")"
+	}
+	return p.Username
+}
+
+func ValidRole(role string) bool {
+	return slices.Contains(Roles, role)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal of ctx. Background work such as import
+// jobs runs without one.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// tokenHeader is the only JOSE header issued and accepted.
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type claims struct {
+	Subject   string `json:"sub"`
+	Username  string `json:"name"`
+	Role      string `json:"role"`
+	TeamID    *int64 `json:"team_id,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Tokens issues and verifies HS256 JSON web tokens.
+type Tokens struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokens(secret []byte, ttl time.Duration) *Tokens {
+	return &Tokens{secret: secret, ttl: ttl}
+}
+
+// Issue returns a token for p and the time it expires.
+func (t *Tokens) Issue(p Principal) (string, time.Time, error) {
+	now := time.Now()
+	expires := now.Add(t.ttl)
+	payload, err := json.Marshal(claims{
+		Subject:   strconv.FormatInt(p.UserID, 10),
+		Username:  p.Username,
+		Role:      p.Role,
+		TeamID:    p.TeamID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expires.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	signed := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + t.sign(signed), expires, nil
+}
+
+// Parse verifies a token and returns the principal it was issued for.
+func (t *Tokens) Parse(token string) (*Principal, error) {
+	header, rest, ok := strings.Cut(token, ".")
+	if !ok || header != tokenHeader {
+		return nil, ErrInvalidToken
+	}
+	payload, signature, ok := strings.Cut(rest, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(t.sign(header+"."+payload))) {
+		return nil, ErrInvalidToken
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(raw, &c); err != nil || time.Now().Unix() >= c.ExpiresAt {
+		return nil, ErrInvalidToken
+	}
+	id, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil || !ValidRole(c.Role) {
+		return nil, ErrInvalidToken
+	}
+	return &Principal{UserID: id, Username: c.Username, Role: c.Role, TeamID: c.TeamID}, nil
+}
+
+func (t *Tokens) sign(s string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(s))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,155 @@
+package bracket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"db_course_project/internal/series"
+)
+
+const (
+	TypeSingleElim = "single_elim"
+	TypeDoubleElim = "double_elim"
+	TypeRoundRobin = "round_robin"
+	TypeSwiss      = "swiss"
+)
+
+const (
+	SectionUpper      = "upper"
+	SectionLower      = "lower"
+	SectionGrandFinal = "grand_final"
+	SectionThirdPlace = "third_place"
+	SectionGroup      = "group"
+	SectionSwiss      = "swiss"
+)
+
+const (
+	DefaultFormat        = "bo3"
+	DefaultMatchInterval = 180
+)
+
+var (
+	ErrNoConfig      = errors.New("bracket_config is not set")
+	ErrInvalidPoints = errors.New("points must satisfy win >= draw >= loss")
+)
+
+type Config struct {
+	Type                 string  `json:"type"`
+	Format               string  `json:"format,omitempty"`
+	FinalFormat          string  `json:"final_format,omitempty"`
+	ThirdPlaceMatch      bool    `json:"third_place_match,omitempty"`
+	Rounds               int     `json:"rounds,omitempty"`
+	MatchIntervalMinutes int     `json:"match_interval_minutes,omitempty"`
+	Points               *Points `json:"points,omitempty"`
+}
+
+// Points awarded per match result in standings tables.
+type Points struct {
+	Win  int `json:"win"`
+	Draw int `json:"draw"`
+	Loss int `json:"loss"`
+}
+
+var DefaultPoints = Points{Win: 3, Draw: 1, Loss: 0}
+
+func ParseConfig(raw json.RawMessage) (Config, error) {
+	var cfg Config
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return cfg, ErrNoConfig
+	}
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("invalid bracket_config: %w", err)
+	}
+	if err := cfg.normalize(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) normalize() error {
+	c.Type = strings.ToLower(strings.TrimSpace(c.Type))
+	c.Format = strings.ToLower(strings.TrimSpace(c.Format))
+	c.FinalFormat = strings.ToLower(strings.TrimSpace(c.FinalFormat))
+
+	switch c.Type {
+	case TypeSingleElim, TypeDoubleElim, TypeRoundRobin, TypeSwiss:
+	case "":
+		return errors.New("bracket_config.type is required")
+	default:
+		return fmt.Errorf("bracket_config.type must be one of %s, %s, %s, %s", TypeSingleElim, TypeDoubleElim, TypeRoundRobin, TypeSwiss)
+	}
+	if c.Format == "" {
+		c.Format = DefaultFormat
+	}
+	if c.FinalFormat == "" {
+		c.FinalFormat = c.Format
+	}
+	for _, field := range [][2]string{{"format", c.Format}, {"final_format", c.FinalFormat}} {
+		f, err := series.Parse(field[1])
+		if err != nil {
+			return fmt.Errorf("bracket_config.%s: %w", field[0], err)
+		}
+		if c.IsElimination() && f.Games%2 == 0 {
+			return fmt.Errorf("bracket_config.%s %s can end in a draw and cannot be used in elimination brackets", field[0], f.Name)
+		}
+	}
+	if c.ThirdPlaceMatch && c.Type != TypeSingleElim {
+		return errors.New("bracket_config.third_place_match is only valid for single_elim")
+	}
+	if c.Rounds < 0 {
+		return errors.New("bracket_config.rounds cannot be negative")
+	}
+	if c.Rounds > 0 && c.Type != TypeSwiss {
+		return errors.New("bracket_config.rounds is only valid for swiss")
+	}
+	if c.MatchIntervalMinutes < 0 {
+		return errors.New("bracket_config.match_interval_minutes cannot be negative")
+	}
+	if c.MatchIntervalMinutes == 0 {
+		c.MatchIntervalMinutes = DefaultMatchInterval
+	}
+	if c.Points != nil {
+		if err := c.Points.Validate(); err != nil {
+			return fmt.Errorf("bracket_config.points: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c Config) IsElimination() bool {
+	return c.Type == TypeSingleElim || c.Type == TypeDoubleElim
+}
+
+// SwissRounds returns the configured number of Swiss rounds, or enough rounds
+// to separate a single undefeated team when none is configured.
+func (c Config) SwissRounds(teams int) int {
+	if c.Rounds > 0 {
+		return c.Rounds
+	}
+	rounds := 0
+	for size := 1; size < teams; size *= 2 {
+		rounds++
+	}
+	return rounds
+}
+
+// StandingPoints returns the configured points or DefaultPoints.
+func (c Config) StandingPoints() Points {
+	if c.Points == nil {
+		return DefaultPoints
+	}
+	return *c.Points
+}
+
+func (p Points) Validate() error {
+	if p.Win < p.Draw || p.Draw < p.Loss {
+		return ErrInvalidPoints
+	}
+	return nil
+}
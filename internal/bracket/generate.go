@@ -0,0 +1,436 @@
+package bracket
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Match is one planned match of a generated bracket. Links refer to other
+// matches of the same Plan by index.
+type Match struct {
+	Section  string
+	Round    int
+	Position int
+	Order    int
+	Stage    string
+	Format   string
+	Team1ID  *int64
+	Team2ID  *int64
+	WinnerTo *Link
+	LoserTo  *Link
+	// Bye marks a Swiss bye: Team1ID sits the round out and is credited
+	// with a win.
+	Bye bool
+}
+
+type Link struct {
+	Match int
+	Slot  int
+}
+
+type Plan struct {
+	Matches []Match
+}
+
+var ErrNotEnoughTeams = errors.New("at least 2 teams are required to build a bracket")
+
+// Generate builds the bracket for teams given in seed order (best seed first).
+// For swiss only the first round is generated; later rounds depend on results.
+func Generate(cfg Config, teams []int64) (Plan, error) {
+	if len(teams) < 2 {
+		return Plan{}, ErrNotEnoughTeams
+	}
+	switch cfg.Type {
+	case TypeSingleElim:
+		return singleElim(cfg, teams), nil
+	case TypeDoubleElim:
+		return doubleElim(cfg, teams), nil
+	case TypeRoundRobin:
+		return roundRobin(cfg, teams), nil
+	case TypeSwiss:
+		seeded := make([]SwissStanding, len(teams))
+		for i, id := range teams {
+			seeded[i] = SwissStanding{TeamID: id, Seed: i + 1}
+		}
+		seeded, bye := takeBye(seeded)
+		half := len(seeded) / 2
+		pairs := make([][2]int64, 0, half)
+		for i := 0; i < half; i++ {
+			pairs = append(pairs, [2]int64{seeded[i].TeamID, seeded[i+half].TeamID})
+		}
+		return SwissRound(cfg, 1, pairs, bye), nil
+	default:
+		return Plan{}, fmt.Errorf("unsupported bracket type %q", cfg.Type)
+	}
+}
+
+// SwissRound turns the pairings of one Swiss round into a plan. A bye is
+// planned after the pairings as a match of its own.
+func SwissRound(cfg Config, round int, pairs [][2]int64, bye *int64) Plan {
+	plan := Plan{}
+	for i, p := range pairs {
+		team1, team2 := p[0], p[1]
+		plan.Matches = append(plan.Matches, Match{
+			Section:  SectionSwiss,
+			Round:    round,
+			Position: i + 1,
+			Order:    round - 1,
+			Stage:    "Swiss Stage",
+			Format:   cfg.Format,
+			Team1ID:  &team1,
+			Team2ID:  &team2,
+		})
+	}
+	if bye != nil {
+		team := *bye
+		plan.Matches = append(plan.Matches, Match{
+			Section:  SectionSwiss,
+			Round:    round,
+			Position: len(pairs) + 1,
+			Order:    round - 1,
+			Stage:    "Swiss Stage",
+			Format:   cfg.Format,
+			Team1ID:  &team,
+			Bye:      true,
+		})
+	}
+	return plan
+}
+
+type sourceKind int
+
+const (
+	srcTeam sourceKind = iota
+	srcBye
+	srcWinner
+	srcLoser
+)
+
+type source struct {
+	kind  sourceKind
+	team  int64
+	match int
+}
+
+type node struct {
+	section  string
+	round    int
+	position int
+	order    int
+	stage    string
+	format   string
+	slots    [2]source
+	removed  bool
+}
+
+type builder struct {
+	nodes []*node
+}
+
+func (b *builder) add(n *node) int {
+	b.nodes = append(b.nodes, n)
+	return len(b.nodes) - 1
+}
+
+func winnerOf(i int) source { return source{kind: srcWinner, match: i} }
+func loserOf(i int) source  { return source{kind: srcLoser, match: i} }
+
+// seedSlots places seeds into a bracket of the given power-of-two size so that
+// the top seeds meet as late as possible. Seeds beyond the team count are byes.
+func seedSlots(teams []int64, size int) []source {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		sum := len(order)*2 + 1
+		for _, s := range order {
+			next = append(next, s, sum-s)
+		}
+		order = next
+	}
+	slots := make([]source, size)
+	for i, seed := range order {
+		if seed <= len(teams) {
+			slots[i] = source{kind: srcTeam, team: teams[seed-1]}
+		} else {
+			slots[i] = source{kind: srcBye}
+		}
+	}
+	return slots
+}
+
+func bracketSize(teams int) (size, rounds int) {
+	size = 1
+	for size < teams {
+		size *= 2
+		rounds++
+	}
+	return size, rounds
+}
+
+// upperBracket adds the winners' side of an elimination bracket and returns
+// the match indexes grouped by round.
+func (b *builder) upperBracket(cfg Config, teams []int64, section string, stageName func(round, rounds int) string, order func(round int) int) [][]int {
+	size, rounds := bracketSize(len(teams))
+	slots := seedSlots(teams, size)
+	byRound := make([][]int, rounds+1)
+	for r := 1; r <= rounds; r++ {
+		count := size >> r
+		for i := 0; i < count; i++ {
+			n := &node{
+				section:  section,
+				round:    r,
+				position: i + 1,
+				order:    order(r),
+				stage:    stageName(r, rounds),
+				format:   cfg.Format,
+			}
+			if r == 1 {
+				n.slots = [2]source{slots[2*i], slots[2*i+1]}
+			} else {
+				prev := byRound[r-1]
+				n.slots = [2]source{winnerOf(prev[2*i]), winnerOf(prev[2*i+1])}
+			}
+			byRound[r] = append(byRound[r], b.add(n))
+		}
+	}
+	return byRound
+}
+
+func singleElim(cfg Config, teams []int64) Plan {
+	b := &builder{}
+	byRound := b.upperBracket(cfg, teams, SectionUpper, singleElimStage, func(round int) int { return round - 1 })
+	rounds := len(byRound) - 1
+	final := byRound[rounds][0]
+	b.nodes[final].format = cfg.FinalFormat
+	if cfg.ThirdPlaceMatch && rounds >= 2 {
+		semis := byRound[rounds-1]
+		b.add(&node{
+			section:  SectionThirdPlace,
+			round:    1,
+			position: 1,
+			order:    rounds - 1,
+			stage:    "Third Place Match",
+			format:   cfg.Format,
+			slots:    [2]source{loserOf(semis[0]), loserOf(semis[1])},
+		})
+	}
+	return b.plan()
+}
+
+func singleElimStage(round, rounds int) string {
+	switch rounds - round {
+	case 0:
+		return "Final"
+	case 1:
+		return "Semifinal"
+	case 2:
+		return "Quarterfinal"
+	default:
+		return fmt.Sprintf("Round %d", round)
+	}
+}
+
+func doubleElim(cfg Config, teams []int64) Plan {
+	b := &builder{}
+	upper := b.upperBracket(cfg, teams, SectionUpper, func(round, rounds int) string {
+		if round == rounds {
+			return "Upper Bracket Final"
+		}
+		return fmt.Sprintf("Upper Bracket Round %d", round)
+	}, func(round int) int { return 2 * (round - 1) })
+	rounds := len(upper) - 1
+	lowerRounds := 2*rounds - 2
+	lowerStage := func(round int) string {
+		if round == lowerRounds {
+			return "Lower Bracket Final"
+		}
+		return fmt.Sprintf("Lower Bracket Round %d", round)
+	}
+	lowerOrder := func(round int) int {
+		if round == 1 {
+			return 1
+		}
+		return round + 1
+	}
+
+	// The lower bracket alternates between rounds where upper-bracket losers
+	// drop in and rounds where the surviving lower-bracket teams play each other.
+	var lowerChampion source
+	if rounds == 1 {
+		lowerChampion = loserOf(upper[1][0])
+	} else {
+		prev := []int{}
+		for i := 0; i < len(upper[1])/2; i++ {
+			prev = append(prev, b.add(&node{
+				section:  SectionLower,
+				round:    1,
+				position: i + 1,
+				order:    lowerOrder(1),
+				stage:    lowerStage(1),
+				format:   cfg.Format,
+				slots:    [2]source{loserOf(upper[1][2*i]), loserOf(upper[1][2*i+1])},
+			}))
+		}
+		lowerRound := 1
+		for r := 2; r <= rounds; r++ {
+			lowerRound++
+			dropped := upper[r]
+			next := []int{}
+			for i := range prev {
+				next = append(next, b.add(&node{
+					section:  SectionLower,
+					round:    lowerRound,
+					position: i + 1,
+					order:    lowerOrder(lowerRound),
+					stage:    lowerStage(lowerRound),
+					format:   cfg.Format,
+					slots:    [2]source{winnerOf(prev[i]), loserOf(dropped[len(dropped)-1-i])},
+				}))
+			}
+			prev = next
+			if r == rounds {
+				break
+			}
+			lowerRound++
+			next = []int{}
+			for i := 0; i < len(prev)/2; i++ {
+				next = append(next, b.add(&node{
+					section:  SectionLower,
+					round:    lowerRound,
+					position: i + 1,
+					order:    lowerOrder(lowerRound),
+					stage:    lowerStage(lowerRound),
+					format:   cfg.Format,
+					slots:    [2]source{winnerOf(prev[2*i]), winnerOf(prev[2*i+1])},
+				}))
+			}
+			prev = next
+		}
+		lowerChampion = winnerOf(prev[0])
+	}
+
+	b.add(&node{
+		section:  SectionGrandFinal,
+		round:    1,
+		position: 1,
+		order:    2 * rounds,
+		stage:    "Grand Final",
+		format:   cfg.FinalFormat,
+		slots:    [2]source{winnerOf(upper[rounds][0]), lowerChampion},
+	})
+	return b.plan()
+}
+
+func roundRobin(cfg Config, teams []int64) Plan {
+	list := append([]int64{}, teams...)
+	if len(list)%2 == 1 {
+		list = append(list, 0)
+	}
+	n := len(list)
+	plan := Plan{}
+	for r := 1; r < n; r++ {
+		position := 0
+		for i := 0; i < n/2; i++ {
+			team1, team2 := list[i], list[n-1-i]
+			if team1 == 0 || team2 == 0 {
+				continue
+			}
+			if i == 0 && r%2 == 0 {
+				team1, team2 = team2, team1
+			}
+			position++
+			plan.Matches = append(plan.Matches, Match{
+				Section:  SectionGroup,
+				Round:    r,
+				Position: position,
+				Order:    r - 1,
+				Stage:    "Group Stage",
+				Format:   cfg.Format,
+				Team1ID:  &team1,
+				Team2ID:  &team2,
+			})
+		}
+		// Circle method: keep the first team fixed and rotate the rest.
+		last := list[n-1]
+		copy(list[2:], list[1:n-1])
+		list[1] = last
+	}
+	return plan
+}
+
+// plan resolves byes and converts the builder graph into a Plan. A match with
+// a bye is dropped: its winner slot is taken by the opponent and its loser
+// slot becomes a bye, which may cascade into the lower bracket.
+func (b *builder) plan() Plan {
+	for {
+		idx := -1
+		for i, n := range b.nodes {
+			if !n.removed && (n.slots[0].kind == srcBye || n.slots[1].kind == srcBye) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			break
+		}
+		n := b.nodes[idx]
+		n.removed = true
+		advance := n.slots[0]
+		if advance.kind == srcBye {
+			advance = n.slots[1]
+		}
+		for _, other := range b.nodes {
+			if other.removed {
+				continue
+			}
+			for s := range other.slots {
+				switch {
+				case other.slots[s].kind == srcWinner && other.slots[s].match == idx:
+					other.slots[s] = advance
+				case other.slots[s].kind == srcLoser && other.slots[s].match == idx:
+					other.slots[s] = source{kind: srcBye}
+				}
+			}
+		}
+	}
+
+	index := make(map[int]int, len(b.nodes))
+	plan := Plan{}
+	for i, n := range b.nodes {
+		if n.removed {
+			continue
+		}
+		index[i] = len(plan.Matches)
+		plan.Matches = append(plan.Matches, Match{
+			Section:  n.section,
+			Round:    n.round,
+			Position: n.position,
+			Order:    n.order,
+			Stage:    n.stage,
+			Format:   n.format,
+		})
+	}
+	for i, n := range b.nodes {
+		if n.removed {
+			continue
+		}
+		m := &plan.Matches[index[i]]
+		for s, src := range n.slots {
+			link := &Link{Match: index[i], Slot: s + 1}
+			switch src.kind {
+			case srcTeam:
+				team := src.team
+				if s == 0 {
+					m.Team1ID = &team
+				} else {
+					m.Team2ID = &team
+				}
+			case srcWinner:
+				plan.Matches[index[src.match]].WinnerTo = link
+			case srcLoser:
+				plan.Matches[index[src.match]].LoserTo = link
+			}
+		}
+	}
+	return plan
+}
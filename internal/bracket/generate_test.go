@@ -0,0 +1,276 @@
+package bracket
+
+import (
+	"fmt"
+	"testing"
+)
+
+func seeds(n int) []int64 {
+	teams := make([]int64, n)
+	for i := range teams {
+		teams[i] = int64(i + 1)
+	}
+	return teams
+}
+
+// inputs counts what fills each slot of each match: a team or a link from
+// another match. Every slot of a valid plan is filled exactly once.
+func inputs(t *testing.T, plan Plan) [][2]int {
+	t.Helper()
+	filled := make([][2]int, len(plan.Matches))
+	for i, m := range plan.Matches {
+		if m.Team1ID != nil {
+			filled[i][0]++
+		}
+		if m.Team2ID != nil {
+			filled[i][1]++
+		}
+	}
+	for i, m := range plan.Matches {
+		for _, link := range []*Link{m.WinnerTo, m.LoserTo} {
+			if link == nil {
+				continue
+			}
+			if link.Match <= i {
+				t.Fatalf("match %d links back to match %d", i, link.Match)
+			}
+			filled[link.Match][link.Slot-1]++
+		}
+	}
+	return filled
+}
+
+func checkSlots(t *testing.T, plan Plan) {
+	t.Helper()
+	for i, f := range inputs(t, plan) {
+		if f != [2]int{1, 1} {
+			t.Errorf("match %d (%s round %d) has slot inputs %v, want [1 1]", i, plan.Matches[i].Section, plan.Matches[i].Round, f)
+		}
+	}
+}
+
+func teamCount(plan Plan) map[int64]int {
+	count := map[int64]int{}
+	for _, m := range plan.Matches {
+		for _, id := range []*int64{m.Team1ID, m.Team2ID} {
+			if id != nil {
+				count[*id]++
+			}
+		}
+	}
+	return count
+}
+
+func TestSeedSlots(t *testing.T) {
+	tests := []struct {
+		teams int
+		size  int
+		want  []int64
+	}{
+		{teams: 2, size: 2, want: []int64{1, 2}},
+		{teams: 4, size: 4, want: []int64{1, 4, 2, 3}},
+		{teams: 8, size: 8, want: []int64{1, 8, 4, 5, 2, 7, 3, 6}},
+		{teams: 5, size: 8, want: []int64{1, 0, 4, 5, 2, 0, 3, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d_of_%d", tt.teams, tt.size), func(t *testing.T) {
+			slots := seedSlots(seeds(tt.teams), tt.size)
+			for i, s := range slots {
+				got := int64(0)
+				if s.kind == srcTeam {
+					got = s.team
+				}
+				if got != tt.want[i] {
+					t.Fatalf("slot %d = %d, want %d", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSingleElim(t *testing.T) {
+	tests := []struct {
+		teams      int
+		thirdPlace bool
+		matches    int
+		byes       []int64
+	}{
+		{teams: 2, matches: 1},
+		{teams: 3, matches: 2, byes: []int64{1}},
+		{teams: 4, matches: 3},
+		{teams: 4, thirdPlace: true, matches: 4},
+		{teams: 6, matches: 5, byes: []int64{1, 2}},
+		{teams: 8, matches: 7},
+		{teams: 13, thirdPlace: true, matches: 13, byes: []int64{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d_teams_third_%v", tt.teams, tt.thirdPlace), func(t *testing.T) {
+			plan, err := Generate(Config{Type: TypeSingleElim, Format: "bo3", FinalFormat: "bo5", ThirdPlaceMatch: tt.thirdPlace}, seeds(tt.teams))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(plan.Matches) != tt.matches {
+				t.Fatalf("got %d matches, want %d", len(plan.Matches), tt.matches)
+			}
+			checkSlots(t, plan)
+			count := teamCount(plan)
+			for id := int64(1); id <= int64(tt.teams); id++ {
+				if count[id] != 1 {
+					t.Errorf("team %d is placed %d times, want once", id, count[id])
+				}
+			}
+			for _, id := range tt.byes {
+				for _, m := range plan.Matches {
+					if m.Round == 1 && m.Section == SectionUpper && (m.Team1ID != nil && *m.Team1ID == id || m.Team2ID != nil && *m.Team2ID == id) {
+						t.Errorf("seed %d should have a bye but plays round 1", id)
+					}
+				}
+			}
+			finals := 0
+			for _, m := range plan.Matches {
+				if m.Section == SectionUpper && m.WinnerTo == nil {
+					finals++
+					if m.Format != "bo5" {
+						t.Errorf("final format = %s, want bo5", m.Format)
+					}
+				}
+			}
+			if finals != 1 {
+				t.Errorf("got %d matches without a next match, want 1 final", finals)
+			}
+		})
+	}
+}
+
+func TestDoubleElim(t *testing.T) {
+	for _, n := range []int{2, 3, 4, 5, 6, 8, 11, 16} {
+		t.Run(fmt.Sprintf("%d_teams", n), func(t *testing.T) {
+			plan, err := Generate(Config{Type: TypeDoubleElim, Format: "bo3", FinalFormat: "bo5"}, seeds(n))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkSlots(t, plan)
+			// Every team but the champion loses twice, except that the
+			// grand final decides with a single loss: 2n-2 matches.
+			if want := 2*n - 2; len(plan.Matches) != want {
+				t.Fatalf("got %d matches, want %d", len(plan.Matches), want)
+			}
+			grandFinals := 0
+			for i, m := range plan.Matches {
+				switch m.Section {
+				case SectionUpper:
+					if m.LoserTo == nil {
+						t.Errorf("upper match %d drops its loser nowhere", i)
+					} else if plan.Matches[m.LoserTo.Match].Section == SectionUpper {
+						t.Errorf("upper match %d drops its loser into the upper bracket", i)
+					}
+					if m.WinnerTo == nil {
+						t.Errorf("upper match %d has no next match", i)
+					}
+				case SectionLower:
+					if m.LoserTo != nil {
+						t.Errorf("lower match %d sends its loser on", i)
+					}
+					if m.WinnerTo == nil {
+						t.Errorf("lower match %d has no next match", i)
+					}
+				case SectionGrandFinal:
+					grandFinals++
+					if m.WinnerTo != nil || m.LoserTo != nil {
+						t.Errorf("grand final links on")
+					}
+					if m.Format != "bo5" {
+						t.Errorf("grand final format = %s, want bo5", m.Format)
+					}
+				default:
+					t.Errorf("unexpected section %q", m.Section)
+				}
+			}
+			if grandFinals != 1 {
+				t.Errorf("got %d grand finals, want 1", grandFinals)
+			}
+		})
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	for _, n := range []int{2, 3, 4, 5, 8} {
+		t.Run(fmt.Sprintf("%d_teams", n), func(t *testing.T) {
+			plan, err := Generate(Config{Type: TypeRoundRobin, Format: "bo1"}, seeds(n))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := n * (n - 1) / 2; len(plan.Matches) != want {
+				t.Fatalf("got %d matches, want %d", len(plan.Matches), want)
+			}
+			pairs := map[[2]int64]bool{}
+			perRound := map[int]map[int64]bool{}
+			for _, m := range plan.Matches {
+				key := PairKey(*m.Team1ID, *m.Team2ID)
+				if pairs[key] {
+					t.Errorf("pair %v plays twice", key)
+				}
+				pairs[key] = true
+				if perRound[m.Round] == nil {
+					perRound[m.Round] = map[int64]bool{}
+				}
+				for _, id := range key {
+					if perRound[m.Round][id] {
+						t.Errorf("team %d plays twice in round %d", id, m.Round)
+					}
+					perRound[m.Round][id] = true
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSwiss(t *testing.T) {
+	tests := []struct {
+		teams int
+		pairs [][2]int64
+		bye   int64
+	}{
+		{teams: 2, pairs: [][2]int64{{1, 2}}},
+		{teams: 4, pairs: [][2]int64{{1, 3}, {2, 4}}},
+		{teams: 5, pairs: [][2]int64{{1, 3}, {2, 4}}, bye: 5},
+		{teams: 7, pairs: [][2]int64{{1, 4}, {2, 5}, {3, 6}}, bye: 7},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d_teams", tt.teams), func(t *testing.T) {
+			plan, err := Generate(Config{Type: TypeSwiss, Format: "bo1"}, seeds(tt.teams))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var bye int64
+			var pairs [][2]int64
+			for _, m := range plan.Matches {
+				if m.Section != SectionSwiss || m.Round != 1 {
+					t.Errorf("match in %s round %d, want swiss round 1", m.Section, m.Round)
+				}
+				if m.Bye {
+					if m.Team2ID != nil {
+						t.Errorf("bye has a second team")
+					}
+					bye = *m.Team1ID
+					continue
+				}
+				pairs = append(pairs, [2]int64{*m.Team1ID, *m.Team2ID})
+			}
+			if bye != tt.bye {
+				t.Errorf("bye = %d, want %d", bye, tt.bye)
+			}
+			if fmt.Sprint(pairs) != fmt.Sprint(tt.pairs) {
+				t.Errorf("pairs = %v, want %v", pairs, tt.pairs)
+			}
+		})
+	}
+}
+
+func TestGenerateNotEnoughTeams(t *testing.T) {
+	for _, typ := range []string{TypeSingleElim, TypeDoubleElim, TypeRoundRobin, TypeSwiss} {
+		if _, err := Generate(Config{Type: typ}, seeds(1)); err != ErrNotEnoughTeams {
+			t.Errorf("%s with one team: err = %v, want ErrNotEnoughTeams", typ, err)
+		}
+	}
+}
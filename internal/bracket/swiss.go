@@ -0,0 +1,105 @@
+package bracket
+
+import "sort"
+
+// SwissStanding is the record of a team before a Swiss round. Byes count as
+// wins but not as played matches.
+type SwissStanding struct {
+	TeamID int64
+	Seed   int
+	Wins   int
+	Played int
+}
+
+func PairKey(a, b int64) [2]int64 {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int64{a, b}
+}
+
+// PairSwiss pairs teams with equal or close records while avoiding rematches
+// where possible. With an odd number of teams the lowest-ranked team that has
+// not sat out yet gets a bye and is returned separately.
+func PairSwiss(standings []SwissStanding, played map[[2]int64]bool) ([][2]int64, *int64) {
+	ranked := append([]SwissStanding{}, standings...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Wins != ranked[j].Wins {
+			return ranked[i].Wins > ranked[j].Wins
+		}
+		return ranked[i].Seed < ranked[j].Seed
+	})
+
+	ranked, bye := takeBye(ranked)
+
+	ids := make([]int64, len(ranked))
+	for i, s := range ranked {
+		ids[i] = s.TeamID
+	}
+	used := make([]bool, len(ids))
+	pairs := make([][2]int64, 0, len(ids)/2)
+	if pairSwiss(ids, used, played, &pairs) {
+		return pairs, bye
+	}
+
+	// Every team has already met every possible opponent: fall back to
+	// pairing neighbours in the ranking.
+	pairs = pairs[:0]
+	for i := 0; i+1 < len(ids); i += 2 {
+		pairs = append(pairs, [2]int64{ids[i], ids[i+1]})
+	}
+	return pairs, bye
+}
+
+// takeBye removes the team that sits out the round when the number of teams
+// is odd: the lowest-ranked one that has not had a bye yet, which is the one
+// with the most played matches. ranked must be in ranking order.
+func takeBye(ranked []SwissStanding) ([]SwissStanding, *int64) {
+	if len(ranked)%2 == 0 {
+		return ranked, nil
+	}
+	maxPlayed := 0
+	for _, s := range ranked {
+		if s.Played > maxPlayed {
+			maxPlayed = s.Played
+		}
+	}
+	pick := len(ranked) - 1
+	for i := len(ranked) - 1; i >= 0; i-- {
+		if ranked[i].Played == maxPlayed {
+			pick = i
+			break
+		}
+	}
+	team := ranked[pick].TeamID
+	rest := append(append([]SwissStanding{}, ranked[:pick]...), ranked[pick+1:]...)
+	return rest, &team
+}
+
+func pairSwiss(ids []int64, used []bool, played map[[2]int64]bool, pairs *[][2]int64) bool {
+	first := -1
+	for i := range ids {
+		if !used[i] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return true
+	}
+	used[first] = true
+	for j := first + 1; j < len(ids); j++ {
+		if used[j] || played[PairKey(ids[first], ids[j])] {
+			continue
+		}
+		used[j] = true
+		*pairs = append(*pairs, [2]int64{ids[first], ids[j]})
+		if pairSwiss(ids, used, played, pairs) {
+			return true
+		}
+		*pairs = (*pairs)[:len(*pairs)-1]
+		used[j] = false
+	}
+	used[first] = false
+	return false
+}
@@ -0,0 +1,129 @@
+package bracket
+
+import "testing"
+
+func TestPairSwiss(t *testing.T) {
+	tests := []struct {
+		name      string
+		standings []SwissStanding
+		played    [][2]int64
+		want      [][2]int64
+		bye       int64
+	}{
+		{
+			name: "pairs by record",
+			standings: []SwissStanding{
+				{TeamID: 1, Seed: 1, Wins: 0, Played: 1},
+				{TeamID: 2, Seed: 2, Wins: 1, Played: 1},
+				{TeamID: 3, Seed: 3, Wins: 0, Played: 1},
+				{TeamID: 4, Seed: 4, Wins: 1, Played: 1},
+			},
+			played: [][2]int64{{1, 3}, {2, 4}},
+			want:   [][2]int64{{2, 1}, {4, 3}},
+		},
+		{
+			name: "avoids rematches",
+			standings: []SwissStanding{
+				{TeamID: 1, Seed: 1, Wins: 1, Played: 1},
+				{TeamID: 2, Seed: 2, Wins: 1, Played: 1},
+				{TeamID: 3, Seed: 3, Wins: 0, Played: 1},
+				{TeamID: 4, Seed: 4, Wins: 0, Played: 1},
+			},
+			played: [][2]int64{{1, 2}, {3, 4}},
+			want:   [][2]int64{{1, 3}, {2, 4}},
+		},
+		{
+			name: "falls back to rematches when every pairing was played",
+			standings: []SwissStanding{
+				{TeamID: 1, Seed: 1, Wins: 2, Played: 3},
+				{TeamID: 2, Seed: 2, Wins: 1, Played: 3},
+			},
+			played: [][2]int64{{1, 2}},
+			want:   [][2]int64{{1, 2}},
+		},
+		{
+			name: "bye goes to the lowest-ranked team",
+			standings: []SwissStanding{
+				{TeamID: 1, Seed: 1},
+				{TeamID: 2, Seed: 2},
+				{TeamID: 3, Seed: 3},
+			},
+			want: [][2]int64{{1, 2}},
+			bye:  3,
+		},
+		{
+			name: "no second bye",
+			standings: []SwissStanding{
+				{TeamID: 1, Seed: 1, Wins: 1, Played: 1},
+				{TeamID: 2, Seed: 2, Wins: 0, Played: 1},
+				{TeamID: 3, Seed: 3, Wins: 1, Played: 0},
+			},
+			played: [][2]int64{{1, 2}},
+			want:   [][2]int64{{1, 3}},
+			bye:    2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			played := map[[2]int64]bool{}
+			for _, p := range tt.played {
+				played[PairKey(p[0], p[1])] = true
+			}
+			pairs, bye := PairSwiss(tt.standings, played)
+			if len(pairs) != len(tt.want) {
+				t.Fatalf("pairs = %v, want %v", pairs, tt.want)
+			}
+			for i := range pairs {
+				if pairs[i] != tt.want[i] {
+					t.Fatalf("pairs = %v, want %v", pairs, tt.want)
+				}
+			}
+			got := int64(0)
+			if bye != nil {
+				got = *bye
+			}
+			if got != tt.bye {
+				t.Errorf("bye = %d, want %d", got, tt.bye)
+			}
+		})
+	}
+}
+
+func TestSwissRoundsWithoutRematches(t *testing.T) {
+	// Team i beats every team with a higher id, so records spread out as in
+	// a real event; no pair may meet twice while another pairing exists.
+	teams := seeds(8)
+	cfg := Config{Type: TypeSwiss, Format: "bo1"}
+	standings := make([]SwissStanding, len(teams))
+	for i, id := range teams {
+		standings[i] = SwissStanding{TeamID: id, Seed: i + 1}
+	}
+	played := map[[2]int64]bool{}
+	plan, err := Generate(cfg, teams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for round := 1; round <= cfg.SwissRounds(len(teams)); round++ {
+		if round > 1 {
+			pairs, bye := PairSwiss(standings, played)
+			plan = SwissRound(cfg, round, pairs, bye)
+		}
+		for _, m := range plan.Matches {
+			a, b := *m.Team1ID, *m.Team2ID
+			key := PairKey(a, b)
+			if played[key] {
+				t.Fatalf("round %d: %d and %d meet again", round, a, b)
+			}
+			played[key] = true
+			winner := min(a, b)
+			for i := range standings {
+				if standings[i].TeamID == a || standings[i].TeamID == b {
+					standings[i].Played++
+					if standings[i].TeamID == winner {
+						standings[i].Wins++
+					}
+				}
+			}
+		}
+	}
+}
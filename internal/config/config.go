@@ -8,8 +8,23 @@ import (
 )
 
 type Config struct {
-	HTTPAddr string
-	DB       DBConfig
+	HTTPAddr             string
+	DB                   DBConfig
+	Auth                 AuthConfig
+	ImportWorkers        int
+	ImportErrorRetention time.Duration
+	AutoMigrate          bool
+}
+
+type AuthConfig struct {
+	// JWTSecret signs bearer tokens. When empty a random secret is used, and
+	// tokens do not survive a restart.
+	JWTSecret string
+	TokenTTL  time.Duration
+	// AdminUsername and AdminPassword create the first admin when the
+	// database has no users.
+	AdminUsername string
+	AdminPassword string
 }
 
 type DBConfig struct {
@@ -38,6 +53,15 @@ func New() Config {
 			MaxIdleConns:    mustInt(getEnv("DB_MAX_IDLE_CONNS", "25"), 25),
 			ConnMaxLifetime: mustDuration(getEnv("DB_CONN_MAX_LIFETIME", "30m"), 30*time.Minute),
 		},
+		Auth: AuthConfig{
+			JWTSecret:     getEnv("JWT_SECRET", ""),
+			TokenTTL:      mustDuration(getEnv("JWT_TTL", "1h"), time.Hour),
+			AdminUsername: getEnv("ADMIN_USERNAME", "admin"),
+			AdminPassword: getEnv("ADMIN_PASSWORD", ""),
+		},
+		ImportWorkers:        mustInt(getEnv("IMPORT_WORKERS", "2"), 2),
+		ImportErrorRetention: mustDuration(getEnv("IMPORT_ERROR_RETENTION", "720h"), 30*24*time.Hour),
+		AutoMigrate:          mustBool(getEnv("AUTO_MIGRATE", "false"), false),
 	}
 }
 
@@ -63,6 +87,14 @@ func mustInt(raw string, fallback int) int {
 	return v
 }
 
+func mustBool(raw string, fallback bool) bool {
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
 func mustDuration(raw string, fallback time.Duration) time.Duration {
 	if d, err := time.ParseDuration(raw); err == nil {
 		return d
@@ -0,0 +1,317 @@
+// Package migrate applies the numbered SQL migrations embedded in the binary
+// and records them in schema_migrations.
+//
+// A migration is a pair of files NNNN_name.up.sql and NNNN_name.down.sql. The
+// checksum of the up file is stored when it is applied, so an applied migration
+// that was edited afterwards is reported instead of being silently skipped.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/*.sql
+var files embed.FS
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration was modified")
+	ErrUnknownMigration = errors.New("applied migration is not known to this binary")
+	ErrNoDownMigration  = errors.New("migration has no down file")
+)
+
+// lockKey serializes migrators of the same database, e.g. several API
+// instances migrating at startup.
+const lockKey = 0x6d696772
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+// baselineTable exists in databases created from the old schema.sql before
+// migrations were tracked.
+const baselineTable = "disciplines"
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Status is a migration with its state in the database.
+type Status struct {
+	Version   int64      `db:"version"`
+	Name      string     `db:"name"`
+	Checksum  string     `db:"checksum"`
+	AppliedAt *time.Time `db:"applied_at"`
+	// Modified is set when the applied checksum differs from the embedded file.
+	Modified bool
+	// Unknown is set for applied versions that have no embedded file.
+	Unknown bool
+}
+
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+	// Log receives progress messages; nil discards them.
+	Log func(format string, args ...any)
+}
+
+func New(db *sqlx.DB) (*Migrator, error) {
+	migrations, err := load(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, name := range names {
+		base := path.Base(name)
+		stem, direction, ok := strings.Cut(strings.TrimSuffix(base, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("migration %s: name must be NNNN_name.up.sql or NNNN_name.down.sql", base)
+		}
+		num, label, _ := strings.Cut(stem, "_")
+		version, err := strconv.ParseInt(num, 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: invalid version", base)
+		}
+		body, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: label}
+			byVersion[version] = m
+		} else if m.Name != label {
+			return nil, fmt.Errorf("migration %d: files named %s and %s", version, m.Name, label)
+		}
+		if direction == "up" {
+			sum := sha256.Sum256(body)
+			m.Up, m.Checksum = string(body), hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(body)
+		}
+	}
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+func (m *Migrator) logf(format string, args ...any) {
+	if m.Log != nil {
+		m.Log(format, args...)
+	}
+}
+
+// locked runs fn on a single connection holding the migration lock, with the
+// migrations table in place.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockKey)
+	if _, err := conn.ExecContext(ctx, createMigrationsTable); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func applied(ctx context.Context, conn *sqlx.Conn) (map[int64]Status, error) {
+	var rows []Status
+	if err := conn.SelectContext(ctx, &rows, `SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version`); err != nil {
+		return nil, err
+	}
+	out := make(map[int64]Status, len(rows))
+	for _, r := range rows {
+		out[r.Version] = r
+	}
+	return out, nil
+}
+
+// Up applies the pending migrations in order, each in its own transaction, and
+// returns the applied ones. A database created from the old schema.sql gets
+// the baseline recorded without running it. The baseline is the schema.sql
+// of before the migrations, and the migrations after it tolerate objects that
+// a later schema.sql already created, so such a database is brought up to
+// date whichever version of schema.sql it was created from.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *sqlx.Conn) error {
+		state, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(state); err != nil {
+			return err
+		}
+		if len(state) == 0 && len(m.migrations) > 0 {
+			var exists bool
+			if err := conn.GetContext(ctx, &exists, `SELECT to_regclass($1) IS NOT NULL`, baselineTable); err != nil {
+				return err
+			}
+			if exists {
+				baseline := m.migrations[0]
+				if err := record(ctx, conn, baseline); err != nil {
+					return err
+				}
+				state[baseline.Version] = Status{Version: baseline.Version}
+				m.logf("migrate: existing schema recorded as %d_%s", baseline.Version, baseline.Name)
+			}
+		}
+		for _, mig := range m.migrations {
+			if _, ok := state[mig.Version]; ok {
+				continue
+			}
+			if err := run(ctx, conn, mig.Up, func(tx *sqlx.Tx) error {
+				return record(ctx, tx, mig)
+			}); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			m.logf("migrate: applied %d_%s", mig.Version, mig.Name)
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the last steps applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *sqlx.Conn) error {
+		state, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(state); err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := state[mig.Version]; !ok {
+				continue
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, ErrNoDownMigration)
+			}
+			if err := run(ctx, conn, mig.Down, func(tx *sqlx.Tx) error {
+				_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+				return err
+			}); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			m.logf("migrate: reverted %d_%s", mig.Version, mig.Name)
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status lists the embedded migrations with their state, together with applied
+// versions this binary does not know, ordered by version.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var out []Status
+	err := m.locked(ctx, func(conn *sqlx.Conn) error {
+		state, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		known := map[int64]bool{}
+		for _, mig := range m.migrations {
+			known[mig.Version] = true
+			st := Status{Version: mig.Version, Name: mig.Name, Checksum: mig.Checksum}
+			if a, ok := state[mig.Version]; ok {
+				st.AppliedAt = a.AppliedAt
+				st.Modified = a.Checksum != mig.Checksum
+			}
+			out = append(out, st)
+		}
+		for _, a := range state {
+			if !known[a.Version] {
+				a.Unknown = true
+				out = append(out, a)
+			}
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+		return nil
+	})
+	return out, err
+}
+
+// verify refuses to migrate a database whose applied migrations differ from
+// the embedded ones.
+func (m *Migrator) verify(state map[int64]Status) error {
+	known := map[int64]Migration{}
+	for _, mig := range m.migrations {
+		known[mig.Version] = mig
+	}
+	for version, a := range state {
+		mig, ok := known[version]
+		if !ok {
+			return fmt.Errorf("migration %d_%s: %w", version, a.Name, ErrUnknownMigration)
+		}
+		if a.Checksum != mig.Checksum {
+			return fmt.Errorf("migration %d_%s: %w", version, mig.Name, ErrChecksumMismatch)
+		}
+	}
+	return nil
+}
+
+func record(ctx context.Context, ex sqlx.ExecerContext, mig Migration) error {
+	_, err := ex.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+		mig.Version, mig.Name, mig.Checksum)
+	return err
+}
+
+// run executes a migration script and its bookkeeping in one transaction.
+func run(ctx context.Context, conn *sqlx.Conn, script string, bookkeeping func(tx *sqlx.Tx) error) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if err := bookkeeping(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
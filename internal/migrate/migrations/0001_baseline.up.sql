@@ -1,25 +1,3 @@
-DROP VIEW IF EXISTS v_player_career_stats CASCADE;
-DROP VIEW IF EXISTS v_match_results CASCADE;
-DROP VIEW IF EXISTS v_active_rosters CASCADE;
-
-DROP FUNCTION IF EXISTS fn_tournament_standings(INT) CASCADE;
-DROP FUNCTION IF EXISTS fn_player_kda(INT) CASCADE;
-DROP FUNCTION IF EXISTS refresh_team_rating(INT) CASCADE;
-DROP FUNCTION IF EXISTS audit_log_changes() CASCADE;
-
-DROP TABLE IF EXISTS batch_import_errors CASCADE;
-DROP TABLE IF EXISTS audit_logs CASCADE;
-DROP TABLE IF EXISTS game_player_stats CASCADE;
-DROP TABLE IF EXISTS match_games CASCADE;
-DROP TABLE IF EXISTS matches CASCADE;
-DROP TABLE IF EXISTS tournament_registrations CASCADE;
-DROP TABLE IF EXISTS tournaments CASCADE;
-DROP TABLE IF EXISTS squad_members CASCADE;
-DROP TABLE IF EXISTS team_profiles CASCADE;
-DROP TABLE IF EXISTS players CASCADE;
-DROP TABLE IF EXISTS teams CASCADE;
-DROP TABLE IF EXISTS disciplines CASCADE;
-
 -- ==========================================
 -- 1. disciplines
 -- ==========================================
@@ -0,0 +1,146 @@
+DROP VIEW IF EXISTS v_match_results;
+CREATE OR REPLACE VIEW v_match_results AS
+SELECT m.id AS match_id,
+       m.tournament_id,
+       m.start_time,
+       m.stage,
+       m.format,
+       m.winner_team_id,
+       COUNT(g.id) AS games_played,
+       SUM(g.score_team1) AS total_score_team1,
+       SUM(g.score_team2) AS total_score_team2
+FROM matches m
+LEFT JOIN match_games g ON g.match_id = m.id
+GROUP BY m.id, m.tournament_id, m.start_time, m.stage, m.format, m.winner_team_id;
+
+DROP VIEW IF EXISTS v_roster_health;
+
+DROP FUNCTION IF EXISTS fn_tournament_standings(INT, VARCHAR, INT, INT, INT);
+CREATE OR REPLACE FUNCTION fn_tournament_standings(p_tournament_id INT)
+RETURNS TABLE (
+    team_id INT,
+    matches_played BIGINT,
+    wins BIGINT,
+    losses BIGINT,
+    forfeits BIGINT
+) AS $$
+BEGIN
+    RETURN QUERY
+    WITH teams_in_matches AS (
+        SELECT m.id AS match_id, m.tournament_id, m.team1_id AS team_id, m.winner_team_id, m.is_forfeit
+        FROM matches m
+        WHERE m.tournament_id = p_tournament_id
+        UNION ALL
+        SELECT m.id, m.tournament_id, m.team2_id AS team_id, m.winner_team_id, m.is_forfeit
+        FROM matches m
+        WHERE m.tournament_id = p_tournament_id
+    )
+        SELECT tim.team_id,
+               COUNT(*) AS matches_played,
+               COUNT(*) FILTER (WHERE tim.winner_team_id = tim.team_id) AS wins,
+               COUNT(*) FILTER (WHERE tim.winner_team_id IS NOT NULL AND tim.winner_team_id <> tim.team_id) AS losses,
+               COUNT(*) FILTER (WHERE tim.is_forfeit) AS forfeits
+        FROM teams_in_matches tim
+        WHERE tim.team_id IS NOT NULL
+        GROUP BY tim.team_id
+        ORDER BY wins DESC, losses ASC;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+DROP TRIGGER IF EXISTS trg_tournament_status_log ON tournaments;
+DROP FUNCTION IF EXISTS trg_tournament_status_history();
+
+CREATE OR REPLACE FUNCTION refresh_team_rating(p_team_id INT) RETURNS VOID AS $$
+DECLARE
+    v_avg_rating DECIMAL(7,2);
+    v_scaled DECIMAL(7,2);
+BEGIN
+    SELECT AVG(p.mmr_rating) INTO v_avg_rating
+    FROM squad_members sm
+    JOIN players p ON p.id = sm.player_id
+    WHERE sm.team_id = p_team_id
+      AND sm.leave_date IS NULL;
+
+    v_scaled := CASE
+        WHEN v_avg_rating IS NULL THEN 0
+        ELSE LEAST(ROUND(v_avg_rating / 100, 2), 1000)
+    END;
+
+    UPDATE teams
+    SET world_ranking = v_scaled
+    WHERE id = p_team_id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION trg_refresh_team_rating() RETURNS trigger AS $$
+BEGIN
+    PERFORM refresh_team_rating(COALESCE(NEW.team_id, OLD.team_id));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION trg_refresh_team_rating_on_player() RETURNS trigger AS $$
+DECLARE
+    v_team_id INT;
+BEGIN
+    FOR v_team_id IN
+        SELECT sm.team_id
+        FROM squad_members sm
+        WHERE sm.player_id = NEW.id
+          AND sm.leave_date IS NULL
+    LOOP
+        PERFORM refresh_team_rating(v_team_id);
+    END LOOP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_squad_rating_refresh
+AFTER INSERT OR UPDATE OR DELETE ON squad_members
+FOR EACH ROW EXECUTE FUNCTION trg_refresh_team_rating();
+
+CREATE TRIGGER trg_player_rating_refresh
+AFTER UPDATE OF mmr_rating ON players
+FOR EACH ROW EXECUTE FUNCTION trg_refresh_team_rating_on_player();
+
+DROP TABLE IF EXISTS import_jobs;
+DROP INDEX IF EXISTS idx_import_errors_occurred;
+ALTER TABLE batch_import_errors
+    DROP COLUMN IF EXISTS retried_at,
+    DROP COLUMN IF EXISTS retry_count,
+    DROP COLUMN IF EXISTS line_number,
+    DROP COLUMN IF EXISTS row_index,
+    DROP COLUMN IF EXISTS field,
+    DROP COLUMN IF EXISTS error_code;
+
+DROP TABLE IF EXISTS rating_history;
+DROP TABLE IF EXISTS player_ratings;
+DROP TABLE IF EXISTS team_ratings;
+
+ALTER TABLE game_player_stats DROP COLUMN IF EXISTS is_unregistered_sub;
+
+ALTER TABLE matches
+    DROP CONSTRAINT IF EXISTS chk_loser_next_match_slot,
+    DROP CONSTRAINT IF EXISTS chk_next_match_slot,
+    DROP COLUMN IF EXISTS loser_next_match_slot,
+    DROP COLUMN IF EXISTS loser_next_match_id,
+    DROP COLUMN IF EXISTS next_match_slot,
+    DROP COLUMN IF EXISTS next_match_id,
+    DROP COLUMN IF EXISTS bracket_position,
+    DROP COLUMN IF EXISTS bracket_round,
+    DROP COLUMN IF EXISTS bracket_section;
+
+ALTER TABLE tournament_registrations
+    DROP CONSTRAINT IF EXISTS chk_registration_status,
+    DROP COLUMN IF EXISTS roster_locked_at;
+
+DROP TABLE IF EXISTS tournament_status_history;
+
+ALTER TABLE tournaments
+    DROP CONSTRAINT IF EXISTS chk_tournament_status,
+    DROP CONSTRAINT IF EXISTS chk_tournament_max_teams,
+    DROP COLUMN IF EXISTS max_teams;
+
+-- Elo ratings do not fit the old 0-100 scale.
+UPDATE teams SET world_ranking = LEAST(world_ranking, 999.99);
+ALTER TABLE teams ALTER COLUMN world_ranking TYPE DECIMAL(5,2);
@@ -0,0 +1,450 @@
+-- ==========================================
+-- Сетки, жизненный цикл, рейтинги и загрузки
+-- ==========================================
+-- Brings a schema created from the original schema.sql, or from any later
+-- version of it, up to date. Every step may find its object already in place.
+
+-- ==========================================
+-- 2. teams
+-- ==========================================
+ALTER TABLE teams ALTER COLUMN world_ranking TYPE DECIMAL(7,2);            -- [DECIMAL] (Elo рейтинг команды)
+
+-- ==========================================
+-- 5. tournaments
+-- ==========================================
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS max_teams INT;           -- [INT] (лимит участников, NULL = без лимита)
+
+-- Старые статусы были свободным текстом: известные написания приводятся к
+-- новым значениям, остальные выводятся из дат турнира.
+UPDATE tournaments t SET status = s.status
+FROM (VALUES
+    ('announced', 'Announced'), ('upcoming', 'Announced'), ('planned', 'Announced'), ('scheduled', 'Announced'),
+    ('registrationopen', 'RegistrationOpen'), ('registration', 'RegistrationOpen'), ('open', 'RegistrationOpen'), ('registering', 'RegistrationOpen'),
+    ('registrationclosed', 'RegistrationClosed'), ('closed', 'RegistrationClosed'),
+    ('ongoing', 'Ongoing'), ('inprogress', 'Ongoing'), ('live', 'Ongoing'), ('running', 'Ongoing'), ('active', 'Ongoing'), ('started', 'Ongoing'),
+    ('completed', 'Completed'), ('complete', 'Completed'), ('finished', 'Completed'), ('done', 'Completed'), ('ended', 'Completed'),
+    ('cancelled', 'Cancelled'), ('canceled', 'Cancelled'), ('aborted', 'Cancelled')
+) AS s(legacy, status)
+WHERE LOWER(REGEXP_REPLACE(t.status, '[\s_-]', '', 'g')) = s.legacy AND t.status <> s.status;
+
+DO $$
+DECLARE
+    unknown TEXT;
+BEGIN
+    SELECT STRING_AGG(FORMAT('%s (%L)', id, status), ', ' ORDER BY id) INTO unknown
+    FROM tournaments
+    WHERE status NOT IN ('Announced', 'RegistrationOpen', 'RegistrationClosed', 'Ongoing', 'Completed', 'Cancelled');
+    IF unknown IS NOT NULL THEN
+        RAISE NOTICE 'tournaments with unknown status, derived from dates: %', unknown;
+    END IF;
+END $$;
+
+UPDATE tournaments SET status = CASE
+        WHEN end_date < CURRENT_DATE THEN 'Completed'
+        WHEN start_date <= CURRENT_DATE THEN 'Ongoing'
+        ELSE 'Announced'
+    END
+WHERE status NOT IN ('Announced', 'RegistrationOpen', 'RegistrationClosed', 'Ongoing', 'Completed', 'Cancelled');
+
+ALTER TABLE tournaments DROP CONSTRAINT IF EXISTS chk_tournament_max_teams;
+ALTER TABLE tournaments ADD CONSTRAINT chk_tournament_max_teams CHECK (max_teams IS NULL OR max_teams > 0);
+ALTER TABLE tournaments DROP CONSTRAINT IF EXISTS chk_tournament_status;
+ALTER TABLE tournaments ADD CONSTRAINT chk_tournament_status CHECK (status IN ('Announced', 'RegistrationOpen', 'RegistrationClosed', 'Ongoing', 'Completed', 'Cancelled'));
+
+-- ==========================================
+-- 5a. tournament_status_history (переходы статусов)
+-- ==========================================
+CREATE TABLE IF NOT EXISTS tournament_status_history (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,                 -- [BIGINT/INT]
+    tournament_id INT NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE, -- [INT]
+    from_status VARCHAR(20),                                             -- [VARCHAR] (NULL при создании турнира)
+    to_status VARCHAR(20) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP        -- [TIMESTAMP]
+);
+CREATE INDEX IF NOT EXISTS idx_status_history_tournament ON tournament_status_history(tournament_id, changed_at);
+
+-- ==========================================
+-- 6. tournament_registrations
+-- ==========================================
+ALTER TABLE tournament_registrations ADD COLUMN IF NOT EXISTS roster_locked_at TIMESTAMP WITH TIME ZONE; -- [TIMESTAMP] (фиксация состава на старте турнира)
+
+-- Известные старые написания приводятся к новым статусам, остальные заявки
+-- возвращаются на рассмотрение (Pending).
+UPDATE tournament_registrations r SET status = s.status
+FROM (VALUES
+    ('pending', 'Pending'), ('new', 'Pending'), ('applied', 'Pending'), ('submitted', 'Pending'),
+    ('confirmed', 'Confirmed'), ('approved', 'Confirmed'), ('accepted', 'Confirmed'), ('registered', 'Confirmed'),
+    ('waitlisted', 'Waitlisted'), ('waitlist', 'Waitlisted'), ('waiting', 'Waitlisted'), ('reserve', 'Waitlisted'),
+    ('rejected', 'Rejected'), ('declined', 'Rejected'), ('denied', 'Rejected'),
+    ('withdrawn', 'Withdrawn'), ('withdrew', 'Withdrawn'), ('cancelled', 'Withdrawn'), ('canceled', 'Withdrawn')
+) AS s(legacy, status)
+WHERE LOWER(REGEXP_REPLACE(r.status, '[\s_-]', '', 'g')) = s.legacy AND r.status <> s.status;
+
+DO $$
+DECLARE
+    unknown TEXT;
+BEGIN
+    SELECT STRING_AGG(FORMAT('%s (%L)', id, status), ', ' ORDER BY id) INTO unknown
+    FROM tournament_registrations
+    WHERE status IS NULL OR status NOT IN ('Pending', 'Confirmed', 'Waitlisted', 'Rejected', 'Withdrawn');
+    IF unknown IS NOT NULL THEN
+        RAISE NOTICE 'registrations with unknown status, reset to Pending: %', unknown;
+    END IF;
+END $$;
+
+UPDATE tournament_registrations SET status = 'Pending'
+WHERE status IS NULL OR status NOT IN ('Pending', 'Confirmed', 'Waitlisted', 'Rejected', 'Withdrawn');
+
+ALTER TABLE tournament_registrations DROP CONSTRAINT IF EXISTS chk_registration_status;
+ALTER TABLE tournament_registrations ADD CONSTRAINT chk_registration_status CHECK (status IN ('Pending', 'Confirmed', 'Waitlisted', 'Rejected', 'Withdrawn'));
+
+-- ==========================================
+-- 7. matches
+-- ==========================================
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS bracket_section VARCHAR(20); -- [VARCHAR] (часть сетки: upper/lower/grand_final/...)
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS bracket_round INT;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS bracket_position INT;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS next_match_id BIGINT REFERENCES matches(id) ON DELETE SET NULL; -- [BIGINT] (куда проходит победитель)
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS next_match_slot SMALLINT;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS loser_next_match_id BIGINT REFERENCES matches(id) ON DELETE SET NULL; -- [BIGINT] (куда уходит проигравший в double elim)
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS loser_next_match_slot SMALLINT;
+
+ALTER TABLE matches DROP CONSTRAINT IF EXISTS chk_next_match_slot;
+ALTER TABLE matches ADD CONSTRAINT chk_next_match_slot CHECK (next_match_slot IS NULL OR next_match_slot IN (1, 2));
+ALTER TABLE matches DROP CONSTRAINT IF EXISTS chk_loser_next_match_slot;
+ALTER TABLE matches ADD CONSTRAINT chk_loser_next_match_slot CHECK (loser_next_match_slot IS NULL OR loser_next_match_slot IN (1, 2));
+
+CREATE INDEX IF NOT EXISTS idx_matches_next ON matches(next_match_id);
+CREATE INDEX IF NOT EXISTS idx_matches_loser_next ON matches(loser_next_match_id);
+
+-- ==========================================
+-- 9. game_player_stats
+-- ==========================================
+ALTER TABLE game_player_stats ADD COLUMN IF NOT EXISTS is_unregistered_sub BOOLEAN DEFAULT FALSE; -- [BOOLEAN] (игрок вне зафиксированного состава)
+
+-- ==========================================
+-- 9a. team_ratings / player_ratings (рейтинговые пулы по дисциплинам)
+-- ==========================================
+CREATE TABLE IF NOT EXISTS team_ratings (
+    team_id INT PRIMARY KEY REFERENCES teams(id) ON DELETE CASCADE,     -- [INT]
+    discipline_id INT NOT NULL REFERENCES disciplines(id) ON DELETE CASCADE,
+    rating DECIMAL(7,2) NOT NULL,                                        -- [DECIMAL] (Elo)
+    matches_played INT NOT NULL DEFAULT 0,
+    last_match_id INT REFERENCES matches(id) ON DELETE SET NULL,
+    last_played_at TIMESTAMP WITH TIME ZONE,                             -- [TIMESTAMP]
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_team_ratings_pool ON team_ratings(discipline_id, rating DESC);
+
+CREATE TABLE IF NOT EXISTS player_ratings (
+    player_id INT NOT NULL REFERENCES players(id) ON DELETE CASCADE,    -- [INT]
+    discipline_id INT NOT NULL REFERENCES disciplines(id) ON DELETE CASCADE,
+    rating DECIMAL(7,2) NOT NULL,                                        -- [DECIMAL] (Elo)
+    matches_played INT NOT NULL DEFAULT 0,
+    last_match_id INT REFERENCES matches(id) ON DELETE SET NULL,
+    last_played_at TIMESTAMP WITH TIME ZONE,                             -- [TIMESTAMP]
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (player_id, discipline_id)
+);
+CREATE INDEX IF NOT EXISTS idx_player_ratings_pool ON player_ratings(discipline_id, rating DESC);
+
+-- ==========================================
+-- 9b. rating_history (журнал изменений рейтинга)
+-- ==========================================
+CREATE TABLE IF NOT EXISTS rating_history (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,                 -- [BIGINT]
+    team_id INT REFERENCES teams(id) ON DELETE CASCADE,
+    player_id INT REFERENCES players(id) ON DELETE CASCADE,
+    discipline_id INT REFERENCES disciplines(id) ON DELETE CASCADE,
+    rating DECIMAL(7,2) NOT NULL,                                        -- [DECIMAL]
+    previous_rating DECIMAL(7,2),
+    cause VARCHAR(20) NOT NULL,                                          -- [VARCHAR] (match / manual / recompute)
+    match_id INT REFERENCES matches(id) ON DELETE CASCADE,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP, -- [TIMESTAMP]
+
+    CONSTRAINT chk_rating_history_entity CHECK ((team_id IS NULL) <> (player_id IS NULL)),
+    CONSTRAINT chk_rating_history_cause CHECK (cause IN ('match', 'manual', 'recompute')),
+    CONSTRAINT chk_rating_history_match CHECK ((cause = 'match') = (match_id IS NOT NULL))
+);
+CREATE INDEX IF NOT EXISTS idx_rating_history_team ON rating_history(team_id, recorded_at) WHERE team_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_rating_history_player ON rating_history(player_id, recorded_at) WHERE player_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_rating_history_pool ON rating_history(discipline_id, cause);
+CREATE INDEX IF NOT EXISTS idx_rating_history_match ON rating_history(match_id) WHERE match_id IS NOT NULL;
+
+-- ==========================================
+-- 10b. batch_import_errors
+-- ==========================================
+ALTER TABLE batch_import_errors ADD COLUMN IF NOT EXISTS error_code VARCHAR(30) NOT NULL DEFAULT 'invalid'; -- [VARCHAR] (parse_error/required/unique_violation/fk_violation/check_violation/invalid)
+ALTER TABLE batch_import_errors ADD COLUMN IF NOT EXISTS field VARCHAR(100);            -- [VARCHAR] (поле, вызвавшее ошибку)
+ALTER TABLE batch_import_errors ADD COLUMN IF NOT EXISTS row_index INT;                 -- [INT] (номер строки в загрузке)
+ALTER TABLE batch_import_errors ADD COLUMN IF NOT EXISTS line_number INT;               -- [INT] (номер строки файла)
+ALTER TABLE batch_import_errors ADD COLUMN IF NOT EXISTS retry_count INT NOT NULL DEFAULT 0; -- [INT] (число повторных попыток)
+ALTER TABLE batch_import_errors ADD COLUMN IF NOT EXISTS retried_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_import_errors_occurred ON batch_import_errors(occurred_at);
+
+-- ==========================================
+-- 10c. import_jobs (фоновые загрузки)
+-- ==========================================
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    entity VARCHAR(50) NOT NULL,                                         -- [VARCHAR] (тип загружаемых строк)
+    source VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',                        -- [VARCHAR] (queued/running/completed/failed/cancelled)
+    is_atomic BOOLEAN NOT NULL DEFAULT FALSE,                            -- [BOOLEAN] (все строки в одной транзакции)
+    is_dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    on_conflict VARCHAR(10) NOT NULL DEFAULT 'error',
+    payload JSONB NOT NULL,                                              -- [JSONB] (строки загрузки)
+    total INT NOT NULL DEFAULT 0,                                        -- [INT] (прогресс обработки)
+    processed INT NOT NULL DEFAULT 0,
+    inserted INT NOT NULL DEFAULT 0,
+    updated INT NOT NULL DEFAULT 0,
+    skipped INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    errors JSONB NOT NULL DEFAULT '[]',                                  -- [JSONB] (ошибки строк, не больше 1000)
+    last_error TEXT,                                                     -- [TEXT] (причина падения задачи)
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,       -- [TIMESTAMP]
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT chk_import_job_status CHECK (status IN ('queued', 'running', 'completed', 'failed', 'cancelled')),
+    CONSTRAINT chk_import_job_progress CHECK (processed >= 0 AND processed <= total)
+);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_queue ON import_jobs(status, id);
+
+-- ==========================================
+-- 12a. Рейтинг команды ведется пулами Elo вместо среднего MMR
+-- ==========================================
+DROP TRIGGER IF EXISTS trg_squad_rating_refresh ON squad_members;
+DROP TRIGGER IF EXISTS trg_player_rating_refresh ON players;
+DROP FUNCTION IF EXISTS trg_refresh_team_rating();
+DROP FUNCTION IF EXISTS trg_refresh_team_rating_on_player();
+DROP FUNCTION IF EXISTS refresh_team_rating(INT);
+
+-- ==========================================
+-- 12. Журнал смены статусов турнира
+-- ==========================================
+CREATE OR REPLACE FUNCTION trg_tournament_status_history() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO tournament_status_history(tournament_id, from_status, to_status)
+        VALUES (NEW.id, NULL, NEW.status);
+    ELSIF NEW.status IS DISTINCT FROM OLD.status THEN
+        INSERT INTO tournament_status_history(tournament_id, from_status, to_status)
+        VALUES (NEW.id, OLD.status, NEW.status);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_tournament_status_log ON tournaments;
+CREATE TRIGGER trg_tournament_status_log
+AFTER INSERT OR UPDATE OF status ON tournaments
+FOR EACH ROW EXECUTE FUNCTION trg_tournament_status_history();
+
+-- ==========================================
+-- 13. Функции и представления для отчетов
+-- ==========================================
+-- fn_tournament_standings changed its arguments and result, so whatever
+-- version exists is dropped first.
+DO $$
+DECLARE
+    v_fn REGPROCEDURE;
+BEGIN
+    FOR v_fn IN SELECT oid::REGPROCEDURE FROM pg_proc WHERE proname = 'fn_tournament_standings' LOOP
+        EXECUTE 'DROP FUNCTION ' || v_fn;
+    END LOOP;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION fn_tournament_standings(
+    p_tournament_id INT,
+    p_stage VARCHAR DEFAULT NULL,
+    p_points_win INT DEFAULT 3,
+    p_points_draw INT DEFAULT 1,
+    p_points_loss INT DEFAULT 0
+)
+RETURNS TABLE (
+    rank BIGINT,
+    team_id INT,
+    team_name VARCHAR,
+    matches_played BIGINT,
+    wins BIGINT,
+    draws BIGINT,
+    losses BIGINT,
+    forfeits BIGINT,
+    points BIGINT,
+    head_to_head BIGINT,
+    buchholz BIGINT,
+    maps_won BIGINT,
+    maps_lost BIGINT,
+    map_diff BIGINT,
+    rounds_won BIGINT,
+    rounds_lost BIGINT,
+    round_diff BIGINT
+) AS $$
+#variable_conflict use_column
+DECLARE
+    v_swiss BOOLEAN;
+BEGIN
+    SELECT COALESCE(t.bracket_config ->> 'type', '') = 'swiss'
+    INTO v_swiss
+    FROM tournaments t
+    WHERE t.id = p_tournament_id;
+
+    RETURN QUERY
+    WITH stage_matches AS (
+        SELECT m.id, m.team1_id, m.team2_id, m.winner_team_id, m.is_forfeit,
+               CASE WHEN m.format ~ '^bo[0-9]+$' THEN substring(m.format FROM 3)::INT END AS series_length
+        FROM matches m
+        WHERE m.tournament_id = p_tournament_id
+          AND (p_stage IS NULL OR LOWER(m.stage) = LOWER(p_stage))
+          AND m.team1_id IS NOT NULL
+          AND m.team2_id IS NOT NULL
+    ),
+    game_totals AS (
+        SELECT sm.id AS match_id,
+               COUNT(g.id) FILTER (WHERE g.winner_team_id = sm.team1_id) AS maps1,
+               COUNT(g.id) FILTER (WHERE g.winner_team_id = sm.team2_id) AS maps2,
+               COALESCE(SUM(g.score_team1), 0) AS rounds1,
+               COALESCE(SUM(g.score_team2), 0) AS rounds2
+        FROM stage_matches sm
+        LEFT JOIN match_games g ON g.match_id = sm.id
+        GROUP BY sm.id
+    ),
+    sides AS (
+        SELECT sm.id AS match_id, sm.team1_id AS team_id, sm.team2_id AS opponent_id,
+               sm.winner_team_id, sm.is_forfeit, sm.series_length,
+               gt.maps1 AS maps_won, gt.maps2 AS maps_lost, gt.rounds1 AS rounds_won, gt.rounds2 AS rounds_lost
+        FROM stage_matches sm
+        JOIN game_totals gt ON gt.match_id = sm.id
+        UNION ALL
+        SELECT sm.id, sm.team2_id, sm.team1_id,
+               sm.winner_team_id, sm.is_forfeit, sm.series_length,
+               gt.maps2, gt.maps1, gt.rounds2, gt.rounds1
+        FROM stage_matches sm
+        JOIN game_totals gt ON gt.match_id = sm.id
+    ),
+    results AS (
+        -- ничья: серия сыграна полностью (bo2 1-1), победитель не определен
+        SELECT s.*,
+               (s.winner_team_id IS NULL
+                AND s.series_length IS NOT NULL
+                AND s.maps_won = s.maps_lost
+                AND s.maps_won + s.maps_lost >= s.series_length) AS is_draw
+        FROM sides s
+    ),
+    decided AS (
+        SELECT r.*,
+               CASE
+                   WHEN r.winner_team_id = r.team_id THEN p_points_win
+                   WHEN r.is_draw THEN p_points_draw
+                   ELSE p_points_loss
+               END AS match_points
+        FROM results r
+        WHERE r.winner_team_id IS NOT NULL OR r.is_draw
+    ),
+    totals AS (
+        SELECT r.team_id,
+               COUNT(d.match_id) AS matches_played,
+               COUNT(d.match_id) FILTER (WHERE d.winner_team_id = d.team_id) AS wins,
+               COUNT(d.match_id) FILTER (WHERE d.is_draw) AS draws,
+               COUNT(d.match_id) FILTER (WHERE d.winner_team_id IS NOT NULL AND d.winner_team_id <> d.team_id) AS losses,
+               COUNT(d.match_id) FILTER (WHERE d.is_forfeit) AS forfeits,
+               COALESCE(SUM(d.match_points), 0)::BIGINT AS points,
+               COALESCE(SUM(d.maps_won), 0)::BIGINT AS maps_won,
+               COALESCE(SUM(d.maps_lost), 0)::BIGINT AS maps_lost,
+               COALESCE(SUM(d.rounds_won), 0)::BIGINT AS rounds_won,
+               COALESCE(SUM(d.rounds_lost), 0)::BIGINT AS rounds_lost
+        FROM (SELECT DISTINCT res.team_id FROM results res) r
+        LEFT JOIN decided d ON d.team_id = r.team_id
+        GROUP BY r.team_id
+    ),
+    head_to_head AS (
+        -- очки, набранные только в матчах против команд с тем же количеством очков
+        SELECT d.team_id, SUM(d.match_points)::BIGINT AS h2h_points
+        FROM decided d
+        JOIN totals a ON a.team_id = d.team_id
+        JOIN totals b ON b.team_id = d.opponent_id
+        WHERE a.points = b.points
+        GROUP BY d.team_id
+    ),
+    opponents AS (
+        SELECT d.team_id, SUM(o.points)::BIGINT AS opp_points
+        FROM decided d
+        JOIN totals o ON o.team_id = d.opponent_id
+        GROUP BY d.team_id
+    )
+    SELECT RANK() OVER (
+               ORDER BY t.points DESC,
+                        CASE WHEN v_swiss THEN COALESCE(o.opp_points, 0) END DESC NULLS LAST,
+                        COALESCE(h.h2h_points, 0) DESC,
+                        t.maps_won - t.maps_lost DESC,
+                        t.rounds_won - t.rounds_lost DESC
+           ) AS rank,
+           t.team_id::INT,
+           tm.name::VARCHAR,
+           t.matches_played,
+           t.wins,
+           t.draws,
+           t.losses,
+           t.forfeits,
+           t.points,
+           COALESCE(h.h2h_points, 0)::BIGINT,
+           COALESCE(o.opp_points, 0)::BIGINT,
+           t.maps_won,
+           t.maps_lost,
+           t.maps_won - t.maps_lost,
+           t.rounds_won,
+           t.rounds_lost,
+           t.rounds_won - t.rounds_lost
+    FROM totals t
+    JOIN teams tm ON tm.id = t.team_id
+    LEFT JOIN head_to_head h ON h.team_id = t.team_id
+    LEFT JOIN opponents o ON o.team_id = t.team_id
+    ORDER BY 1, tm.name;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+-- short_handed: активных игроков (вместе с замами) меньше team_size
+-- over_staffed: основных игроков больше team_size
+CREATE OR REPLACE VIEW v_roster_health AS
+SELECT t.id AS team_id,
+       t.name AS team_name,
+       t.tag,
+       t.discipline_id,
+       d.team_size,
+       COUNT(sm.id) FILTER (WHERE NOT sm.is_standin) AS starters,
+       COUNT(sm.id) FILTER (WHERE sm.is_standin) AS standins,
+       COUNT(sm.id) AS active_players,
+       CASE
+           WHEN d.team_size IS NULL THEN 'ok'
+           WHEN COUNT(sm.id) < d.team_size THEN 'short_handed'
+           WHEN COUNT(sm.id) FILTER (WHERE NOT sm.is_standin) > d.team_size THEN 'over_staffed'
+           ELSE 'ok'
+       END AS status
+FROM teams t
+JOIN disciplines d ON d.id = t.discipline_id
+LEFT JOIN squad_members sm ON sm.team_id = t.id AND sm.leave_date IS NULL
+GROUP BY t.id, t.name, t.tag, t.discipline_id, d.team_size;
+
+DROP VIEW IF EXISTS v_match_results;
+CREATE OR REPLACE VIEW v_match_results AS
+SELECT m.id AS match_id,
+       m.tournament_id,
+       m.start_time,
+       m.stage,
+       m.format,
+       m.team1_id,
+       m.team2_id,
+       m.winner_team_id,
+       COUNT(g.id) AS games_played,
+       COUNT(g.id) FILTER (WHERE g.winner_team_id = m.team1_id) AS series_score_team1,
+       COUNT(g.id) FILTER (WHERE g.winner_team_id = m.team2_id) AS series_score_team2,
+       (COUNT(g.id) FILTER (WHERE g.winner_team_id = m.team1_id))::TEXT || '-' ||
+       (COUNT(g.id) FILTER (WHERE g.winner_team_id = m.team2_id))::TEXT AS series_score,
+       SUM(g.score_team1) AS total_score_team1,
+       SUM(g.score_team2) AS total_score_team2
+FROM matches m
+LEFT JOIN match_games g ON g.match_id = m.id
+GROUP BY m.id, m.tournament_id, m.start_time, m.stage, m.format, m.team1_id, m.team2_id, m.winner_team_id;
@@ -0,0 +1,31 @@
+-- ==========================================
+-- 14. users (учётные записи API)
+-- ==========================================
+CREATE TABLE users (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,                 -- [BIGINT/INT]
+    username VARCHAR(50) NOT NULL UNIQUE,                                -- [VARCHAR]
+    password_hash VARCHAR(100) NOT NULL,                                 -- [VARCHAR] (bcrypt)
+    role VARCHAR(20) NOT NULL DEFAULT 'viewer',                          -- [VARCHAR] (viewer/team_manager/organizer/admin)
+    team_id INT REFERENCES teams(id) ON DELETE SET NULL,                 -- [INT] (команда менеджера)
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,                             -- [BOOLEAN]
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,       -- [TIMESTAMP]
+
+    CONSTRAINT chk_user_role CHECK (role IN ('viewer', 'team_manager', 'organizer', 'admin')),
+    CONSTRAINT chk_user_team CHECK (team_id IS NULL OR role = 'team_manager')
+);
+
+-- ==========================================
+-- 14a. api_keys (ключи доступа пользователей)
+-- ==========================================
+CREATE TABLE api_keys (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,                                          -- [VARCHAR] (назначение ключа)
+    prefix VARCHAR(12) NOT NULL,                                         -- [VARCHAR] (начало ключа для поиска в списке)
+    key_hash CHAR(64) NOT NULL UNIQUE,                                   -- [CHAR] (sha256 ключа)
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX idx_api_keys_user ON api_keys(user_id);
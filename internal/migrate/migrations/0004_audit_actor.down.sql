@@ -0,0 +1,49 @@
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS request_id;
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS created_by;
+
+DROP INDEX IF EXISTS idx_audit_request;
+
+DROP TRIGGER trg_squad_audit ON squad_members;
+CREATE TRIGGER trg_squad_audit AFTER INSERT OR UPDATE OR DELETE ON squad_members
+FOR EACH ROW EXECUTE FUNCTION audit_log_changes();
+DROP TRIGGER trg_players_audit ON players;
+CREATE TRIGGER trg_players_audit AFTER INSERT OR UPDATE OR DELETE ON players
+FOR EACH ROW EXECUTE FUNCTION audit_log_changes();
+
+CREATE OR REPLACE FUNCTION audit_log_changes() RETURNS trigger AS $$
+DECLARE
+    v_new JSONB;
+    v_old JSONB;
+    v_pk  BIGINT;
+BEGIN
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        v_new := to_jsonb(NEW);
+    END IF;
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        v_old := to_jsonb(OLD);
+    END IF;
+
+    v_pk := COALESCE(
+        (v_new ->> 'id')::BIGINT,
+        (v_old ->> 'id')::BIGINT,
+        (v_new ->> 'team_id')::BIGINT,
+        (v_old ->> 'team_id')::BIGINT
+    );
+
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO audit_logs(table_name, record_id, operation, new_value, changed_by)
+        VALUES (TG_TABLE_NAME, v_pk, TG_OP, v_new, current_user);
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        INSERT INTO audit_logs(table_name, record_id, operation, old_value, new_value, changed_by)
+        VALUES (TG_TABLE_NAME, v_pk, TG_OP, v_old, v_new, current_user);
+        RETURN NEW;
+    ELSE
+        INSERT INTO audit_logs(table_name, record_id, operation, old_value, changed_by)
+        VALUES (TG_TABLE_NAME, v_pk, TG_OP, v_old, current_user);
+        RETURN OLD;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS request_id;
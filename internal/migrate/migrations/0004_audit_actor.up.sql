@@ -0,0 +1,60 @@
+-- ==========================================
+-- 15. Автор изменений в аудите
+-- ==========================================
+-- The API connects as a single role, so the acting user and request are
+-- passed per transaction in app.actor and app.request_id (SET LOCAL).
+-- Triggers created with the 'sensitive' argument mark their rows as
+-- sensitive.
+ALTER TABLE audit_logs ADD COLUMN request_id VARCHAR(64);              -- [VARCHAR] (X-Request-ID запроса)
+
+CREATE OR REPLACE FUNCTION audit_log_changes() RETURNS trigger AS $$
+DECLARE
+    v_new       JSONB;
+    v_old       JSONB;
+    v_pk        BIGINT;
+    v_actor     VARCHAR(100);
+    v_request   VARCHAR(64);
+    v_sensitive BOOLEAN;
+BEGIN
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        v_new := to_jsonb(NEW);
+    END IF;
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        v_old := to_jsonb(OLD);
+    END IF;
+
+    v_pk := COALESCE(
+        (v_new ->> 'id')::BIGINT,
+        (v_old ->> 'id')::BIGINT,
+        (v_new ->> 'team_id')::BIGINT,
+        (v_old ->> 'team_id')::BIGINT
+    );
+    v_actor := left(COALESCE(NULLIF(current_setting('app.actor', true), ''), current_user), 100);
+    v_request := left(NULLIF(current_setting('app.request_id', true), ''), 64);
+    v_sensitive := TG_NARGS > 0 AND TG_ARGV[0] = 'sensitive';
+
+    INSERT INTO audit_logs(table_name, record_id, operation, old_value, new_value, changed_by, request_id, is_sensitive)
+    VALUES (TG_TABLE_NAME, v_pk, TG_OP, v_old, v_new, v_actor, v_request, v_sensitive);
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- squad_members holds salaries, players personal data.
+DROP TRIGGER trg_squad_audit ON squad_members;
+CREATE TRIGGER trg_squad_audit AFTER INSERT OR UPDATE OR DELETE ON squad_members
+FOR EACH ROW EXECUTE FUNCTION audit_log_changes('sensitive');
+DROP TRIGGER trg_players_audit ON players;
+CREATE TRIGGER trg_players_audit AFTER INSERT OR UPDATE OR DELETE ON players
+FOR EACH ROW EXECUTE FUNCTION audit_log_changes('sensitive');
+
+UPDATE audit_logs SET is_sensitive = TRUE WHERE table_name IN ('squad_members', 'players');
+
+CREATE INDEX idx_audit_request ON audit_logs(request_id) WHERE request_id IS NOT NULL;
+
+-- Import jobs run after their request, so they keep who queued them.
+ALTER TABLE import_jobs ADD COLUMN created_by TEXT;                     -- [TEXT] (автор задачи для аудита)
+ALTER TABLE import_jobs ADD COLUMN request_id VARCHAR(64);
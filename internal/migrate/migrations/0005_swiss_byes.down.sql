@@ -0,0 +1,151 @@
+-- ==========================================
+-- 16. Баи швейцарской системы (откат)
+-- ==========================================
+CREATE OR REPLACE FUNCTION fn_tournament_standings(
+    p_tournament_id INT,
+    p_stage VARCHAR DEFAULT NULL,
+    p_points_win INT DEFAULT 3,
+    p_points_draw INT DEFAULT 1,
+    p_points_loss INT DEFAULT 0
+)
+RETURNS TABLE (
+    rank BIGINT,
+    team_id INT,
+    team_name VARCHAR,
+    matches_played BIGINT,
+    wins BIGINT,
+    draws BIGINT,
+    losses BIGINT,
+    forfeits BIGINT,
+    points BIGINT,
+    head_to_head BIGINT,
+    buchholz BIGINT,
+    maps_won BIGINT,
+    maps_lost BIGINT,
+    map_diff BIGINT,
+    rounds_won BIGINT,
+    rounds_lost BIGINT,
+    round_diff BIGINT
+) AS $$
+#variable_conflict use_column
+DECLARE
+    v_swiss BOOLEAN;
+BEGIN
+    SELECT COALESCE(t.bracket_config ->> 'type', '') = 'swiss'
+    INTO v_swiss
+    FROM tournaments t
+    WHERE t.id = p_tournament_id;
+
+    RETURN QUERY
+    WITH stage_matches AS (
+        SELECT m.id, m.team1_id, m.team2_id, m.winner_team_id, m.is_forfeit,
+               CASE WHEN m.format ~ '^bo[0-9]+$' THEN substring(m.format FROM 3)::INT END AS series_length
+        FROM matches m
+        WHERE m.tournament_id = p_tournament_id
+          AND (p_stage IS NULL OR LOWER(m.stage) = LOWER(p_stage))
+          AND m.team1_id IS NOT NULL
+          AND m.team2_id IS NOT NULL
+    ),
+    game_totals AS (
+        SELECT sm.id AS match_id,
+               COUNT(g.id) FILTER (WHERE g.winner_team_id = sm.team1_id) AS maps1,
+               COUNT(g.id) FILTER (WHERE g.winner_team_id = sm.team2_id) AS maps2,
+               COALESCE(SUM(g.score_team1), 0) AS rounds1,
+               COALESCE(SUM(g.score_team2), 0) AS rounds2
+        FROM stage_matches sm
+        LEFT JOIN match_games g ON g.match_id = sm.id
+        GROUP BY sm.id
+    ),
+    sides AS (
+        SELECT sm.id AS match_id, sm.team1_id AS team_id, sm.team2_id AS opponent_id,
+               sm.winner_team_id, sm.is_forfeit, sm.series_length,
+               gt.maps1 AS maps_won, gt.maps2 AS maps_lost, gt.rounds1 AS rounds_won, gt.rounds2 AS rounds_lost
+        FROM stage_matches sm
+        JOIN game_totals gt ON gt.match_id = sm.id
+        UNION ALL
+        SELECT sm.id, sm.team2_id, sm.team1_id,
+               sm.winner_team_id, sm.is_forfeit, sm.series_length,
+               gt.maps2, gt.maps1, gt.rounds2, gt.rounds1
+        FROM stage_matches sm
+        JOIN game_totals gt ON gt.match_id = sm.id
+    ),
+    results AS (
+        -- ничья: серия сыграна полностью (bo2 1-1), победитель не определен
+        SELECT s.*,
+               (s.winner_team_id IS NULL
+                AND s.series_length IS NOT NULL
+                AND s.maps_won = s.maps_lost
+                AND s.maps_won + s.maps_lost >= s.series_length) AS is_draw
+        FROM sides s
+    ),
+    decided AS (
+        SELECT r.*,
+               CASE
+                   WHEN r.winner_team_id = r.team_id THEN p_points_win
+                   WHEN r.is_draw THEN p_points_draw
+                   ELSE p_points_loss
+               END AS match_points
+        FROM results r
+        WHERE r.winner_team_id IS NOT NULL OR r.is_draw
+    ),
+    totals AS (
+        SELECT r.team_id,
+               COUNT(d.match_id) AS matches_played,
+               COUNT(d.match_id) FILTER (WHERE d.winner_team_id = d.team_id) AS wins,
+               COUNT(d.match_id) FILTER (WHERE d.is_draw) AS draws,
+               COUNT(d.match_id) FILTER (WHERE d.winner_team_id IS NOT NULL AND d.winner_team_id <> d.team_id) AS losses,
+               COUNT(d.match_id) FILTER (WHERE d.is_forfeit) AS forfeits,
+               COALESCE(SUM(d.match_points), 0)::BIGINT AS points,
+               COALESCE(SUM(d.maps_won), 0)::BIGINT AS maps_won,
+               COALESCE(SUM(d.maps_lost), 0)::BIGINT AS maps_lost,
+               COALESCE(SUM(d.rounds_won), 0)::BIGINT AS rounds_won,
+               COALESCE(SUM(d.rounds_lost), 0)::BIGINT AS rounds_lost
+        FROM (SELECT DISTINCT res.team_id FROM results res) r
+        LEFT JOIN decided d ON d.team_id = r.team_id
+        GROUP BY r.team_id
+    ),
+    head_to_head AS (
+        -- очки, набранные только в матчах против команд с тем же количеством очков
+        SELECT d.team_id, SUM(d.match_points)::BIGINT AS h2h_points
+        FROM decided d
+        JOIN totals a ON a.team_id = d.team_id
+        JOIN totals b ON b.team_id = d.opponent_id
+        WHERE a.points = b.points
+        GROUP BY d.team_id
+    ),
+    opponents AS (
+        SELECT d.team_id, SUM(o.points)::BIGINT AS opp_points
+        FROM decided d
+        JOIN totals o ON o.team_id = d.opponent_id
+        GROUP BY d.team_id
+    )
+    SELECT RANK() OVER (
+               ORDER BY t.points DESC,
+                        CASE WHEN v_swiss THEN COALESCE(o.opp_points, 0) END DESC NULLS LAST,
+                        COALESCE(h.h2h_points, 0) DESC,
+                        t.maps_won - t.maps_lost DESC,
+                        t.rounds_won - t.rounds_lost DESC
+           ) AS rank,
+           t.team_id::INT,
+           tm.name::VARCHAR,
+           t.matches_played,
+           t.wins,
+           t.draws,
+           t.losses,
+           t.forfeits,
+           t.points,
+           COALESCE(h.h2h_points, 0)::BIGINT,
+           COALESCE(o.opp_points, 0)::BIGINT,
+           t.maps_won,
+           t.maps_lost,
+           t.maps_won - t.maps_lost,
+           t.rounds_won,
+           t.rounds_lost,
+           t.rounds_won - t.rounds_lost
+    FROM totals t
+    JOIN teams tm ON tm.id = t.team_id
+    LEFT JOIN head_to_head h ON h.team_id = t.team_id
+    LEFT JOIN opponents o ON o.team_id = t.team_id
+    ORDER BY 1, tm.name;
+END;
+$$ LANGUAGE plpgsql STABLE;
@@ -0,0 +1,4 @@
+-- ==========================================
+-- 17. Восстановление выгрузок фоновыми загрузками (откат)
+-- ==========================================
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS is_restore;
@@ -0,0 +1,4 @@
+-- ==========================================
+-- 17. Восстановление выгрузок фоновыми загрузками
+-- ==========================================
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS is_restore BOOLEAN NOT NULL DEFAULT FALSE; -- [BOOLEAN] (статусы турниров и заявок берутся из выгрузки)
@@ -0,0 +1,33 @@
+package models
+
+type BracketLink struct {
+	From  int
+	To    int
+	Slot  int
+	Loser bool
+}
+
+type MatchSlotAssignment struct {
+	MatchID int64
+	Slot    int
+	TeamID  *int64
+}
+
+type BracketNode struct {
+	Match    Match          `json:"match"`
+	Children []*BracketNode `json:"children"`
+}
+
+type BracketRound struct {
+	Section string  `json:"section"`
+	Round   int     `json:"round"`
+	Matches []Match `json:"matches"`
+}
+
+type BracketView struct {
+	TournamentID int64          `json:"tournament_id"`
+	Type         string         `json:"type"`
+	Format       string         `json:"format"`
+	Roots        []*BracketNode `json:"roots"`
+	Rounds       []BracketRound `json:"rounds"`
+}
@@ -0,0 +1,14 @@
+package models
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatSQL    = "sql"
+)
+
+// ExportFilter narrows an export to the data of one tournament: its discipline,
+// the teams registered or scheduled in it with their players, and its matches,
+// games and stats.
+type ExportFilter struct {
+	TournamentID *int64
+}
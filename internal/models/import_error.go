@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ImportError is a row rejected by a batch import, kept with the data it was
+// sent with so that it can be fixed and retried.
+type ImportError struct {
+	ID           int64           `db:"id" json:"id"`
+	Source       string          `db:"source" json:"source"`
+	RowData      json.RawMessage `db:"row_data" json:"row_data" swaggertype:"object"`
+	ErrorMessage string          `db:"error_message" json:"error_message"`
+	ErrorCode    string          `db:"error_code" json:"error_code"`
+	Field        *string         `db:"field" json:"field"`
+	RowIndex     *int            `db:"row_index" json:"row_index"`
+	LineNumber   *int            `db:"line_number" json:"line_number"`
+	OccurredAt   time.Time       `db:"occurred_at" json:"occurred_at"`
+	RetryCount   int             `db:"retry_count" json:"retry_count"`
+	RetriedAt    *time.Time      `db:"retried_at" json:"retried_at"`
+}
+
+type ImportErrorFilter struct {
+	Source string
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
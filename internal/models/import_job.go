@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ImportJobQueued    = "queued"
+	ImportJobRunning   = "running"
+	ImportJobCompleted = "completed"
+	ImportJobFailed    = "failed"
+	ImportJobCancelled = "cancelled"
+)
+
+const (
+	ImportEntityPlayers                 = "players"
+	ImportEntityDisciplines             = "disciplines"
+	ImportEntityTeams                   = "teams"
+	ImportEntityTournaments             = "tournaments"
+	ImportEntityTournamentRegistrations = "tournament-registrations"
+	ImportEntityMatches                 = "matches"
+	ImportEntityMatchGames              = "match-games"
+	ImportEntityGamePlayerStats         = "game-player-stats"
+	ImportEntitySquadMembers            = "squad-members"
+	ImportEntityTeamProfiles            = "team-profiles"
+)
+
+// ImportJob is a batch import processed in the background. Payload holds the
+// rows as a JSON array; Processed rows are never imported again, so a job
+// interrupted by a restart resumes where it stopped. CreatedBy and RequestID
+// are the audit actor and request that queued the job.
+type ImportJob struct {
+	ID         int64           `db:"id" json:"id"`
+	Entity     string          `db:"entity" json:"entity"`
+	Source     string          `db:"source" json:"source"`
+	Status     string          `db:"status" json:"status"`
+	IsAtomic   bool            `db:"is_atomic" json:"is_atomic"`
+	IsDryRun   bool            `db:"is_dry_run" json:"is_dry_run"`
+	IsRestore  bool            `db:"is_restore" json:"is_restore"`
+	OnConflict string          `db:"on_conflict" json:"on_conflict"`
+	CreatedBy  *string         `db:"created_by" json:"created_by"`
+	RequestID  *string         `db:"request_id" json:"request_id"`
+	Payload    json.RawMessage `db:"payload" json:"-"`
+	Total      int             `db:"total" json:"total"`
+	Processed  int             `db:"processed" json:"processed"`
+	Inserted   int             `db:"inserted" json:"inserted"`
+	Updated    int             `db:"updated" json:"updated"`
+	Skipped    int             `db:"skipped" json:"skipped"`
+	Failed     int             `db:"failed" json:"failed"`
+	Errors     json.RawMessage `db:"errors" json:"errors" swaggertype:"array,object"`
+	LastError  *string         `db:"last_error" json:"last_error"`
+	CreatedAt  time.Time       `db:"created_at" json:"created_at"`
+	StartedAt  *time.Time      `db:"started_at" json:"started_at"`
+	FinishedAt *time.Time      `db:"finished_at" json:"finished_at"`
+}
+
+// ImportJobProgress is the outcome of one processed chunk of a job.
+type ImportJobProgress struct {
+	Processed int
+	Inserted  int
+	Updated   int
+	Skipped   int
+	Failed    int
+	Errors    json.RawMessage
+}
+
+type ImportJobFilter struct {
+	Status string
+	Entity string
+	Limit  int
+	Offset int
+}
@@ -16,6 +16,14 @@ type Match struct {
 	WinnerTeamID *int64           `db:"winner_team_id" json:"winner_team_id"`
 	IsForfeit    bool             `db:"is_forfeit" json:"is_forfeit"`
 	MatchNotes   *json.RawMessage `db:"match_notes" json:"match_notes" swaggertype:"object"`
+
+	BracketSection     *string `db:"bracket_section" json:"bracket_section"`
+	BracketRound       *int    `db:"bracket_round" json:"bracket_round"`
+	BracketPosition    *int    `db:"bracket_position" json:"bracket_position"`
+	NextMatchID        *int64  `db:"next_match_id" json:"next_match_id"`
+	NextMatchSlot      *int    `db:"next_match_slot" json:"next_match_slot"`
+	LoserNextMatchID   *int64  `db:"loser_next_match_id" json:"loser_next_match_id"`
+	LoserNextMatchSlot *int    `db:"loser_next_match_slot" json:"loser_next_match_slot"`
 }
 
 type MatchFilter struct {
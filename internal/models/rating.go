@@ -0,0 +1,103 @@
+package models
+
+import "time"
+
+const (
+	RatingCauseMatch     = "match"
+	RatingCauseManual    = "manual"
+	RatingCauseRecompute = "recompute"
+)
+
+const (
+	RatingIntervalDaily  = "daily"
+	RatingIntervalWeekly = "weekly"
+)
+
+// RatedMatch is a decided match of a discipline pool. WinnerTeamID is nil
+// for a drawn series.
+type RatedMatch struct {
+	MatchID      int64     `db:"match_id"`
+	StartTime    time.Time `db:"start_time"`
+	Team1ID      int64     `db:"team1_id"`
+	Team2ID      int64     `db:"team2_id"`
+	WinnerTeamID *int64    `db:"winner_team_id"`
+}
+
+type MatchLineup struct {
+	MatchID  int64 `db:"match_id"`
+	TeamID   int64 `db:"team_id"`
+	PlayerID int64 `db:"player_id"`
+}
+
+type TeamRating struct {
+	TeamID        int64      `db:"team_id" json:"team_id"`
+	TeamName      string     `db:"team_name" json:"team_name"`
+	DisciplineID  int64      `db:"discipline_id" json:"discipline_id"`
+	Rating        float64    `db:"rating" json:"rating"`
+	MatchesPlayed int        `db:"matches_played" json:"matches_played"`
+	LastMatchID   *int64     `db:"last_match_id" json:"last_match_id"`
+	LastPlayedAt  *time.Time `db:"last_played_at" json:"last_played_at"`
+	UpdatedAt     time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+type PlayerRating struct {
+	PlayerID      int64      `db:"player_id" json:"player_id"`
+	Nickname      string     `db:"nickname" json:"nickname"`
+	DisciplineID  int64      `db:"discipline_id" json:"discipline_id"`
+	Rating        float64    `db:"rating" json:"rating"`
+	MatchesPlayed int        `db:"matches_played" json:"matches_played"`
+	LastMatchID   *int64     `db:"last_match_id" json:"last_match_id"`
+	LastPlayedAt  *time.Time `db:"last_played_at" json:"last_played_at"`
+	UpdatedAt     time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+type RatingFilter struct {
+	DisciplineID *int64
+	Limit        int
+	Offset       int
+}
+
+// RatingRecompute summarises the replay of one discipline pool.
+type RatingRecompute struct {
+	DisciplineID int64 `json:"discipline_id"`
+	Matches      int   `json:"matches"`
+	Teams        int   `json:"teams"`
+	Players      int   `json:"players"`
+}
+
+// RatingChange is one entry of the rating history of a team or a player.
+type RatingChange struct {
+	ID             int64     `db:"id" json:"id"`
+	TeamID         *int64    `db:"team_id" json:"team_id"`
+	PlayerID       *int64    `db:"player_id" json:"player_id"`
+	DisciplineID   *int64    `db:"discipline_id" json:"discipline_id"`
+	Rating         float64   `db:"rating" json:"rating"`
+	PreviousRating *float64  `db:"previous_rating" json:"previous_rating"`
+	Cause          string    `db:"cause" json:"cause"`
+	MatchID        *int64    `db:"match_id" json:"match_id"`
+	RecordedAt     time.Time `db:"recorded_at" json:"recorded_at"`
+}
+
+// RatingHistoryPoint is a point of a rating chart. A downsampled point covers
+// a whole day or week: Rating is the value at its end, PreviousRating the value
+// before its first change, and Cause and MatchID describe the last change.
+type RatingHistoryPoint struct {
+	RecordedAt     time.Time `db:"recorded_at" json:"recorded_at"`
+	DisciplineID   *int64    `db:"discipline_id" json:"discipline_id"`
+	Rating         float64   `db:"rating" json:"rating"`
+	PreviousRating *float64  `db:"previous_rating" json:"previous_rating"`
+	MinRating      float64   `db:"min_rating" json:"min_rating"`
+	MaxRating      float64   `db:"max_rating" json:"max_rating"`
+	Cause          string    `db:"cause" json:"cause"`
+	MatchID        *int64    `db:"match_id" json:"match_id"`
+	Changes        int       `db:"changes" json:"changes"`
+}
+
+type RatingHistoryFilter struct {
+	TeamID       *int64
+	PlayerID     *int64
+	DisciplineID *int64
+	From         *time.Time
+	To           *time.Time
+	Interval     string
+}
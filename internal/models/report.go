@@ -13,16 +13,46 @@ type ActiveRosterView struct {
 	JoinDate    time.Time `db:"join_date" json:"join_date"`
 }
 
+const (
+	RosterShortHanded = "short_handed"
+	RosterOverStaffed = "over_staffed"
+	RosterOK          = "ok"
+)
+
+type RosterHealthView struct {
+	TeamID        int64  `db:"team_id" json:"team_id"`
+	TeamName      string `db:"team_name" json:"team_name"`
+	Tag           string `db:"tag" json:"tag"`
+	DisciplineID  int64  `db:"discipline_id" json:"discipline_id"`
+	TeamSize      *int   `db:"team_size" json:"team_size"`
+	Starters      int64  `db:"starters" json:"starters"`
+	Standins      int64  `db:"standins" json:"standins"`
+	ActivePlayers int64  `db:"active_players" json:"active_players"`
+	Status        string `db:"status" json:"status"`
+}
+
+type RosterHealthFilter struct {
+	DisciplineID *int64
+	Status       string
+	Limit        int
+	Offset       int
+}
+
 type MatchResultView struct {
-	MatchID         int64     `db:"match_id" json:"match_id"`
-	TournamentID    int64     `db:"tournament_id" json:"tournament_id"`
-	StartTime       time.Time `db:"start_time" json:"start_time"`
-	Stage           *string   `db:"stage" json:"stage"`
-	Format          string    `db:"format" json:"format"`
-	WinnerTeamID    *int64    `db:"winner_team_id" json:"winner_team_id"`
-	GamesPlayed     int64     `db:"games_played" json:"games_played"`
-	TotalScoreTeam1 *int64    `db:"total_score_team1" json:"total_score_team1"`
-	TotalScoreTeam2 *int64    `db:"total_score_team2" json:"total_score_team2"`
+	MatchID          int64     `db:"match_id" json:"match_id"`
+	TournamentID     int64     `db:"tournament_id" json:"tournament_id"`
+	StartTime        time.Time `db:"start_time" json:"start_time"`
+	Stage            *string   `db:"stage" json:"stage"`
+	Format           string    `db:"format" json:"format"`
+	Team1ID          *int64    `db:"team1_id" json:"team1_id"`
+	Team2ID          *int64    `db:"team2_id" json:"team2_id"`
+	WinnerTeamID     *int64    `db:"winner_team_id" json:"winner_team_id"`
+	GamesPlayed      int64     `db:"games_played" json:"games_played"`
+	SeriesScoreTeam1 int64     `db:"series_score_team1" json:"series_score_team1"`
+	SeriesScoreTeam2 int64     `db:"series_score_team2" json:"series_score_team2"`
+	SeriesScore      string    `db:"series_score" json:"series_score"`
+	TotalScoreTeam1  *int64    `db:"total_score_team1" json:"total_score_team1"`
+	TotalScoreTeam2  *int64    `db:"total_score_team2" json:"total_score_team2"`
 }
 
 type PlayerCareerStats struct {
@@ -37,9 +67,29 @@ type PlayerCareerStats struct {
 }
 
 type TournamentStanding struct {
-	TeamID        int64 `db:"team_id" json:"team_id"`
-	MatchesPlayed int64 `db:"matches_played" json:"matches_played"`
-	Wins          int64 `db:"wins" json:"wins"`
-	Losses        int64 `db:"losses" json:"losses"`
-	Forfeits      int64 `db:"forfeits" json:"forfeits"`
+	Rank          int64  `db:"rank" json:"rank"`
+	TeamID        int64  `db:"team_id" json:"team_id"`
+	TeamName      string `db:"team_name" json:"team_name"`
+	MatchesPlayed int64  `db:"matches_played" json:"matches_played"`
+	Wins          int64  `db:"wins" json:"wins"`
+	Draws         int64  `db:"draws" json:"draws"`
+	Losses        int64  `db:"losses" json:"losses"`
+	Forfeits      int64  `db:"forfeits" json:"forfeits"`
+	Points        int64  `db:"points" json:"points"`
+	HeadToHead    int64  `db:"head_to_head" json:"head_to_head"`
+	Buchholz      int64  `db:"buchholz" json:"buchholz"`
+	MapsWon       int64  `db:"maps_won" json:"maps_won"`
+	MapsLost      int64  `db:"maps_lost" json:"maps_lost"`
+	MapDiff       int64  `db:"map_diff" json:"map_diff"`
+	RoundsWon     int64  `db:"rounds_won" json:"rounds_won"`
+	RoundsLost    int64  `db:"rounds_lost" json:"rounds_lost"`
+	RoundDiff     int64  `db:"round_diff" json:"round_diff"`
+}
+
+type StandingsFilter struct {
+	TournamentID int64
+	Stage        *string
+	PointsWin    *int
+	PointsDraw   *int
+	PointsLoss   *int
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// RosterSnapshot is stored in tournament_registrations.roster_snapshot when a
+// registration is confirmed.
+type RosterSnapshot struct {
+	TeamID     int64          `json:"team_id"`
+	CapturedAt time.Time      `json:"captured_at"`
+	Players    []RosterPlayer `json:"players"`
+}
+
+type RosterPlayer struct {
+	PlayerID  int64  `db:"player_id" json:"player_id"`
+	Nickname  string `db:"nickname" json:"nickname"`
+	Role      string `db:"role" json:"role"`
+	IsStandin bool   `db:"is_standin" json:"is_standin"`
+}
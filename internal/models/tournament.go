@@ -16,6 +16,7 @@ type Tournament struct {
 	Status        string          `db:"status" json:"status"`
 	IsOnline      bool            `db:"is_online" json:"is_online"`
 	BracketConfig json.RawMessage `db:"bracket_config" json:"bracket_config" swaggertype:"object"`
+	MaxTeams      *int            `db:"max_teams" json:"max_teams"`
 }
 type TournamentFilter struct {
 	Search       string
@@ -26,3 +27,20 @@ type TournamentFilter struct {
 	Limit        int
 	Offset       int
 }
+
+const (
+	TournamentAnnounced          = "Announced"
+	TournamentRegistrationOpen   = "RegistrationOpen"
+	TournamentRegistrationClosed = "RegistrationClosed"
+	TournamentOngoing            = "Ongoing"
+	TournamentCompleted          = "Completed"
+	TournamentCancelled          = "Cancelled"
+)
+
+type TournamentStatusChange struct {
+	ID           int64     `db:"id" json:"id"`
+	TournamentID int64     `db:"tournament_id" json:"tournament_id"`
+	FromStatus   *string   `db:"from_status" json:"from_status"`
+	ToStatus     string    `db:"to_status" json:"to_status"`
+	ChangedAt    time.Time `db:"changed_at" json:"changed_at"`
+}
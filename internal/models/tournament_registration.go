@@ -13,6 +13,7 @@ type TournamentRegistration struct {
 	Status         string          `db:"status" json:"status"`
 	ManagerContact *string         `db:"manager_contact" json:"manager_contact"`
 	RosterSnapshot json.RawMessage `db:"roster_snapshot" json:"roster_snapshot" swaggertype:"object"`
+	RosterLockedAt *time.Time      `db:"roster_locked_at" json:"roster_locked_at"`
 	IsInvited      bool            `db:"is_invited" json:"is_invited"`
 	RegisteredAt   time.Time       `db:"registered_at" json:"registered_at"`
 }
@@ -24,3 +25,18 @@ type TournamentRegistrationFilter struct {
 	Limit        int
 	Offset       int
 }
+
+const (
+	RegistrationPending    = "Pending"
+	RegistrationConfirmed  = "Confirmed"
+	RegistrationWaitlisted = "Waitlisted"
+	RegistrationRejected   = "Rejected"
+	RegistrationWithdrawn  = "Withdrawn"
+)
+
+// RegistrationStatusChange is the result of an approve/reject/withdraw action.
+// Promoted is the waitlisted registration that took the freed slot, if any.
+type RegistrationStatusChange struct {
+	Registration TournamentRegistration  `json:"registration"`
+	Promoted     *TournamentRegistration `json:"promoted"`
+}
@@ -0,0 +1,123 @@
+package rating
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	DefaultRating = 1500.0
+	DefaultK      = 32.0
+)
+
+// Match is one rated match. Score is the result for team 1: 1 for a win,
+// 0.5 for a draw and 0 for a loss.
+type Match struct {
+	ID           int64
+	PlayedAt     time.Time
+	Team1ID      int64
+	Team2ID      int64
+	Score        float64
+	Team1Players []int64
+	Team2Players []int64
+}
+
+// Change is the rating movement of one team or player caused by a match.
+type Change struct {
+	Team   bool
+	ID     int64
+	Before float64
+	After  float64
+}
+
+type Entry struct {
+	Rating       float64
+	Matches      int
+	LastMatchID  int64
+	LastPlayedAt time.Time
+}
+
+// Pool holds the Elo ratings of one discipline. Teams and players enter the
+// pool at DefaultRating with their first match.
+type Pool struct {
+	K       float64
+	Teams   map[int64]*Entry
+	Players map[int64]*Entry
+}
+
+func NewPool() *Pool {
+	return &Pool{K: DefaultK, Teams: map[int64]*Entry{}, Players: map[int64]*Entry{}}
+}
+
+// Expected is the probability that a side rated a beats a side rated b.
+func Expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// Apply rates a match. Teams are rated against each other; every player is
+// rated against the average of the opposing lineup, or the opposing team when
+// its lineup is unknown. Matches must be applied in the order they were played.
+func (p *Pool) Apply(m Match) []Change {
+	team1, team2 := p.entry(p.Teams, m.Team1ID), p.entry(p.Teams, m.Team2ID)
+	opp1, opp2 := p.lineupRating(m.Team2Players, team2), p.lineupRating(m.Team1Players, team1)
+	changes := p.applyPlayers(m, m.Team1Players, opp1, m.Score)
+	changes = append(changes, p.applyPlayers(m, m.Team2Players, opp2, 1-m.Score)...)
+
+	delta := p.K * (m.Score - Expected(team1.Rating, team2.Rating))
+	changes = append(changes,
+		Change{Team: true, ID: m.Team1ID, Before: team1.Rating, After: team1.Rating + delta},
+		Change{Team: true, ID: m.Team2ID, Before: team2.Rating, After: team2.Rating - delta},
+	)
+	team1.Rating += delta
+	team2.Rating -= delta
+	touch(team1, m)
+	touch(team2, m)
+	return changes
+}
+
+func (p *Pool) applyPlayers(m Match, players []int64, opponent, score float64) []Change {
+	changes := make([]Change, 0, len(players))
+	seen := map[int64]bool{}
+	for _, id := range players {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		e := p.entry(p.Players, id)
+		before := e.Rating
+		e.Rating += p.K * (score - Expected(e.Rating, opponent))
+		touch(e, m)
+		changes = append(changes, Change{ID: id, Before: before, After: e.Rating})
+	}
+	return changes
+}
+
+func (p *Pool) lineupRating(players []int64, team *Entry) float64 {
+	if len(players) == 0 {
+		return team.Rating
+	}
+	sum := 0.0
+	for _, id := range players {
+		if e, ok := p.Players[id]; ok {
+			sum += e.Rating
+		} else {
+			sum += DefaultRating
+		}
+	}
+	return sum / float64(len(players))
+}
+
+func (p *Pool) entry(entries map[int64]*Entry, id int64) *Entry {
+	e, ok := entries[id]
+	if !ok {
+		e = &Entry{Rating: DefaultRating}
+		entries[id] = e
+	}
+	return e
+}
+
+func touch(e *Entry, m Match) {
+	e.Matches++
+	e.LastMatchID = m.ID
+	e.LastPlayedAt = m.PlayedAt
+}
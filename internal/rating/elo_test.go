@@ -0,0 +1,142 @@
+package rating
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestExpected(t *testing.T) {
+	tests := []struct {
+		a, b float64
+		want float64
+	}{
+		{a: 1500, b: 1500, want: 0.5},
+		{a: 1900, b: 1500, want: 10.0 / 11},
+		{a: 1500, b: 1900, want: 1.0 / 11},
+		{a: 2300, b: 1500, want: 100.0 / 101},
+	}
+	for _, tt := range tests {
+		if got := Expected(tt.a, tt.b); !near(got, tt.want) {
+			t.Errorf("Expected(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if sum := Expected(tt.a, tt.b) + Expected(tt.b, tt.a); !near(sum, 1) {
+			t.Errorf("Expected(%v, %v) and its reverse add up to %v", tt.a, tt.b, sum)
+		}
+	}
+}
+
+func TestApplyTeams(t *testing.T) {
+	tests := []struct {
+		name   string
+		team1  float64
+		team2  float64
+		score  float64
+		after1 float64
+	}{
+		{name: "even win", team1: 1500, team2: 1500, score: 1, after1: 1516},
+		{name: "even draw", team1: 1500, team2: 1500, score: 0.5, after1: 1500},
+		{name: "even loss", team1: 1500, team2: 1500, score: 0, after1: 1484},
+		{name: "favourite wins", team1: 1900, team2: 1500, score: 1, after1: 1900 + 32.0/11},
+		{name: "upset", team1: 1500, team2: 1900, score: 1, after1: 1500 + 32*10.0/11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPool()
+			p.Teams[1] = &Entry{Rating: tt.team1}
+			p.Teams[2] = &Entry{Rating: tt.team2}
+			p.Apply(Match{ID: 10, Team1ID: 1, Team2ID: 2, Score: tt.score})
+			if got := p.Teams[1].Rating; !near(got, tt.after1) {
+				t.Errorf("team 1 = %v, want %v", got, tt.after1)
+			}
+			if total := p.Teams[1].Rating + p.Teams[2].Rating; !near(total, tt.team1+tt.team2) {
+				t.Errorf("ratings add up to %v, want %v", total, tt.team1+tt.team2)
+			}
+			for _, id := range []int64{1, 2} {
+				if e := p.Teams[id]; e.Matches != 1 || e.LastMatchID != 10 {
+					t.Errorf("team %d: matches %d, last match %d", id, e.Matches, e.LastMatchID)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyPlayers(t *testing.T) {
+	p := NewPool()
+	p.Players[11] = &Entry{Rating: 1600}
+	changes := p.Apply(Match{
+		ID:           1,
+		Team1ID:      1,
+		Team2ID:      2,
+		Score:        1,
+		Team1Players: []int64{11, 12},
+		Team2Players: []int64{21, 22, 22},
+	})
+	// Two winners, two distinct losers and both teams.
+	if len(changes) != 6 {
+		t.Fatalf("got %d changes, want 6", len(changes))
+	}
+	// Player 11 is rated against the average of the opposing lineup.
+	if want := 1600 + 32*(1-Expected(1600, 1500)); !near(p.Players[11].Rating, want) {
+		t.Errorf("player 11 = %v, want %v", p.Players[11].Rating, want)
+	}
+	if p.Players[22].Matches != 1 {
+		t.Errorf("player 22 listed twice was rated %d times", p.Players[22].Matches)
+	}
+	// The losers face a lineup averaging 1550.
+	if want := 1500 + 32*(0-Expected(1500, 1550)); !near(p.Players[21].Rating, want) {
+		t.Errorf("player 21 = %v, want %v", p.Players[21].Rating, want)
+	}
+}
+
+func TestApplyWithoutLineup(t *testing.T) {
+	p := NewPool()
+	p.Teams[2] = &Entry{Rating: 1700}
+	p.Apply(Match{ID: 1, Team1ID: 1, Team2ID: 2, Score: 1, Team1Players: []int64{11}})
+	if want := 1500 + 32*(1-Expected(1500, 1700)); !near(p.Players[11].Rating, want) {
+		t.Errorf("player 11 = %v, want %v rated against the opposing team", p.Players[11].Rating, want)
+	}
+}
+
+// Replaying the same matches in the same order gives the same pool, and
+// appending a match to a replayed pool matches replaying all of them.
+func TestReplay(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	matches := []Match{
+		{ID: 1, PlayedAt: start, Team1ID: 1, Team2ID: 2, Score: 1, Team1Players: []int64{11}, Team2Players: []int64{21}},
+		{ID: 2, PlayedAt: start.Add(time.Hour), Team1ID: 2, Team2ID: 3, Score: 0.5},
+		{ID: 3, PlayedAt: start.Add(2 * time.Hour), Team1ID: 3, Team2ID: 1, Score: 1, Team2Players: []int64{11}},
+		{ID: 4, PlayedAt: start.Add(3 * time.Hour), Team1ID: 1, Team2ID: 2, Score: 0, Team1Players: []int64{11}, Team2Players: []int64{21}},
+	}
+	replay := func(ms []Match) *Pool {
+		p := NewPool()
+		for _, m := range ms {
+			p.Apply(m)
+		}
+		return p
+	}
+	full := replay(matches)
+	again := replay(matches)
+	appended := replay(matches[:3])
+	appended.Apply(matches[3])
+	for _, p := range []*Pool{again, appended} {
+		for id, e := range full.Teams {
+			got := p.Teams[id]
+			if got == nil || !near(got.Rating, e.Rating) || got.Matches != e.Matches || got.LastMatchID != e.LastMatchID {
+				t.Errorf("team %d = %+v, want %+v", id, got, e)
+			}
+		}
+		for id, e := range full.Players {
+			if got := p.Players[id]; got == nil || !near(got.Rating, e.Rating) {
+				t.Errorf("player %d = %+v, want %+v", id, got, e)
+			}
+		}
+	}
+	if e := full.Teams[1]; e.Matches != 3 || !e.LastPlayedAt.Equal(matches[3].PlayedAt) {
+		t.Errorf("team 1 = %+v, want 3 matches last played at %v", e, matches[3].PlayedAt)
+	}
+}
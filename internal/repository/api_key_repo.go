@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	"db_course_project/internal/models"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, k *models.APIKey, hash string) error
+	ListByUser(ctx context.Context, userID int64) ([]models.APIKey, error)
+	Revoke(ctx context.Context, id, userID int64) error
+	// Authenticate returns the active user owning the unrevoked, unexpired key
+	// with this hash, and the name of the key, and records the use of the key.
+	Authenticate(ctx context.Context, hash string) (*models.User, string, error)
+}
+
+func NewAPIKeyRepository(db *sqlx.DB) APIKeyRepository {
+	return &apiKeyRepo{db: db}
+}
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyInvalid  = errors.New("invalid, revoked or expired api key")
+)
+
+const apiKeyColumns = `id, user_id, name, prefix, created_at, expires_at, last_used_at, revoked_at`
+
+type apiKeyRepo struct {
+	db *sqlx.DB
+}
+
+func (r *apiKeyRepo) Create(ctx context.Context, k *models.APIKey, hash string) error {
+	query := `INSERT INTO api_keys (user_id, name, prefix, key_hash, expires_at)
+			  VALUES ($1,$2,$3,$4,$5)
+			  RETURNING id, created_at`
+	return conn(ctx, r.db).QueryRowxContext(ctx, query,
+		k.UserID,
+		k.Name,
+		k.Prefix,
+		hash,
+		k.ExpiresAt,
+	).Scan(&k.ID, &k.CreatedAt)
+}
+
+func (r *apiKeyRepo) ListByUser(ctx context.Context, userID int64) ([]models.APIKey, error) {
+	rows := []models.APIKey{}
+	err := conn(ctx, r.db).SelectContext(ctx, &rows,
+		`SELECT `+apiKeyColumns+` FROM api_keys WHERE user_id=$1 ORDER BY id`, userID)
+	return rows, err
+}
+
+func (r *apiKeyRepo) Revoke(ctx context.Context, id, userID int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE api_keys SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP) WHERE id=$1 AND user_id=$2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *apiKeyRepo) Authenticate(ctx context.Context, hash string) (*models.User, string, error) {
+	var row struct {
+		models.User
+		KeyName string `db:"key_name"`
+	}
+	query := `UPDATE api_keys k SET last_used_at = CURRENT_TIMESTAMP
+			  FROM users u
+			  WHERE k.key_hash=$1 AND u.id = k.user_id AND u.is_active
+			    AND k.revoked_at IS NULL AND (k.expires_at IS NULL OR k.expires_at > CURRENT_TIMESTAMP)
+			  RETURNING u.id, u.username, u.password_hash, u.role, u.team_id, u.is_active, u.created_at, k.name AS key_name`
+	if err := conn(ctx, r.db).GetContext(ctx, &row, query, hash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", ErrAPIKeyInvalid
+		}
+		return nil, "", err
+	}
+	return &row.User, row.KeyName, nil
+}
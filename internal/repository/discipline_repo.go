@@ -14,6 +14,7 @@ import (
 type DisciplineRepository interface {
 	Create(ctx context.Context, d *models.Discipline) error
 	GetByID(ctx context.Context, id int64) (*models.Discipline, error)
+	GetByCode(ctx context.Context, code string) (*models.Discipline, error)
 	List(ctx context.Context, filter models.DisciplineFilter) ([]models.Discipline, int, error)
 	Update(ctx context.Context, d *models.Discipline) error
 	Delete(ctx context.Context, id int64) error
@@ -32,7 +33,7 @@ type disciplineRepo struct {
 func (r *disciplineRepo) Create(ctx context.Context, d *models.Discipline) error {
 	query := `INSERT INTO disciplines (code, name, description, icon_url, team_size, is_active, metadata)
 			  VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
-	return r.db.QueryRowxContext(ctx, query, d.Code, d.Name, d.Description, d.IconURL, d.TeamSize, d.IsActive, d.Metadata).
+	return conn(ctx, r.db).QueryRowxContext(ctx, query, d.Code, d.Name, d.Description, d.IconURL, d.TeamSize, d.IsActive, d.Metadata).
 		Scan(&d.ID)
 }
 
@@ -40,7 +41,20 @@ func (r *disciplineRepo) GetByID(ctx context.Context, id int64) (*models.Discipl
 	var d models.Discipline
 	query := `SELECT id, code, name, description, icon_url, team_size, is_active, metadata
 			  FROM disciplines WHERE id = $1`
-	if err := r.db.GetContext(ctx, &d, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &d, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDisciplineNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *disciplineRepo) GetByCode(ctx context.Context, code string) (*models.Discipline, error) {
+	var d models.Discipline
+	query := `SELECT id, code, name, description, icon_url, team_size, is_active, metadata
+			  FROM disciplines WHERE code = $1`
+	if err := conn(ctx, r.db).GetContext(ctx, &d, query, code); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrDisciplineNotFound
 		}
@@ -66,7 +80,7 @@ func (r *disciplineRepo) List(ctx context.Context, filter models.DisciplineFilte
 
 	countQuery := `SELECT count(*) ` + base + conditions
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
@@ -77,7 +91,7 @@ func (r *disciplineRepo) List(ctx context.Context, filter models.DisciplineFilte
 				  ORDER BY name ASC, id ASC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	var rows []models.Discipline
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
@@ -87,7 +101,7 @@ func (r *disciplineRepo) Update(ctx context.Context, d *models.Discipline) error
 	query := `UPDATE disciplines
 			  SET code=$1, name=$2, description=$3, icon_url=$4, team_size=$5, is_active=$6, metadata=$7
 			  WHERE id=$8`
-	res, err := r.db.ExecContext(ctx, query, d.Code, d.Name, d.Description, d.IconURL, d.TeamSize, d.IsActive, d.Metadata, d.ID)
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, d.Code, d.Name, d.Description, d.IconURL, d.TeamSize, d.IsActive, d.Metadata, d.ID)
 	if err != nil {
 		return err
 	}
@@ -98,7 +112,7 @@ func (r *disciplineRepo) Update(ctx context.Context, d *models.Discipline) error
 }
 
 func (r *disciplineRepo) Delete(ctx context.Context, id int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM disciplines WHERE id=$1`, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM disciplines WHERE id=$1`, id)
 	if err != nil {
 		return err
 	}
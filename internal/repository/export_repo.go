@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+
+	"db_course_project/internal/models"
+)
+
+type ExportRepository interface {
+	// Rows streams an entity with its columns named after the csv tags of its
+	// import type; references are exported by natural key.
+	Rows(ctx context.Context, entity string, filter models.ExportFilter) (*sqlx.Rows, error)
+	Table(ctx context.Context, entity string) (*DumpTable, error)
+	// TableRows streams the stored columns of a dump table.
+	TableRows(ctx context.Context, table *DumpTable, filter models.ExportFilter) (*sqlx.Rows, error)
+}
+
+func NewExportRepository(db *sqlx.DB) ExportRepository {
+	return &exportRepo{db: db}
+}
+
+var ErrUnknownExportEntity = errors.New("unknown export entity")
+
+// DumpTable is the stored form of an entity's table.
+type DumpTable struct {
+	Name    string
+	Columns []string
+	// Identity is the identity column, empty when the table has none.
+	Identity string
+	// Deferred columns reference rows of the same table and are set once all
+	// rows are in.
+	Deferred []string
+	entity   string
+}
+
+type exportQuery struct {
+	table   string
+	columns string
+	joins   string
+	// scope limits the rows to the tournament $1.
+	scope    string
+	order    string
+	deferred []string
+}
+
+// exportTournamentTeams selects the teams registered or scheduled in the
+// tournament $1.
+const exportTournamentTeams = `SELECT team_id FROM tournament_registrations WHERE tournament_id = $1
+	UNION SELECT team1_id FROM matches WHERE tournament_id = $1
+	UNION SELECT team2_id FROM matches WHERE tournament_id = $1`
+
+const exportTournamentGames = `SELECT g.id FROM match_games g JOIN matches m ON m.id = g.match_id WHERE m.tournament_id = $1`
+
+// exportMatchRef names the match m by its tournament, start time and teams,
+// the reference the game and stat imports resolve; match ids differ between
+// databases.
+const exportMatchRef = `tn.name AS tournament_name,
+	to_char(m.start_time AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"') AS match_start_time,
+	COALESCE(mt1.tag, '') AS match_team1_tag, COALESCE(mt2.tag, '') AS match_team2_tag`
+
+const exportMatchRefJoins = `JOIN tournaments tn ON tn.id = m.tournament_id
+	LEFT JOIN teams mt1 ON mt1.id = m.team1_id LEFT JOIN teams mt2 ON mt2.id = m.team2_id`
+
+var exportQueries = map[string]exportQuery{
+	models.ImportEntityDisciplines: {
+		table: "disciplines",
+		columns: `t.code, t.name, COALESCE(t.description, '') AS description, t.icon_url, t.team_size,
+			COALESCE(t.metadata, '{}'::jsonb) AS metadata, t.is_active`,
+		scope: `t.id = (SELECT discipline_id FROM tournaments WHERE id = $1)`,
+	},
+	models.ImportEntityTeams: {
+		table: "teams",
+		columns: `t.name, t.tag, t.country_code, d.code AS discipline_code, t.logo_url,
+			t.world_ranking::float8 AS world_ranking, t.is_verified`,
+		joins: `JOIN disciplines d ON d.id = t.discipline_id`,
+		scope: `t.id IN (` + exportTournamentTeams + `)`,
+	},
+	models.ImportEntityTeamProfiles: {
+		table: "team_profiles",
+		columns: `tm.tag AS team_tag, d.code AS discipline_code, t.coach_name, t.sponsor_info, t.headquarters,
+			t.website, t.contact_email`,
+		joins: `JOIN teams tm ON tm.id = t.team_id JOIN disciplines d ON d.id = tm.discipline_id`,
+		scope: `t.team_id IN (` + exportTournamentTeams + `)`,
+		order: `t.team_id`,
+	},
+	models.ImportEntityPlayers: {
+		table: "players",
+		columns: `t.nickname, COALESCE(t.real_name, '') AS real_name, COALESCE(t.country_code, '') AS country_code,
+			COALESCE(to_char(t.birth_date, 'YYYY-MM-DD'), '') AS birth_date, COALESCE(t.steam_id, '') AS steam_id,
+			COALESCE(t.avatar_url, '') AS avatar_url, COALESCE(t.mmr_rating, 0)::float8 AS mmr_rating, t.is_retired`,
+		scope: `t.id IN (SELECT player_id FROM squad_members WHERE team_id IN (` + exportTournamentTeams + `))
+			OR t.id IN (SELECT player_id FROM game_player_stats WHERE game_id IN (` + exportTournamentGames + `))`,
+	},
+	models.ImportEntitySquadMembers: {
+		table: "squad_members",
+		columns: `tm.tag AS team_tag, d.code AS discipline_code, p.nickname AS player_nickname, t.role, t.is_standin,
+			to_char(t.join_date, 'YYYY-MM-DD') AS join_date, to_char(t.contract_end_date, 'YYYY-MM-DD') AS contract_end_date,
+			to_char(t.leave_date, 'YYYY-MM-DD') AS leave_date, t.salary_monthly::float8 AS salary_monthly`,
+		joins: `JOIN teams tm ON tm.id = t.team_id JOIN disciplines d ON d.id = tm.discipline_id
+			JOIN players p ON p.id = t.player_id`,
+		scope: `t.team_id IN (` + exportTournamentTeams + `)`,
+	},
+	models.ImportEntityTournaments: {
+		table: "tournaments",
+		columns: `d.code AS discipline_code, t.name, to_char(t.start_date, 'YYYY-MM-DD') AS start_date,
+			to_char(t.end_date, 'YYYY-MM-DD') AS end_date, COALESCE(t.prize_pool, 0)::float8 AS prize_pool,
+			COALESCE(t.currency, '') AS currency, t.status, t.is_online, t.bracket_config, t.max_teams`,
+		joins: `JOIN disciplines d ON d.id = t.discipline_id`,
+		scope: `t.id = $1`,
+	},
+	models.ImportEntityTournamentRegistrations: {
+		table: "tournament_registrations",
+		columns: `tn.name AS tournament_name, tm.tag AS team_tag, t.seed_number, COALESCE(t.status, '') AS status,
+			t.manager_contact, t.is_invited`,
+		joins: `JOIN tournaments tn ON tn.id = t.tournament_id JOIN teams tm ON tm.id = t.team_id`,
+		scope: `t.tournament_id = $1`,
+	},
+	models.ImportEntityMatches: {
+		table: "matches",
+		columns: `tn.name AS tournament_name, COALESCE(t1.tag, '') AS team1_tag, COALESCE(t2.tag, '') AS team2_tag,
+			to_char(t.start_time AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"') AS start_time, t.format, t.stage,
+			COALESCE(w.tag, '') AS winner_team_tag, t.is_forfeit, t.match_notes`,
+		joins: `JOIN tournaments tn ON tn.id = t.tournament_id LEFT JOIN teams t1 ON t1.id = t.team1_id
+			LEFT JOIN teams t2 ON t2.id = t.team2_id LEFT JOIN teams w ON w.id = t.winner_team_id`,
+		scope:    `t.tournament_id = $1`,
+		deferred: []string{"next_match_id", "loser_next_match_id"},
+	},
+	models.ImportEntityMatchGames: {
+		table: "match_games",
+		columns: exportMatchRef + `, t.map_name, t.game_number, t.duration_seconds, COALESCE(w.tag, '') AS winner_team_tag,
+			t.score_team1, t.score_team2, to_char(t.started_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"') AS started_at,
+			t.had_technical_pause, t.pick_ban_phase`,
+		joins: `JOIN matches m ON m.id = t.match_id ` + exportMatchRefJoins + ` LEFT JOIN teams w ON w.id = t.winner_team_id`,
+		scope: `t.match_id IN (SELECT id FROM matches WHERE tournament_id = $1)`,
+	},
+	models.ImportEntityGamePlayerStats: {
+		table: "game_player_stats",
+		columns: exportMatchRef + `, g.game_number, p.nickname AS player_nickname, COALESCE(tm.tag, '') AS team_tag,
+			COALESCE(t.kills, 0) AS kills, COALESCE(t.deaths, 0) AS deaths, COALESCE(t.assists, 0) AS assists, t.hero_name,
+			COALESCE(t.damage_dealt, 0) AS damage_dealt, COALESCE(t.gold_earned, 0) AS gold_earned, t.was_mvp`,
+		joins: `JOIN match_games g ON g.id = t.game_id JOIN matches m ON m.id = g.match_id ` + exportMatchRefJoins + `
+			JOIN players p ON p.id = t.player_id LEFT JOIN teams tm ON tm.id = t.team_id`,
+		scope: `t.game_id IN (` + exportTournamentGames + `)`,
+	},
+}
+
+type exportRepo struct {
+	db *sqlx.DB
+}
+
+func (r *exportRepo) Rows(ctx context.Context, entity string, filter models.ExportFilter) (*sqlx.Rows, error) {
+	q, ok := exportQueries[entity]
+	if !ok {
+		return nil, ErrUnknownExportEntity
+	}
+	where, args := q.where(filter)
+	rows, err := conn(ctx, r.db).QueryxContext(ctx,
+		`SELECT `+q.columns+` FROM `+q.table+` t `+q.joins+where+` ORDER BY `+q.orderBy(), args...)
+	if err != nil {
+		return nil, err
+	}
+	rows.Mapper = reflectx.NewMapper("csv")
+	return rows, nil
+}
+
+func (r *exportRepo) Table(ctx context.Context, entity string) (*DumpTable, error) {
+	q, ok := exportQueries[entity]
+	if !ok {
+		return nil, ErrUnknownExportEntity
+	}
+	var cols []struct {
+		Name       string `db:"column_name"`
+		IsIdentity bool   `db:"is_identity"`
+	}
+	err := conn(ctx, r.db).SelectContext(ctx, &cols, `SELECT column_name, is_identity = 'YES' AS is_identity
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1 AND is_generated = 'NEVER'
+		ORDER BY ordinal_position`, q.table)
+	if err != nil {
+		return nil, err
+	}
+	table := &DumpTable{Name: q.table, Deferred: q.deferred, entity: entity}
+	for _, col := range cols {
+		table.Columns = append(table.Columns, col.Name)
+		if col.IsIdentity {
+			table.Identity = col.Name
+		}
+	}
+	return table, nil
+}
+
+func (r *exportRepo) TableRows(ctx context.Context, table *DumpTable, filter models.ExportFilter) (*sqlx.Rows, error) {
+	q := exportQueries[table.entity]
+	where, args := q.where(filter)
+	return conn(ctx, r.db).QueryxContext(ctx,
+		`SELECT t.`+strings.Join(table.Columns, `, t.`)+` FROM `+q.table+` t`+where+` ORDER BY `+q.orderBy(), args...)
+}
+
+func (q exportQuery) where(filter models.ExportFilter) (string, []any) {
+	if filter.TournamentID == nil {
+		return ``, nil
+	}
+	return ` WHERE (` + q.scope + `)`, []any{*filter.TournamentID}
+}
+
+func (q exportQuery) orderBy() string {
+	if q.order != "" {
+		return q.order
+	}
+	return `t.id`
+}
@@ -14,10 +14,13 @@ import (
 
 type GamePlayerStatRepository interface {
 	Create(ctx context.Context, s *models.GamePlayerStat) error
+	CreateMany(ctx context.Context, stats []*models.GamePlayerStat) error
 	GetByID(ctx context.Context, id int64) (*models.GamePlayerStat, error)
+	GetByGamePlayer(ctx context.Context, gameID, playerID int64) (*models.GamePlayerStat, error)
 	List(ctx context.Context, filter models.GamePlayerStatFilter) ([]models.GamePlayerStat, int, error)
 	Update(ctx context.Context, s *models.GamePlayerStat) error
 	Delete(ctx context.Context, id int64) error
+	CountByGameTeam(ctx context.Context, gameID, teamID, excludeID int64) (int, error)
 }
 
 func NewGamePlayerStatRepository(db *sqlx.DB) GamePlayerStatRepository {
@@ -31,9 +34,9 @@ type gamePlayerStatRepo struct {
 }
 
 func (r *gamePlayerStatRepo) Create(ctx context.Context, s *models.GamePlayerStat) error {
-	query := `INSERT INTO game_player_stats (game_id, player_id, team_id, kills, deaths, assists, hero_name, damage_dealt, gold_earned, was_mvp)
-			  VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING id, kda_ratio`
-	return r.db.QueryRowxContext(ctx, query,
+	query := `INSERT INTO game_player_stats (game_id, player_id, team_id, kills, deaths, assists, hero_name, damage_dealt, gold_earned, was_mvp, is_unregistered_sub)
+			  VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) RETURNING id, kda_ratio`
+	return conn(ctx, r.db).QueryRowxContext(ctx, query,
 		s.GameID,
 		s.PlayerID,
 		s.TeamID,
@@ -44,14 +47,50 @@ func (r *gamePlayerStatRepo) Create(ctx context.Context, s *models.GamePlayerSta
 		s.DamageDealt,
 		s.GoldEarned,
 		s.WasMVP,
+		s.IsUnregisteredSub,
 	).Scan(&s.ID, &s.KDARatio)
 }
 
+// CreateMany inserts stats with a single statement. The generated ids are not
+// read back.
+func (r *gamePlayerStatRepo) CreateMany(ctx context.Context, stats []*models.GamePlayerStat) error {
+	n := len(stats)
+	gameIDs, playerIDs, teamIDs := make([]int64, n), make([]int64, n), make([]*int64, n)
+	kills, deaths, assists := make([]int, n), make([]int, n), make([]int, n)
+	heroNames := make([]*string, n)
+	damage, gold := make([]int, n), make([]int, n)
+	mvps, subs := make([]bool, n), make([]bool, n)
+	for i, s := range stats {
+		gameIDs[i], playerIDs[i], teamIDs[i] = s.GameID, s.PlayerID, s.TeamID
+		kills[i], deaths[i], assists[i] = s.Kills, s.Deaths, s.Assists
+		heroNames[i] = s.HeroName
+		damage[i], gold[i] = s.DamageDealt, s.GoldEarned
+		mvps[i], subs[i] = s.WasMVP, s.IsUnregisteredSub
+	}
+	query := `INSERT INTO game_player_stats (game_id, player_id, team_id, kills, deaths, assists, hero_name, damage_dealt, gold_earned, was_mvp, is_unregistered_sub)
+			  SELECT * FROM unnest($1::bigint[], $2::int[], $3::int[], $4::int[], $5::int[], $6::int[], $7::varchar[], $8::int[], $9::int[], $10::boolean[], $11::boolean[])`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, gameIDs, playerIDs, teamIDs, kills, deaths, assists, heroNames, damage, gold, mvps, subs)
+	return err
+}
+
 func (r *gamePlayerStatRepo) GetByID(ctx context.Context, id int64) (*models.GamePlayerStat, error) {
 	var s models.GamePlayerStat
-	query := `SELECT id, game_id, player_id, team_id, kills, deaths, assists, hero_name, damage_dealt, gold_earned, kda_ratio, was_mvp
+	query := `SELECT id, game_id, player_id, team_id, kills, deaths, assists, hero_name, damage_dealt, gold_earned, kda_ratio, was_mvp, is_unregistered_sub
 			  FROM game_player_stats WHERE id=$1`
-	if err := r.db.GetContext(ctx, &s, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &s, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrGamePlayerStatNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *gamePlayerStatRepo) GetByGamePlayer(ctx context.Context, gameID, playerID int64) (*models.GamePlayerStat, error) {
+	var s models.GamePlayerStat
+	query := `SELECT id, game_id, player_id, team_id, kills, deaths, assists, hero_name, damage_dealt, gold_earned, kda_ratio, was_mvp, is_unregistered_sub
+			  FROM game_player_stats WHERE game_id=$1 AND player_id=$2`
+	if err := conn(ctx, r.db).GetContext(ctx, &s, query, gameID, playerID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrGamePlayerStatNotFound
 		}
@@ -81,30 +120,34 @@ func (r *gamePlayerStatRepo) List(ctx context.Context, filter models.GamePlayerS
 		args = append(args, *filter.WasMVP)
 		conds.WriteString(` AND was_mvp = $` + strconv.Itoa(len(args)))
 	}
+	if filter.UnregisteredSub != nil {
+		args = append(args, *filter.UnregisteredSub)
+		conds.WriteString(` AND is_unregistered_sub = $` + strconv.Itoa(len(args)))
+	}
 
 	countQuery := `SELECT count(*) ` + base + conds.String()
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
 	args = append(args, filter.Limit, filter.Offset)
 	limitIdx := strconv.Itoa(len(args) - 1)
 	offsetIdx := strconv.Itoa(len(args))
-	listQuery := `SELECT id, game_id, player_id, team_id, kills, deaths, assists, hero_name, damage_dealt, gold_earned, kda_ratio, was_mvp ` + base + conds.String() +
+	listQuery := `SELECT id, game_id, player_id, team_id, kills, deaths, assists, hero_name, damage_dealt, gold_earned, kda_ratio, was_mvp, is_unregistered_sub ` + base + conds.String() +
 		` ORDER BY game_id DESC, id DESC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	rows := []models.GamePlayerStat{}
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
 }
 
 func (r *gamePlayerStatRepo) Update(ctx context.Context, s *models.GamePlayerStat) error {
-	query := `UPDATE game_player_stats SET game_id=$1, player_id=$2, team_id=$3, kills=$4, deaths=$5, assists=$6, hero_name=$7, damage_dealt=$8, gold_earned=$9, was_mvp=$10
-			  WHERE id=$11 RETURNING kda_ratio`
-	if err := r.db.QueryRowxContext(ctx, query,
+	query := `UPDATE game_player_stats SET game_id=$1, player_id=$2, team_id=$3, kills=$4, deaths=$5, assists=$6, hero_name=$7, damage_dealt=$8, gold_earned=$9, was_mvp=$10, is_unregistered_sub=$11
+			  WHERE id=$12 RETURNING kda_ratio`
+	if err := conn(ctx, r.db).QueryRowxContext(ctx, query,
 		s.GameID,
 		s.PlayerID,
 		s.TeamID,
@@ -115,6 +158,7 @@ func (r *gamePlayerStatRepo) Update(ctx context.Context, s *models.GamePlayerSta
 		s.DamageDealt,
 		s.GoldEarned,
 		s.WasMVP,
+		s.IsUnregisteredSub,
 		s.ID,
 	).Scan(&s.KDARatio); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -126,7 +170,7 @@ func (r *gamePlayerStatRepo) Update(ctx context.Context, s *models.GamePlayerSta
 }
 
 func (r *gamePlayerStatRepo) Delete(ctx context.Context, id int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM game_player_stats WHERE id=$1`, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM game_player_stats WHERE id=$1`, id)
 	if err != nil {
 		return err
 	}
@@ -135,3 +179,14 @@ func (r *gamePlayerStatRepo) Delete(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+// CountByGameTeam counts the stat lines of a team in a game, ignoring the row
+// with excludeID.
+func (r *gamePlayerStatRepo) CountByGameTeam(ctx context.Context, gameID, teamID, excludeID int64) (int, error) {
+	query := `SELECT count(*) FROM game_player_stats WHERE game_id=$1 AND team_id=$2 AND id<>$3`
+	var total int
+	if err := conn(ctx, r.db).GetContext(ctx, &total, query, gameID, teamID, excludeID); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"db_course_project/internal/models"
+)
+
+type ImportErrorRepository interface {
+	Log(ctx context.Context, source string, rowData json.RawMessage, code, field, message string, row, line int) error
+	GetByID(ctx context.Context, id int64) (*models.ImportError, error)
+	List(ctx context.Context, filter models.ImportErrorFilter) ([]models.ImportError, int, error)
+	UpdateRowData(ctx context.Context, id int64, rowData json.RawMessage) (*models.ImportError, error)
+	MarkRetried(ctx context.Context, id int64, code, field, message string) error
+	Delete(ctx context.Context, id int64) error
+	Purge(ctx context.Context, before time.Time, source string) (int64, error)
+}
+
+func NewImportErrorRepository(db *sqlx.DB) ImportErrorRepository {
+	return &importErrorRepo{db: db}
+}
+
+var ErrImportErrorNotFound = errors.New("import error not found")
+
+const importErrorColumns = `id, source, row_data, error_message, error_code, field, row_index, line_number, occurred_at, retry_count, retried_at`
+
+type importErrorRepo struct {
+	db *sqlx.DB
+}
+
+// Log records a failed import row. An empty field and a zero line are stored
+// as NULL.
+func (r *importErrorRepo) Log(ctx context.Context, source string, rowData json.RawMessage, code, field, message string, row, line int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO batch_import_errors (source, row_data, error_message, error_code, field, row_index, line_number)
+		 VALUES ($1, $2::jsonb, $3, $4, NULLIF($5, ''), $6, NULLIF($7, 0))`,
+		source, string(rowData), message, code, field, row, line)
+	return err
+}
+
+func (r *importErrorRepo) GetByID(ctx context.Context, id int64) (*models.ImportError, error) {
+	var e models.ImportError
+	if err := conn(ctx, r.db).GetContext(ctx, &e, `SELECT `+importErrorColumns+` FROM batch_import_errors WHERE id=$1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrImportErrorNotFound
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *importErrorRepo) List(ctx context.Context, filter models.ImportErrorFilter) ([]models.ImportError, int, error) {
+	base := `FROM batch_import_errors WHERE 1=1`
+	args := []any{}
+	conds := strings.Builder{}
+
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		conds.WriteString(` AND source = $` + strconv.Itoa(len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conds.WriteString(` AND occurred_at >= $` + strconv.Itoa(len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conds.WriteString(` AND occurred_at < $` + strconv.Itoa(len(args)))
+	}
+
+	countQuery := `SELECT count(*) ` + base + conds.String()
+	var total int
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	limitIdx := strconv.Itoa(len(args) - 1)
+	offsetIdx := strconv.Itoa(len(args))
+	listQuery := `SELECT ` + importErrorColumns + ` ` + base + conds.String() +
+		` ORDER BY occurred_at DESC, id DESC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
+
+	rows := []models.ImportError{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *importErrorRepo) UpdateRowData(ctx context.Context, id int64, rowData json.RawMessage) (*models.ImportError, error) {
+	var e models.ImportError
+	query := `UPDATE batch_import_errors SET row_data=$2::jsonb WHERE id=$1 RETURNING ` + importErrorColumns
+	if err := conn(ctx, r.db).GetContext(ctx, &e, query, id, string(rowData)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrImportErrorNotFound
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+// MarkRetried records a failed retry with the error it failed with.
+func (r *importErrorRepo) MarkRetried(ctx context.Context, id int64, code, field, message string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE batch_import_errors
+		 SET error_code=$2, field=NULLIF($3, ''), error_message=$4, retry_count=retry_count+1, retried_at=CURRENT_TIMESTAMP
+		 WHERE id=$1`,
+		id, code, field, message)
+	return err
+}
+
+func (r *importErrorRepo) Delete(ctx context.Context, id int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM batch_import_errors WHERE id=$1`, id)
+	return err
+}
+
+// Purge deletes the errors that occurred before the given time, of one source
+// when source is set.
+func (r *importErrorRepo) Purge(ctx context.Context, before time.Time, source string) (int64, error) {
+	query := `DELETE FROM batch_import_errors WHERE occurred_at < $1`
+	args := []any{before}
+	if source != "" {
+		args = append(args, source)
+		query += ` AND source = $2`
+	}
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"db_course_project/internal/models"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *models.ImportJob) error
+	GetByID(ctx context.Context, id int64) (*models.ImportJob, error)
+	List(ctx context.Context, filter models.ImportJobFilter) ([]models.ImportJob, int, error)
+	Claim(ctx context.Context) (*models.ImportJob, error)
+	Progress(ctx context.Context, id int64, p models.ImportJobProgress) error
+	Finish(ctx context.Context, id int64, status string, lastError *string) error
+	Cancel(ctx context.Context, id int64) (*models.ImportJob, error)
+	Requeue(ctx context.Context) (int64, error)
+}
+
+func NewImportJobRepository(db *sqlx.DB) ImportJobRepository {
+	return &importJobRepo{db: db}
+}
+
+var (
+	ErrImportJobNotFound   = errors.New("import job not found")
+	ErrImportJobNotRunning = errors.New("import job is no longer running")
+	ErrImportJobFinished   = errors.New("import job has already finished")
+)
+
+// importJobMaxErrors caps the row errors kept on a job; the failed counter
+// keeps counting past it.
+const importJobMaxErrors = 1000
+
+const importJobColumns = `id, entity, source, status, is_atomic, is_dry_run, is_restore, on_conflict, created_by, request_id, total, processed, inserted, updated, skipped, failed, errors, last_error, created_at, started_at, finished_at`
+
+type importJobRepo struct {
+	db *sqlx.DB
+}
+
+func (r *importJobRepo) Create(ctx context.Context, job *models.ImportJob) error {
+	query := `INSERT INTO import_jobs (entity, source, is_atomic, is_dry_run, is_restore, on_conflict, created_by, request_id, payload, total)
+			  VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+			  RETURNING ` + importJobColumns
+	return conn(ctx, r.db).GetContext(ctx, job, query,
+		job.Entity,
+		job.Source,
+		job.IsAtomic,
+		job.IsDryRun,
+		job.IsRestore,
+		job.OnConflict,
+		job.CreatedBy,
+		job.RequestID,
+		job.Payload,
+		job.Total,
+	)
+}
+
+func (r *importJobRepo) GetByID(ctx context.Context, id int64) (*models.ImportJob, error) {
+	var job models.ImportJob
+	if err := conn(ctx, r.db).GetContext(ctx, &job, `SELECT `+importJobColumns+` FROM import_jobs WHERE id=$1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrImportJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *importJobRepo) List(ctx context.Context, filter models.ImportJobFilter) ([]models.ImportJob, int, error) {
+	base := `FROM import_jobs WHERE 1=1`
+	args := []any{}
+	conds := strings.Builder{}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conds.WriteString(` AND status = LOWER($` + strconv.Itoa(len(args)) + `)`)
+	}
+	if filter.Entity != "" {
+		args = append(args, filter.Entity)
+		conds.WriteString(` AND entity = LOWER($` + strconv.Itoa(len(args)) + `)`)
+	}
+
+	countQuery := `SELECT count(*) ` + base + conds.String()
+	var total int
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	limitIdx := strconv.Itoa(len(args) - 1)
+	offsetIdx := strconv.Itoa(len(args))
+	listQuery := `SELECT ` + importJobColumns + ` ` + base + conds.String() +
+		` ORDER BY id DESC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
+
+	rows := []models.ImportJob{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+// Claim marks the oldest queued job as running and returns it with its
+// payload, or nil when the queue is empty. SKIP LOCKED lets several workers
+// claim jobs concurrently.
+func (r *importJobRepo) Claim(ctx context.Context) (*models.ImportJob, error) {
+	var job models.ImportJob
+	query := `UPDATE import_jobs SET status='running', started_at=COALESCE(started_at, CURRENT_TIMESTAMP)
+			  WHERE id = (
+				  SELECT id FROM import_jobs
+				  WHERE status='queued'
+				  ORDER BY id
+				  LIMIT 1
+				  FOR UPDATE SKIP LOCKED
+			  )
+			  RETURNING payload, ` + importJobColumns
+	if err := conn(ctx, r.db).GetContext(ctx, &job, query); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Progress adds the outcome of a chunk to a running job. It fails with
+// ErrImportJobNotRunning once the job was cancelled.
+func (r *importJobRepo) Progress(ctx context.Context, id int64, p models.ImportJobProgress) error {
+	errs := p.Errors
+	if len(errs) == 0 || string(errs) == "null" {
+		errs = []byte(`[]`)
+	}
+	query := `UPDATE import_jobs SET
+				processed=$2,
+				inserted=inserted+$3,
+				updated=updated+$4,
+				skipped=skipped+$5,
+				failed=failed+$6,
+				errors=(
+					SELECT COALESCE(jsonb_agg(e.value ORDER BY e.ord), '[]'::jsonb)
+					FROM (
+						SELECT value, ord FROM jsonb_array_elements(errors || $7::jsonb) WITH ORDINALITY AS x(value, ord)
+						ORDER BY ord
+						LIMIT $8
+					) e
+				)
+			  WHERE id=$1 AND status='running'`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, id, p.Processed, p.Inserted, p.Updated, p.Skipped, p.Failed, string(errs), importJobMaxErrors)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrImportJobNotRunning
+	}
+	return nil
+}
+
+func (r *importJobRepo) Finish(ctx context.Context, id int64, status string, lastError *string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE import_jobs SET status=$2, last_error=$3, finished_at=CURRENT_TIMESTAMP WHERE id=$1 AND status='running'`,
+		id, status, lastError)
+	return err
+}
+
+func (r *importJobRepo) Cancel(ctx context.Context, id int64) (*models.ImportJob, error) {
+	var job models.ImportJob
+	query := `UPDATE import_jobs SET status='cancelled', finished_at=CURRENT_TIMESTAMP
+			  WHERE id=$1 AND status IN ('queued', 'running')
+			  RETURNING ` + importJobColumns
+	err := conn(ctx, r.db).GetContext(ctx, &job, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrImportJobFinished
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Requeue puts jobs left running by a stopped server back into the queue.
+func (r *importJobRepo) Requeue(ctx context.Context) (int64, error) {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE import_jobs SET status='queued' WHERE status='running'`)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -15,9 +15,11 @@ import (
 type MatchGameRepository interface {
 	Create(ctx context.Context, g *models.MatchGame) error
 	GetByID(ctx context.Context, id int64) (*models.MatchGame, error)
+	GetByMatchNumber(ctx context.Context, matchID int64, gameNumber int) (*models.MatchGame, error)
 	List(ctx context.Context, filter models.MatchGameFilter) ([]models.MatchGame, int, error)
 	Update(ctx context.Context, g *models.MatchGame) error
 	Delete(ctx context.Context, id int64) error
+	ListByMatch(ctx context.Context, matchID int64) ([]models.MatchGame, error)
 }
 
 func NewMatchGameRepository(db *sqlx.DB) MatchGameRepository {
@@ -33,7 +35,7 @@ type matchGameRepo struct {
 func (r *matchGameRepo) Create(ctx context.Context, g *models.MatchGame) error {
 	query := `INSERT INTO match_games (match_id, map_name, game_number, duration_seconds, winner_team_id, score_team1, score_team2, started_at, had_technical_pause, pick_ban_phase)
 			  VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING id`
-	return r.db.QueryRowxContext(ctx, query,
+	return conn(ctx, r.db).QueryRowxContext(ctx, query,
 		g.MatchID,
 		g.MapName,
 		g.GameNumber,
@@ -51,7 +53,20 @@ func (r *matchGameRepo) GetByID(ctx context.Context, id int64) (*models.MatchGam
 	var g models.MatchGame
 	query := `SELECT id, match_id, map_name, game_number, duration_seconds, winner_team_id, score_team1, score_team2, started_at, had_technical_pause, pick_ban_phase
 			  FROM match_games WHERE id=$1`
-	if err := r.db.GetContext(ctx, &g, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &g, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMatchGameNotFound
+		}
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *matchGameRepo) GetByMatchNumber(ctx context.Context, matchID int64, gameNumber int) (*models.MatchGame, error) {
+	var g models.MatchGame
+	query := `SELECT id, match_id, map_name, game_number, duration_seconds, winner_team_id, score_team1, score_team2, started_at, had_technical_pause, pick_ban_phase
+			  FROM match_games WHERE match_id=$1 AND game_number=$2`
+	if err := conn(ctx, r.db).GetContext(ctx, &g, query, matchID, gameNumber); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrMatchGameNotFound
 		}
@@ -76,7 +91,7 @@ func (r *matchGameRepo) List(ctx context.Context, filter models.MatchGameFilter)
 
 	countQuery := `SELECT count(*) ` + base + conds.String()
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
@@ -87,7 +102,7 @@ func (r *matchGameRepo) List(ctx context.Context, filter models.MatchGameFilter)
 		` ORDER BY match_id DESC, game_number ASC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	rows := []models.MatchGame{}
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
@@ -96,7 +111,7 @@ func (r *matchGameRepo) List(ctx context.Context, filter models.MatchGameFilter)
 func (r *matchGameRepo) Update(ctx context.Context, g *models.MatchGame) error {
 	query := `UPDATE match_games SET match_id=$1, map_name=$2, game_number=$3, duration_seconds=$4, winner_team_id=$5, score_team1=$6, score_team2=$7, started_at=$8, had_technical_pause=$9, pick_ban_phase=$10
 			  WHERE id=$11`
-	res, err := r.db.ExecContext(ctx, query,
+	res, err := conn(ctx, r.db).ExecContext(ctx, query,
 		g.MatchID,
 		g.MapName,
 		g.GameNumber,
@@ -119,7 +134,7 @@ func (r *matchGameRepo) Update(ctx context.Context, g *models.MatchGame) error {
 }
 
 func (r *matchGameRepo) Delete(ctx context.Context, id int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM match_games WHERE id=$1`, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM match_games WHERE id=$1`, id)
 	if err != nil {
 		return err
 	}
@@ -128,3 +143,13 @@ func (r *matchGameRepo) Delete(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+func (r *matchGameRepo) ListByMatch(ctx context.Context, matchID int64) ([]models.MatchGame, error) {
+	query := `SELECT id, match_id, map_name, game_number, duration_seconds, winner_team_id, score_team1, score_team2, started_at, had_technical_pause, pick_ban_phase
+			  FROM match_games WHERE match_id=$1 ORDER BY game_number ASC`
+	rows := []models.MatchGame{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, matchID); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -18,22 +18,31 @@ type MatchRepository interface {
 	List(ctx context.Context, filter models.MatchFilter) ([]models.Match, int, error)
 	Update(ctx context.Context, m *models.Match) error
 	Delete(ctx context.Context, id int64) error
+	ListByTournament(ctx context.Context, tournamentID int64) ([]models.Match, error)
+	ReplaceBracket(ctx context.Context, tournamentID int64, matches []models.Match, links []models.BracketLink) error
+	AppendBracket(ctx context.Context, matches []models.Match, links []models.BracketLink) error
+	UpdateWithAdvancement(ctx context.Context, m *models.Match, slots []models.MatchSlotAssignment) error
+	CountUndecided(ctx context.Context, tournamentID int64) (int, error)
 }
 
 func NewMatchRepository(db *sqlx.DB) MatchRepository {
 	return &matchRepo{db: db}
 }
 
-var ErrMatchNotFound = errors.New("match not found")
+var (
+	ErrMatchNotFound      = errors.New("match not found")
+	ErrNextMatchHasResult = errors.New("a later bracket match fed by this result has already been played")
+)
+
+const insertMatchQuery = `INSERT INTO matches (tournament_id, team1_id, team2_id, start_time, format, stage, winner_team_id, is_forfeit, match_notes, bracket_section, bracket_round, bracket_position)
+			  VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) RETURNING id`
 
 type matchRepo struct {
 	db *sqlx.DB
 }
 
 func (r *matchRepo) Create(ctx context.Context, m *models.Match) error {
-	query := `INSERT INTO matches (tournament_id, team1_id, team2_id, start_time, format, stage, winner_team_id, is_forfeit, match_notes)
-			  VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING id`
-	return r.db.QueryRowxContext(ctx, query,
+	return conn(ctx, r.db).QueryRowxContext(ctx, insertMatchQuery,
 		m.TournamentID,
 		m.Team1ID,
 		m.Team2ID,
@@ -43,14 +52,18 @@ func (r *matchRepo) Create(ctx context.Context, m *models.Match) error {
 		m.WinnerTeamID,
 		m.IsForfeit,
 		m.MatchNotes,
+		m.BracketSection,
+		m.BracketRound,
+		m.BracketPosition,
 	).Scan(&m.ID)
 }
 
 func (r *matchRepo) GetByID(ctx context.Context, id int64) (*models.Match, error) {
 	var m models.Match
-	query := `SELECT id, tournament_id, team1_id, team2_id, start_time, format, stage, winner_team_id, is_forfeit, match_notes
+	query := `SELECT id, tournament_id, team1_id, team2_id, start_time, format, stage, winner_team_id, is_forfeit, match_notes,
+				 bracket_section, bracket_round, bracket_position, next_match_id, next_match_slot, loser_next_match_id, loser_next_match_slot
 			  FROM matches WHERE id=$1`
-	if err := r.db.GetContext(ctx, &m, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &m, query, id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrMatchNotFound
 		}
@@ -91,27 +104,70 @@ func (r *matchRepo) List(ctx context.Context, filter models.MatchFilter) ([]mode
 
 	countQuery := `SELECT count(*) ` + base + conds.String()
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
 	args = append(args, filter.Limit, filter.Offset)
 	limitIdx := strconv.Itoa(len(args) - 1)
 	offsetIdx := strconv.Itoa(len(args))
-	listQuery := `SELECT id, tournament_id, team1_id, team2_id, start_time, format, stage, winner_team_id, is_forfeit, match_notes ` + base + conds.String() +
+	listQuery := `SELECT id, tournament_id, team1_id, team2_id, start_time, format, stage, winner_team_id, is_forfeit, match_notes,
+				 bracket_section, bracket_round, bracket_position, next_match_id, next_match_slot, loser_next_match_id, loser_next_match_slot ` + base + conds.String() +
 		` ORDER BY start_time DESC, id DESC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	rows := []models.Match{}
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
 }
 
 func (r *matchRepo) Update(ctx context.Context, m *models.Match) error {
+	return updateMatch(ctx, conn(ctx, r.db), m)
+}
+
+// UpdateWithAdvancement saves the match and moves teams into the slots of the
+// bracket matches it feeds, all in one transaction. A slot is only rewritten
+// while the receiving match is unplayed: no winner, no forfeit and no games.
+func (r *matchRepo) UpdateWithAdvancement(ctx context.Context, m *models.Match, slots []models.MatchSlotAssignment) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateMatch(ctx, tx, m); err != nil {
+		return err
+	}
+	for _, slot := range slots {
+		var played bool
+		query := `SELECT m.winner_team_id IS NOT NULL OR COALESCE(m.is_forfeit, FALSE)
+				     OR EXISTS (SELECT 1 FROM match_games g WHERE g.match_id = m.id)
+				  FROM matches m WHERE m.id=$1 FOR UPDATE`
+		if err := tx.GetContext(ctx, &played, query, slot.MatchID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return err
+		}
+		if played {
+			return ErrNextMatchHasResult
+		}
+		query = `UPDATE matches SET team1_id=$1 WHERE id=$2`
+		if slot.Slot == 2 {
+			query = `UPDATE matches SET team2_id=$1 WHERE id=$2`
+		}
+		if _, err := tx.ExecContext(ctx, query, slot.TeamID, slot.MatchID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func updateMatch(ctx context.Context, db sqlx.ExtContext, m *models.Match) error {
 	query := `UPDATE matches SET tournament_id=$1, team1_id=$2, team2_id=$3, start_time=$4, format=$5, stage=$6, winner_team_id=$7, is_forfeit=$8, match_notes=$9
 			  WHERE id=$10`
-	res, err := r.db.ExecContext(ctx, query,
+	res, err := db.ExecContext(ctx, query,
 		m.TournamentID,
 		m.Team1ID,
 		m.Team2ID,
@@ -133,7 +189,7 @@ func (r *matchRepo) Update(ctx context.Context, m *models.Match) error {
 }
 
 func (r *matchRepo) Delete(ctx context.Context, id int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM matches WHERE id=$1`, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM matches WHERE id=$1`, id)
 	if err != nil {
 		return err
 	}
@@ -142,3 +198,96 @@ func (r *matchRepo) Delete(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+func (r *matchRepo) ListByTournament(ctx context.Context, tournamentID int64) ([]models.Match, error) {
+	query := `SELECT id, tournament_id, team1_id, team2_id, start_time, format, stage, winner_team_id, is_forfeit, match_notes,
+				 bracket_section, bracket_round, bracket_position, next_match_id, next_match_slot, loser_next_match_id, loser_next_match_slot
+			  FROM matches WHERE tournament_id=$1
+			  ORDER BY start_time ASC, bracket_section ASC, bracket_round ASC, bracket_position ASC, id ASC`
+	rows := []models.Match{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, tournamentID); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CountUndecided counts matches of a tournament that have neither a winner
+// nor a completed drawn series.
+func (r *matchRepo) CountUndecided(ctx context.Context, tournamentID int64) (int, error) {
+	query := `SELECT count(*) FROM v_match_results
+			  WHERE tournament_id=$1
+				AND winner_team_id IS NULL
+				AND NOT COALESCE(series_score_team1 = series_score_team2
+					 AND series_score_team1 + series_score_team2 >= CASE WHEN format ~ '^bo[0-9]+$' THEN substring(format FROM 3)::INT END, FALSE)`
+	var total int
+	if err := conn(ctx, r.db).GetContext(ctx, &total, query, tournamentID); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *matchRepo) ReplaceBracket(ctx context.Context, tournamentID int64, matches []models.Match, links []models.BracketLink) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM matches WHERE tournament_id=$1 AND bracket_section IS NOT NULL`, tournamentID); err != nil {
+		return err
+	}
+	if err := insertBracket(ctx, tx.Tx, matches, links); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *matchRepo) AppendBracket(ctx context.Context, matches []models.Match, links []models.BracketLink) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertBracket(ctx, tx.Tx, matches, links); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertBracket(ctx context.Context, tx *sqlx.Tx, matches []models.Match, links []models.BracketLink) error {
+	for i := range matches {
+		m := &matches[i]
+		if err := tx.QueryRowxContext(ctx, insertMatchQuery,
+			m.TournamentID,
+			m.Team1ID,
+			m.Team2ID,
+			m.StartTime,
+			m.Format,
+			m.Stage,
+			m.WinnerTeamID,
+			m.IsForfeit,
+			m.MatchNotes,
+			m.BracketSection,
+			m.BracketRound,
+			m.BracketPosition,
+		).Scan(&m.ID); err != nil {
+			return err
+		}
+	}
+	for _, l := range links {
+		from, to := &matches[l.From], &matches[l.To]
+		slot := l.Slot
+		query := `UPDATE matches SET next_match_id=$1, next_match_slot=$2 WHERE id=$3`
+		if l.Loser {
+			query = `UPDATE matches SET loser_next_match_id=$1, loser_next_match_slot=$2 WHERE id=$3`
+			from.LoserNextMatchID, from.LoserNextMatchSlot = &to.ID, &slot
+		} else {
+			from.NextMatchID, from.NextMatchSlot = &to.ID, &slot
+		}
+		if _, err := tx.ExecContext(ctx, query, to.ID, slot, from.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
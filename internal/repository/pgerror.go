@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	ViolationNotNull    = "not_null_violation"
+	ViolationUnique     = "unique_violation"
+	ViolationForeignKey = "fk_violation"
+	ViolationCheck      = "check_violation"
+)
+
+// Violation is a constraint violation raised by Postgres.
+type Violation struct {
+	Code       string
+	Table      string
+	Constraint string
+	// Field lists the offending columns, comma separated, when Postgres names
+	// them.
+	Field string
+	// Referenced is the other table of a foreign key violation.
+	Referenced string
+	// InUse is set when a foreign key violation was raised because the row is
+	// still referenced from Referenced, rather than because it references a
+	// missing row.
+	InUse bool
+}
+
+var violationCodes = map[string]string{
+	"23502": ViolationNotNull,
+	"23505": ViolationUnique,
+	"23503": ViolationForeignKey,
+	"23514": ViolationCheck,
+}
+
+// violationKey matches the column list of details such as
+// "Key (game_id, player_id)=(1, 2) already exists."
+var violationKey = regexp.MustCompile(`^Key \(([^)]*)\)=`)
+
+// violationTable matches the other table of foreign key details such as
+// `Key (team_id)=(7) is not present in table "teams".` or
+// `Key (id)=(7) is still referenced from table "matches".`
+var violationTable = regexp.MustCompile(`(is not present in|is still referenced from) table "([^"]+)"`)
+
+// ConstraintViolation reports whether err is a constraint violation and
+// describes it.
+func ConstraintViolation(err error) (*Violation, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil, false
+	}
+	code, ok := violationCodes[pgErr.Code]
+	if !ok {
+		return nil, false
+	}
+	v := &Violation{Code: code, Table: pgErr.TableName, Constraint: pgErr.ConstraintName, Field: pgErr.ColumnName}
+	if m := violationKey.FindStringSubmatch(pgErr.Detail); m != nil {
+		v.Field = m[1]
+	}
+	if m := violationTable.FindStringSubmatch(pgErr.Detail); m != nil {
+		v.Referenced, v.InUse = m[2], m[1] == "is still referenced from"
+	}
+	return v, true
+}
+
+// Message describes the violation without the statement or the values that
+// caused it.
+func (v *Violation) Message() string {
+	switch v.Code {
+	case ViolationNotNull:
+		return fmt.Sprintf("%s is required", v.Field)
+	case ViolationUnique:
+		if v.Field == "" {
+			return fmt.Sprintf("%s already exists (%s)", v.Table, v.Constraint)
+		}
+		return fmt.Sprintf("%s with this %s already exists", v.Table, v.Field)
+	case ViolationForeignKey:
+		if v.InUse {
+			return fmt.Sprintf("%s is still referenced from %s", v.Table, v.Referenced)
+		}
+		if v.Referenced == "" {
+			return fmt.Sprintf("%s references a missing row", v.Field)
+		}
+		return fmt.Sprintf("%s references a missing row in %s", v.Field, v.Referenced)
+	default:
+		return fmt.Sprintf("%s violates check constraint %s", v.Table, v.Constraint)
+	}
+}
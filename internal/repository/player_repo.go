@@ -15,6 +15,8 @@ import (
 type PlayerRepository interface {
 	Create(ctx context.Context, p *models.Player) error
 	GetByID(ctx context.Context, id int64) (*models.Player, error)
+	GetByNickname(ctx context.Context, nickname string) (*models.Player, error)
+	GetBySteamID(ctx context.Context, steamID string) (*models.Player, error)
 	List(ctx context.Context, filter models.PlayerFilter) ([]models.Player, int, error)
 	Update(ctx context.Context, p *models.Player) error
 	Delete(ctx context.Context, id int64) error
@@ -34,7 +36,7 @@ func (r *playerRepo) Create(ctx context.Context, p *models.Player) error {
 	query := `INSERT INTO players (nickname, real_name, country_code, birth_date, steam_id, avatar_url, mmr_rating, is_retired)
 			 VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
 			 RETURNING id, created_at`
-	return r.db.QueryRowxContext(ctx, query,
+	return conn(ctx, r.db).QueryRowxContext(ctx, query,
 		p.Nickname,
 		p.RealName,
 		p.CountryCode,
@@ -50,7 +52,28 @@ func (r *playerRepo) GetByID(ctx context.Context, id int64) (*models.Player, err
 	var p models.Player
 	query := `SELECT id, nickname, real_name, country_code, birth_date, steam_id, avatar_url, mmr_rating, is_retired, created_at
 			  FROM players WHERE id=$1`
-	if err := r.db.GetContext(ctx, &p, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &p, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlayerNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *playerRepo) GetByNickname(ctx context.Context, nickname string) (*models.Player, error) {
+	return r.getBy(ctx, "nickname", nickname)
+}
+
+func (r *playerRepo) GetBySteamID(ctx context.Context, steamID string) (*models.Player, error) {
+	return r.getBy(ctx, "steam_id", steamID)
+}
+
+func (r *playerRepo) getBy(ctx context.Context, column, value string) (*models.Player, error) {
+	var p models.Player
+	query := `SELECT id, nickname, real_name, country_code, birth_date, steam_id, avatar_url, mmr_rating, is_retired, created_at
+			  FROM players WHERE ` + column + `=$1`
+	if err := conn(ctx, r.db).GetContext(ctx, &p, query, value); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrPlayerNotFound
 		}
@@ -88,7 +111,7 @@ func (r *playerRepo) List(ctx context.Context, filter models.PlayerFilter) ([]mo
 
 	countQuery := `SELECT count(*) ` + base + conds.String()
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
@@ -99,7 +122,7 @@ func (r *playerRepo) List(ctx context.Context, filter models.PlayerFilter) ([]mo
 				 ORDER BY nickname ASC, id ASC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	rows := []models.Player{}
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
@@ -108,7 +131,7 @@ func (r *playerRepo) List(ctx context.Context, filter models.PlayerFilter) ([]mo
 func (r *playerRepo) Update(ctx context.Context, p *models.Player) error {
 	query := `UPDATE players SET nickname=$1, real_name=$2, country_code=$3, birth_date=$4, steam_id=$5, avatar_url=$6, mmr_rating=$7, is_retired=$8
 			  WHERE id=$9 RETURNING created_at`
-	if err := r.db.QueryRowxContext(ctx, query,
+	if err := conn(ctx, r.db).QueryRowxContext(ctx, query,
 		p.Nickname,
 		p.RealName,
 		p.CountryCode,
@@ -128,7 +151,7 @@ func (r *playerRepo) Update(ctx context.Context, p *models.Player) error {
 }
 
 func (r *playerRepo) Delete(ctx context.Context, id int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM players WHERE id=$1`, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM players WHERE id=$1`, id)
 	if err != nil {
 		return err
 	}
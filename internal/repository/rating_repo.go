@@ -0,0 +1,478 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"db_course_project/internal/models"
+)
+
+type RatingRepository interface {
+	RatedDisciplines(ctx context.Context) ([]int64, error)
+	ListRatedMatches(ctx context.Context, disciplineID int64) ([]models.RatedMatch, error)
+	GetRatedMatch(ctx context.Context, disciplineID, matchID int64) (*models.RatedMatch, error)
+	ListLineups(ctx context.Context, disciplineID int64) ([]models.MatchLineup, error)
+	ListMatchLineups(ctx context.Context, matchID int64) ([]models.MatchLineup, error)
+	LockPool(ctx context.Context, disciplineID int64) error
+	IsRated(ctx context.Context, disciplineID, matchID int64) (bool, error)
+	LastRated(ctx context.Context, disciplineID int64) (playedAt time.Time, matchID int64, err error)
+	ListPoolEntries(ctx context.Context, disciplineID int64, teamIDs, playerIDs []int64) ([]models.TeamRating, []models.PlayerRating, error)
+	ReplacePool(ctx context.Context, disciplineID int64, teams []models.TeamRating, players []models.PlayerRating, history []models.RatingChange, recompute bool) error
+	AppendMatch(ctx context.Context, disciplineID int64, teams []models.TeamRating, players []models.PlayerRating, history []models.RatingChange) error
+	ListTeamRatings(ctx context.Context, filter models.RatingFilter) ([]models.TeamRating, int, error)
+	ListPlayerRatings(ctx context.Context, filter models.RatingFilter) ([]models.PlayerRating, int, error)
+	AddHistory(ctx context.Context, c *models.RatingChange) error
+	ListHistory(ctx context.Context, filter models.RatingHistoryFilter) ([]models.RatingHistoryPoint, error)
+}
+
+func NewRatingRepository(db *sqlx.DB) RatingRepository {
+	return &ratingRepo{db: db}
+}
+
+type ratingRepo struct {
+	db *sqlx.DB
+}
+
+// RatedDisciplines lists disciplines that have matches or an existing pool.
+func (r *ratingRepo) RatedDisciplines(ctx context.Context) ([]int64, error) {
+	query := `SELECT t.discipline_id FROM matches m JOIN tournaments t ON t.id = m.tournament_id
+			  UNION
+			  SELECT discipline_id FROM team_ratings
+			  ORDER BY 1`
+	ids := []int64{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &ids, query); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ratedMatches selects the decided matches of a discipline: matches with a
+// winner and completed drawn series. Forfeits are not rated.
+const ratedMatches = `SELECT r.match_id, r.start_time, r.team1_id, r.team2_id, r.winner_team_id
+			  FROM v_match_results r
+			  JOIN matches m ON m.id = r.match_id
+			  JOIN tournaments t ON t.id = r.tournament_id
+			  WHERE t.discipline_id = $1
+				AND r.team1_id IS NOT NULL AND r.team2_id IS NOT NULL
+				AND NOT m.is_forfeit
+				AND (r.winner_team_id IS NOT NULL
+					 OR COALESCE(r.series_score_team1 = r.series_score_team2
+						AND r.series_score_team1 + r.series_score_team2 >= CASE WHEN r.format ~ '^bo[0-9]+$' THEN substring(r.format FROM 3)::INT END, FALSE))`
+
+// ListRatedMatches returns the decided matches of a discipline in the order
+// they were played.
+func (r *ratingRepo) ListRatedMatches(ctx context.Context, disciplineID int64) ([]models.RatedMatch, error) {
+	query := ratedMatches + ` ORDER BY r.start_time ASC, r.match_id ASC`
+	rows := []models.RatedMatch{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, disciplineID); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GetRatedMatch returns the match when it is a decided match of the
+// discipline, and nil otherwise.
+func (r *ratingRepo) GetRatedMatch(ctx context.Context, disciplineID, matchID int64) (*models.RatedMatch, error) {
+	var m models.RatedMatch
+	if err := conn(ctx, r.db).GetContext(ctx, &m, ratedMatches+` AND r.match_id = $2`, disciplineID, matchID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ListLineups returns the players that have stat lines for a team in a match.
+func (r *ratingRepo) ListLineups(ctx context.Context, disciplineID int64) ([]models.MatchLineup, error) {
+	query := `SELECT DISTINCT g.match_id, s.team_id, s.player_id
+			  FROM game_player_stats s
+			  JOIN match_games g ON g.id = s.game_id
+			  JOIN matches m ON m.id = g.match_id
+			  JOIN tournaments t ON t.id = m.tournament_id
+			  WHERE t.discipline_id = $1 AND s.team_id IS NOT NULL
+			  ORDER BY g.match_id, s.team_id, s.player_id`
+	rows := []models.MatchLineup{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, disciplineID); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListMatchLineups returns the players that have stat lines for a team in one
+// match.
+func (r *ratingRepo) ListMatchLineups(ctx context.Context, matchID int64) ([]models.MatchLineup, error) {
+	query := `SELECT DISTINCT g.match_id, s.team_id, s.player_id
+			  FROM game_player_stats s
+			  JOIN match_games g ON g.id = s.game_id
+			  WHERE g.match_id = $1 AND s.team_id IS NOT NULL
+			  ORDER BY s.team_id, s.player_id`
+	rows := []models.MatchLineup{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, matchID); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// LockPool serializes changes to the pool of a discipline until the end of
+// the transaction.
+func (r *ratingRepo) LockPool(ctx context.Context, disciplineID int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('rating_pool'), $1)`, disciplineID)
+	return err
+}
+
+// IsRated reports whether the pool of a discipline holds the result of a
+// match.
+func (r *ratingRepo) IsRated(ctx context.Context, disciplineID, matchID int64) (bool, error) {
+	var rated bool
+	query := `SELECT EXISTS (SELECT 1 FROM rating_history WHERE match_id=$1 AND discipline_id=$2 AND cause=$3)`
+	err := conn(ctx, r.db).GetContext(ctx, &rated, query, matchID, disciplineID, models.RatingCauseMatch)
+	return rated, err
+}
+
+// LastRated returns the start time and id of the latest match in the pool of
+// a discipline, or zero values for an empty pool.
+func (r *ratingRepo) LastRated(ctx context.Context, disciplineID int64) (time.Time, int64, error) {
+	var last struct {
+		PlayedAt time.Time `db:"recorded_at"`
+		MatchID  int64     `db:"match_id"`
+	}
+	query := `SELECT recorded_at, match_id FROM rating_history
+			  WHERE discipline_id=$1 AND cause=$2
+			  ORDER BY recorded_at DESC, match_id DESC LIMIT 1`
+	if err := conn(ctx, r.db).GetContext(ctx, &last, query, disciplineID, models.RatingCauseMatch); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, 0, nil
+		}
+		return time.Time{}, 0, err
+	}
+	return last.PlayedAt, last.MatchID, nil
+}
+
+// ListPoolEntries returns the current pool entries of the given teams and
+// players.
+func (r *ratingRepo) ListPoolEntries(ctx context.Context, disciplineID int64, teamIDs, playerIDs []int64) ([]models.TeamRating, []models.PlayerRating, error) {
+	teams := []models.TeamRating{}
+	query := `SELECT team_id, discipline_id, rating, matches_played, last_match_id, last_played_at, updated_at
+			  FROM team_ratings WHERE discipline_id=$1 AND team_id = ANY($2)`
+	if err := conn(ctx, r.db).SelectContext(ctx, &teams, query, disciplineID, teamIDs); err != nil {
+		return nil, nil, err
+	}
+	players := []models.PlayerRating{}
+	query = `SELECT player_id, discipline_id, rating, matches_played, last_match_id, last_played_at, updated_at
+			 FROM player_ratings WHERE discipline_id=$1 AND player_id = ANY($2)`
+	if err := conn(ctx, r.db).SelectContext(ctx, &players, query, disciplineID, playerIDs); err != nil {
+		return nil, nil, err
+	}
+	return teams, players, nil
+}
+
+// ReplacePool stores a recomputed discipline pool and its match history and
+// mirrors it into teams.world_ranking and players.mmr_rating. A player rated
+// in several disciplines gets the rating of the pool they played in last.
+// With recompute set, every mirrored value that moves is logged as a
+// recompute entry.
+func (r *ratingRepo) ReplacePool(ctx context.Context, disciplineID int64, teams []models.TeamRating, players []models.PlayerRating, history []models.RatingChange, recompute bool) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.LockPool(WithTx(ctx, tx.Tx), disciplineID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM team_ratings WHERE discipline_id=$1`, disciplineID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM player_ratings WHERE discipline_id=$1`, disciplineID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM rating_history WHERE discipline_id=$1 AND cause=$2`, disciplineID, models.RatingCauseMatch); err != nil {
+		return err
+	}
+	if err := insertRatingChanges(ctx, tx, history); err != nil {
+		return err
+	}
+	if err := upsertTeamRatings(ctx, tx, disciplineID, teams); err != nil {
+		return err
+	}
+	if err := upsertPlayerRatings(ctx, tx, disciplineID, players); err != nil {
+		return err
+	}
+
+	teamQuery := `WITH changed AS (
+					  UPDATE teams t SET world_ranking = tr.rating
+					  FROM team_ratings tr
+					  JOIN teams old ON old.id = tr.team_id
+					  WHERE tr.team_id = t.id AND tr.discipline_id = $1 AND t.world_ranking IS DISTINCT FROM tr.rating
+					  RETURNING t.id, tr.rating, old.world_ranking AS previous_rating
+				  )
+				  INSERT INTO rating_history (team_id, discipline_id, rating, previous_rating, cause)
+				  SELECT id, $1, rating, previous_rating, $2 FROM changed WHERE $3::BOOLEAN`
+	if _, err := tx.ExecContext(ctx, teamQuery, disciplineID, models.RatingCauseRecompute, recompute); err != nil {
+		return err
+	}
+	playerQuery := `WITH latest AS (
+						SELECT DISTINCT ON (player_id) player_id, discipline_id, rating
+						FROM player_ratings
+						WHERE player_id IN (SELECT player_id FROM player_ratings WHERE discipline_id = $1)
+						ORDER BY player_id, last_played_at DESC NULLS LAST
+					), changed AS (
+						UPDATE players p SET mmr_rating = latest.rating
+						FROM latest
+						JOIN players old ON old.id = latest.player_id
+						WHERE latest.player_id = p.id AND p.mmr_rating IS DISTINCT FROM ROUND(latest.rating, 1)
+						RETURNING p.id, latest.discipline_id, latest.rating, old.mmr_rating AS previous_rating
+					)
+					INSERT INTO rating_history (player_id, discipline_id, rating, previous_rating, cause)
+					SELECT id, discipline_id, rating, previous_rating, $2 FROM changed WHERE $3::BOOLEAN`
+	if _, err := tx.ExecContext(ctx, playerQuery, disciplineID, models.RatingCauseRecompute, recompute); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AppendMatch stores the pool entries and history moved by one match rated
+// after every other match of the pool, and mirrors the entries like
+// ReplacePool.
+func (r *ratingRepo) AppendMatch(ctx context.Context, disciplineID int64, teams []models.TeamRating, players []models.PlayerRating, history []models.RatingChange) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertRatingChanges(ctx, tx, history); err != nil {
+		return err
+	}
+	if err := upsertTeamRatings(ctx, tx, disciplineID, teams); err != nil {
+		return err
+	}
+	if err := upsertPlayerRatings(ctx, tx, disciplineID, players); err != nil {
+		return err
+	}
+	teamIDs := make([]int64, len(teams))
+	for i, t := range teams {
+		teamIDs[i] = t.TeamID
+	}
+	playerIDs := make([]int64, len(players))
+	for i, p := range players {
+		playerIDs[i] = p.PlayerID
+	}
+	teamQuery := `UPDATE teams t SET world_ranking = tr.rating
+				  FROM team_ratings tr
+				  WHERE tr.team_id = t.id AND tr.team_id = ANY($1) AND t.world_ranking IS DISTINCT FROM tr.rating`
+	if _, err := tx.ExecContext(ctx, teamQuery, teamIDs); err != nil {
+		return err
+	}
+	playerQuery := `WITH latest AS (
+						SELECT DISTINCT ON (player_id) player_id, rating
+						FROM player_ratings
+						WHERE player_id = ANY($1)
+						ORDER BY player_id, last_played_at DESC NULLS LAST
+					)
+					UPDATE players p SET mmr_rating = latest.rating
+					FROM latest
+					WHERE latest.player_id = p.id AND p.mmr_rating IS DISTINCT FROM ROUND(latest.rating, 1)`
+	if _, err := tx.ExecContext(ctx, playerQuery, playerIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func upsertTeamRatings(ctx context.Context, db sqlx.ExtContext, disciplineID int64, teams []models.TeamRating) error {
+	if len(teams) == 0 {
+		return nil
+	}
+	n := len(teams)
+	ids, ratings, played := make([]int64, n), make([]float64, n), make([]int, n)
+	matchIDs, playedAt := make([]*int64, n), make([]*time.Time, n)
+	for i, t := range teams {
+		ids[i], ratings[i], played[i] = t.TeamID, t.Rating, t.MatchesPlayed
+		matchIDs[i], playedAt[i] = t.LastMatchID, t.LastPlayedAt
+	}
+	query := `INSERT INTO team_ratings (team_id, discipline_id, rating, matches_played, last_match_id, last_played_at)
+			  SELECT id, $1, rating, played, match_id, played_at
+			  FROM unnest($2::int[], $3::numeric[], $4::int[], $5::int[], $6::timestamptz[]) AS u(id, rating, played, match_id, played_at)
+			  ON CONFLICT (team_id) DO UPDATE SET discipline_id = EXCLUDED.discipline_id, rating = EXCLUDED.rating,
+				  matches_played = EXCLUDED.matches_played, last_match_id = EXCLUDED.last_match_id,
+				  last_played_at = EXCLUDED.last_played_at, updated_at = CURRENT_TIMESTAMP`
+	_, err := db.ExecContext(ctx, query, disciplineID, ids, ratings, played, matchIDs, playedAt)
+	return err
+}
+
+func upsertPlayerRatings(ctx context.Context, db sqlx.ExtContext, disciplineID int64, players []models.PlayerRating) error {
+	if len(players) == 0 {
+		return nil
+	}
+	n := len(players)
+	ids, ratings, played := make([]int64, n), make([]float64, n), make([]int, n)
+	matchIDs, playedAt := make([]*int64, n), make([]*time.Time, n)
+	for i, p := range players {
+		ids[i], ratings[i], played[i] = p.PlayerID, p.Rating, p.MatchesPlayed
+		matchIDs[i], playedAt[i] = p.LastMatchID, p.LastPlayedAt
+	}
+	query := `INSERT INTO player_ratings (player_id, discipline_id, rating, matches_played, last_match_id, last_played_at)
+			  SELECT id, $1, rating, played, match_id, played_at
+			  FROM unnest($2::int[], $3::numeric[], $4::int[], $5::int[], $6::timestamptz[]) AS u(id, rating, played, match_id, played_at)
+			  ON CONFLICT (player_id, discipline_id) DO UPDATE SET rating = EXCLUDED.rating,
+				  matches_played = EXCLUDED.matches_played, last_match_id = EXCLUDED.last_match_id,
+				  last_played_at = EXCLUDED.last_played_at, updated_at = CURRENT_TIMESTAMP`
+	_, err := db.ExecContext(ctx, query, disciplineID, ids, ratings, played, matchIDs, playedAt)
+	return err
+}
+
+// insertRatingChanges inserts match history with a single statement. The
+// generated ids are not read back.
+func insertRatingChanges(ctx context.Context, db sqlx.ExtContext, history []models.RatingChange) error {
+	if len(history) == 0 {
+		return nil
+	}
+	n := len(history)
+	teamIDs, playerIDs, disciplineIDs := make([]*int64, n), make([]*int64, n), make([]*int64, n)
+	ratings, previous := make([]float64, n), make([]*float64, n)
+	causes, matchIDs, recordedAt := make([]string, n), make([]*int64, n), make([]time.Time, n)
+	for i, c := range history {
+		teamIDs[i], playerIDs[i], disciplineIDs[i] = c.TeamID, c.PlayerID, c.DisciplineID
+		ratings[i], previous[i] = c.Rating, c.PreviousRating
+		causes[i], matchIDs[i], recordedAt[i] = c.Cause, c.MatchID, c.RecordedAt
+	}
+	query := `INSERT INTO rating_history (team_id, player_id, discipline_id, rating, previous_rating, cause, match_id, recorded_at)
+			  SELECT * FROM unnest($1::int[], $2::int[], $3::int[], $4::numeric[], $5::numeric[], $6::varchar[], $7::int[], $8::timestamptz[])`
+	_, err := db.ExecContext(ctx, query, teamIDs, playerIDs, disciplineIDs, ratings, previous, causes, matchIDs, recordedAt)
+	return err
+}
+
+func (r *ratingRepo) AddHistory(ctx context.Context, c *models.RatingChange) error {
+	return insertRatingChange(ctx, conn(ctx, r.db), c)
+}
+
+func insertRatingChange(ctx context.Context, db sqlx.ExtContext, c *models.RatingChange) error {
+	query := `INSERT INTO rating_history (team_id, player_id, discipline_id, rating, previous_rating, cause, match_id, recorded_at)
+			  VALUES ($1,$2,$3,$4,$5,$6,$7,COALESCE($8, CURRENT_TIMESTAMP)) RETURNING id, recorded_at`
+	var recordedAt *time.Time
+	if !c.RecordedAt.IsZero() {
+		recordedAt = &c.RecordedAt
+	}
+	return db.QueryRowxContext(ctx, query, c.TeamID, c.PlayerID, c.DisciplineID, c.Rating, c.PreviousRating, c.Cause, c.MatchID, recordedAt).
+		Scan(&c.ID, &c.RecordedAt)
+}
+
+// ListHistory returns the rating history of a team or a player in time
+// order. With an interval set, changes are grouped into daily or weekly
+// buckets.
+func (r *ratingRepo) ListHistory(ctx context.Context, filter models.RatingHistoryFilter) ([]models.RatingHistoryPoint, error) {
+	args := []any{}
+	conds := strings.Builder{}
+	if filter.TeamID != nil {
+		args = append(args, *filter.TeamID)
+		conds.WriteString(` AND team_id = $` + strconv.Itoa(len(args)))
+	}
+	if filter.PlayerID != nil {
+		args = append(args, *filter.PlayerID)
+		conds.WriteString(` AND player_id = $` + strconv.Itoa(len(args)))
+	}
+	if filter.DisciplineID != nil {
+		args = append(args, *filter.DisciplineID)
+		conds.WriteString(` AND discipline_id = $` + strconv.Itoa(len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conds.WriteString(` AND recorded_at >= $` + strconv.Itoa(len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conds.WriteString(` AND recorded_at < $` + strconv.Itoa(len(args)))
+	}
+
+	query := `SELECT recorded_at, discipline_id, rating, previous_rating, rating AS min_rating, rating AS max_rating, cause, match_id, 1 AS changes
+			  FROM rating_history WHERE 1=1` + conds.String() + `
+			  ORDER BY recorded_at ASC, id ASC`
+	if filter.Interval != "" {
+		unit := "day"
+		if filter.Interval == models.RatingIntervalWeekly {
+			unit = "week"
+		}
+		args = append(args, unit)
+		query = `SELECT date_trunc($` + strconv.Itoa(len(args)) + `, recorded_at) AS recorded_at,
+					 (array_agg(discipline_id ORDER BY recorded_at DESC, id DESC))[1] AS discipline_id,
+					 (array_agg(rating ORDER BY recorded_at DESC, id DESC))[1] AS rating,
+					 (array_agg(previous_rating ORDER BY recorded_at ASC, id ASC))[1] AS previous_rating,
+					 MIN(rating) AS min_rating,
+					 MAX(rating) AS max_rating,
+					 (array_agg(cause ORDER BY recorded_at DESC, id DESC))[1] AS cause,
+					 (array_agg(match_id ORDER BY recorded_at DESC, id DESC))[1] AS match_id,
+					 count(*) AS changes
+				 FROM rating_history WHERE 1=1` + conds.String() + `
+				 GROUP BY 1
+				 ORDER BY 1 ASC`
+	}
+	rows := []models.RatingHistoryPoint{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *ratingRepo) ListTeamRatings(ctx context.Context, filter models.RatingFilter) ([]models.TeamRating, int, error) {
+	base := `FROM team_ratings tr JOIN teams t ON t.id = tr.team_id WHERE 1=1`
+	args := []any{}
+	conds := strings.Builder{}
+	if filter.DisciplineID != nil {
+		args = append(args, *filter.DisciplineID)
+		conds.WriteString(` AND tr.discipline_id = $` + strconv.Itoa(len(args)))
+	}
+
+	countQuery := `SELECT count(*) ` + base + conds.String()
+	var total int
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	limitIdx := strconv.Itoa(len(args) - 1)
+	offsetIdx := strconv.Itoa(len(args))
+	listQuery := `SELECT tr.team_id, t.name AS team_name, tr.discipline_id, tr.rating, tr.matches_played, tr.last_match_id, tr.last_played_at, tr.updated_at ` +
+		base + conds.String() + ` ORDER BY tr.rating DESC, tr.team_id ASC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
+
+	rows := []models.TeamRating{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *ratingRepo) ListPlayerRatings(ctx context.Context, filter models.RatingFilter) ([]models.PlayerRating, int, error) {
+	base := `FROM player_ratings pr JOIN players p ON p.id = pr.player_id WHERE 1=1`
+	args := []any{}
+	conds := strings.Builder{}
+	if filter.DisciplineID != nil {
+		args = append(args, *filter.DisciplineID)
+		conds.WriteString(` AND pr.discipline_id = $` + strconv.Itoa(len(args)))
+	}
+
+	countQuery := `SELECT count(*) ` + base + conds.String()
+	var total int
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	limitIdx := strconv.Itoa(len(args) - 1)
+	offsetIdx := strconv.Itoa(len(args))
+	listQuery := `SELECT pr.player_id, p.nickname, pr.discipline_id, pr.rating, pr.matches_played, pr.last_match_id, pr.last_played_at, pr.updated_at ` +
+		base + conds.String() + ` ORDER BY pr.rating DESC, pr.player_id ASC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
+
+	rows := []models.PlayerRating{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
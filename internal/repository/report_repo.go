@@ -12,9 +12,10 @@ import (
 
 type ReportRepository interface {
 	ActiveRosters(ctx context.Context, limit, offset int) ([]models.ActiveRosterView, int, error)
+	RosterHealth(ctx context.Context, filter models.RosterHealthFilter) ([]models.RosterHealthView, int, error)
 	MatchResults(ctx context.Context, tournamentID *int64, limit, offset int) ([]models.MatchResultView, int, error)
 	PlayerCareer(ctx context.Context, search string, limit, offset int) ([]models.PlayerCareerStats, int, error)
-	TournamentStandings(ctx context.Context, tournamentID int64) ([]models.TournamentStanding, error)
+	TournamentStandings(ctx context.Context, filter models.StandingsFilter) ([]models.TournamentStanding, error)
 	PlayerKDA(ctx context.Context, playerID int64) (float64, error)
 }
 
@@ -29,7 +30,7 @@ type reportRepo struct {
 func (r *reportRepo) ActiveRosters(ctx context.Context, limit, offset int) ([]models.ActiveRosterView, int, error) {
 	countQuery := `SELECT count(*) FROM v_active_rosters`
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery); err != nil {
 		return nil, 0, err
 	}
 
@@ -38,7 +39,45 @@ func (r *reportRepo) ActiveRosters(ctx context.Context, limit, offset int) ([]mo
 			   ORDER BY team_name ASC, nickname ASC
 			   LIMIT $1 OFFSET $2`
 	rows := []models.ActiveRosterView{}
-	if err := r.db.SelectContext(ctx, &rows, query, limit, offset); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, limit, offset); err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+// RosterHealth lists teams with the given status, or every team whose roster
+// does not fit the discipline team_size when no status is set.
+func (r *reportRepo) RosterHealth(ctx context.Context, filter models.RosterHealthFilter) ([]models.RosterHealthView, int, error) {
+	base := `FROM v_roster_health WHERE 1=1`
+	args := []any{}
+	conds := strings.Builder{}
+
+	if filter.DisciplineID != nil {
+		args = append(args, *filter.DisciplineID)
+		conds.WriteString(` AND discipline_id = $` + strconv.Itoa(len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conds.WriteString(` AND status = $` + strconv.Itoa(len(args)))
+	} else {
+		args = append(args, models.RosterOK)
+		conds.WriteString(` AND status <> $` + strconv.Itoa(len(args)))
+	}
+
+	countQuery := `SELECT count(*) ` + base + conds.String()
+	var total int
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	limitIdx := strconv.Itoa(len(args) - 1)
+	offsetIdx := strconv.Itoa(len(args))
+	listQuery := `SELECT team_id, team_name, tag, discipline_id, team_size, starters, standins, active_players, status ` + base + conds.String() + `
+				 ORDER BY status ASC, team_name ASC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
+
+	rows := []models.RosterHealthView{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
@@ -56,18 +95,19 @@ func (r *reportRepo) MatchResults(ctx context.Context, tournamentID *int64, limi
 
 	countQuery := `SELECT count(*) ` + base + conds.String()
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
 	args = append(args, limit, offset)
 	limitIdx := strconv.Itoa(len(args) - 1)
 	offsetIdx := strconv.Itoa(len(args))
-	listQuery := `SELECT match_id, tournament_id, start_time, stage, format, winner_team_id, games_played, total_score_team1, total_score_team2 ` + base + conds.String() + `
+	listQuery := `SELECT match_id, tournament_id, start_time, stage, format, team1_id, team2_id, winner_team_id, games_played,
+				 series_score_team1, series_score_team2, series_score, total_score_team1, total_score_team2 ` + base + conds.String() + `
 				 ORDER BY start_time DESC, match_id DESC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	rows := []models.MatchResultView{}
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
@@ -84,7 +124,7 @@ func (r *reportRepo) PlayerCareer(ctx context.Context, search string, limit, off
 
 	countQuery := `SELECT count(*) ` + base + conds.String()
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
@@ -95,16 +135,18 @@ func (r *reportRepo) PlayerCareer(ctx context.Context, search string, limit, off
 				 ORDER BY kda DESC, kills DESC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	rows := []models.PlayerCareerStats{}
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
 }
 
-func (r *reportRepo) TournamentStandings(ctx context.Context, tournamentID int64) ([]models.TournamentStanding, error) {
-	query := `SELECT team_id, matches_played, wins, losses, forfeits FROM fn_tournament_standings($1)`
+func (r *reportRepo) TournamentStandings(ctx context.Context, filter models.StandingsFilter) ([]models.TournamentStanding, error) {
+	query := `SELECT rank, team_id, team_name, matches_played, wins, draws, losses, forfeits, points,
+			  head_to_head, buchholz, maps_won, maps_lost, map_diff, rounds_won, rounds_lost, round_diff
+			  FROM fn_tournament_standings($1, $2, $3, $4, $5)`
 	rows := []models.TournamentStanding{}
-	if err := r.db.SelectContext(ctx, &rows, query, tournamentID); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, filter.TournamentID, filter.Stage, filter.PointsWin, filter.PointsDraw, filter.PointsLoss); err != nil {
 		return nil, err
 	}
 	return rows, nil
@@ -113,7 +155,7 @@ func (r *reportRepo) TournamentStandings(ctx context.Context, tournamentID int64
 func (r *reportRepo) PlayerKDA(ctx context.Context, playerID int64) (float64, error) {
 	query := `SELECT fn_player_kda($1)`
 	var kda float64
-	if err := r.db.GetContext(ctx, &kda, query, playerID); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &kda, query, playerID); err != nil {
 		return 0, err
 	}
 	return kda, nil
@@ -18,6 +18,8 @@ type SquadMemberRepository interface {
 	List(ctx context.Context, filter models.SquadMemberFilter) ([]models.SquadMember, int, error)
 	Update(ctx context.Context, m *models.SquadMember) error
 	Delete(ctx context.Context, id int64) error
+	ListActiveRoster(ctx context.Context, teamID int64) ([]models.RosterPlayer, error)
+	CountActiveStarters(ctx context.Context, teamID, excludeID int64) (int, error)
 }
 
 func NewSquadMemberRepository(db *sqlx.DB) SquadMemberRepository {
@@ -33,7 +35,7 @@ type squadMemberRepo struct {
 func (r *squadMemberRepo) Create(ctx context.Context, m *models.SquadMember) error {
 	query := `INSERT INTO squad_members (team_id, player_id, role, is_standin, join_date, contract_end_date, leave_date, salary_monthly)
 			  VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING id`
-	return r.db.QueryRowxContext(ctx, query,
+	return conn(ctx, r.db).QueryRowxContext(ctx, query,
 		m.TeamID,
 		m.PlayerID,
 		m.Role,
@@ -49,7 +51,7 @@ func (r *squadMemberRepo) GetByID(ctx context.Context, id int64) (*models.SquadM
 	var m models.SquadMember
 	query := `SELECT id, team_id, player_id, role, is_standin, join_date, contract_end_date, leave_date, salary_monthly
 			  FROM squad_members WHERE id=$1`
-	if err := r.db.GetContext(ctx, &m, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &m, query, id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrSquadMemberNotFound
 		}
@@ -77,7 +79,7 @@ func (r *squadMemberRepo) List(ctx context.Context, filter models.SquadMemberFil
 
 	countQuery := `SELECT count(*) ` + base + conds.String()
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
@@ -88,7 +90,7 @@ func (r *squadMemberRepo) List(ctx context.Context, filter models.SquadMemberFil
 		` ORDER BY join_date DESC, id DESC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	rows := []models.SquadMember{}
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
@@ -97,7 +99,7 @@ func (r *squadMemberRepo) List(ctx context.Context, filter models.SquadMemberFil
 func (r *squadMemberRepo) Update(ctx context.Context, m *models.SquadMember) error {
 	query := `UPDATE squad_members SET team_id=$1, player_id=$2, role=$3, is_standin=$4, join_date=$5, contract_end_date=$6, leave_date=$7, salary_monthly=$8
 			  WHERE id=$9`
-	res, err := r.db.ExecContext(ctx, query,
+	res, err := conn(ctx, r.db).ExecContext(ctx, query,
 		m.TeamID,
 		m.PlayerID,
 		m.Role,
@@ -118,7 +120,7 @@ func (r *squadMemberRepo) Update(ctx context.Context, m *models.SquadMember) err
 }
 
 func (r *squadMemberRepo) Delete(ctx context.Context, id int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM squad_members WHERE id=$1`, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM squad_members WHERE id=$1`, id)
 	if err != nil {
 		return err
 	}
@@ -127,3 +129,28 @@ func (r *squadMemberRepo) Delete(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+func (r *squadMemberRepo) ListActiveRoster(ctx context.Context, teamID int64) ([]models.RosterPlayer, error) {
+	query := `SELECT sm.player_id, p.nickname, sm.role, sm.is_standin
+			  FROM squad_members sm
+			  JOIN players p ON p.id = sm.player_id
+			  WHERE sm.team_id=$1 AND sm.leave_date IS NULL
+			  ORDER BY sm.is_standin ASC, sm.join_date ASC, sm.id ASC`
+	rows := []models.RosterPlayer{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, teamID); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CountActiveStarters counts active non-standin members of a team, ignoring
+// the row with excludeID.
+func (r *squadMemberRepo) CountActiveStarters(ctx context.Context, teamID, excludeID int64) (int, error) {
+	query := `SELECT count(*) FROM squad_members
+			  WHERE team_id=$1 AND leave_date IS NULL AND is_standin = FALSE AND id<>$2`
+	var total int
+	if err := conn(ctx, r.db).GetContext(ctx, &total, query, teamID, excludeID); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
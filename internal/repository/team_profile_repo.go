@@ -33,7 +33,7 @@ type teamProfileRepo struct {
 func (r *teamProfileRepo) Create(ctx context.Context, p *models.TeamProfile) error {
 	query := `INSERT INTO team_profiles (team_id, coach_name, sponsor_info, headquarters, website, contact_email)
 			  VALUES ($1,$2,$3,$4,$5,$6)`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
 		p.TeamID,
 		p.CoachName,
 		p.SponsorInfo,
@@ -48,7 +48,7 @@ func (r *teamProfileRepo) GetByTeamID(ctx context.Context, teamID int64) (*model
 	var p models.TeamProfile
 	query := `SELECT team_id, coach_name, sponsor_info, headquarters, website, contact_email
 			  FROM team_profiles WHERE team_id=$1`
-	if err := r.db.GetContext(ctx, &p, query, teamID); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &p, query, teamID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrTeamProfileNotFound
 		}
@@ -69,7 +69,7 @@ func (r *teamProfileRepo) List(ctx context.Context, filter models.TeamProfileFil
 
 	countQuery := `SELECT count(*) ` + base + conds.String()
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
@@ -80,7 +80,7 @@ func (r *teamProfileRepo) List(ctx context.Context, filter models.TeamProfileFil
 		` ORDER BY team_id DESC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	rows := []models.TeamProfile{}
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
@@ -89,7 +89,7 @@ func (r *teamProfileRepo) List(ctx context.Context, filter models.TeamProfileFil
 func (r *teamProfileRepo) Update(ctx context.Context, p *models.TeamProfile) error {
 	query := `UPDATE team_profiles SET coach_name=$1, sponsor_info=$2, headquarters=$3, website=$4, contact_email=$5
 			  WHERE team_id=$6`
-	res, err := r.db.ExecContext(ctx, query,
+	res, err := conn(ctx, r.db).ExecContext(ctx, query,
 		p.CoachName,
 		p.SponsorInfo,
 		p.Headquarters,
@@ -107,7 +107,7 @@ func (r *teamProfileRepo) Update(ctx context.Context, p *models.TeamProfile) err
 }
 
 func (r *teamProfileRepo) Delete(ctx context.Context, teamID int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM team_profiles WHERE team_id=$1`, teamID)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM team_profiles WHERE team_id=$1`, teamID)
 	if err != nil {
 		return err
 	}
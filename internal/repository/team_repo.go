@@ -15,6 +15,7 @@ import (
 type TeamRepository interface {
 	Create(ctx context.Context, t *models.Team) error
 	GetByID(ctx context.Context, id int64) (*models.Team, error)
+	GetByTag(ctx context.Context, tag string, disciplineID int64) (*models.Team, error)
 	List(ctx context.Context, filter models.TeamFilter) ([]models.Team, int, error)
 	Update(ctx context.Context, t *models.Team) error
 	Delete(ctx context.Context, id int64) error
@@ -34,7 +35,7 @@ func (r *teamRepo) Create(ctx context.Context, t *models.Team) error {
 	query := `INSERT INTO teams (name, tag, country_code, discipline_id, logo_url, world_ranking, is_verified)
 			 VALUES ($1,$2,$3,$4,$5,$6,$7)
 			 RETURNING id, created_at`
-	return r.db.QueryRowxContext(ctx, query,
+	return conn(ctx, r.db).QueryRowxContext(ctx, query,
 		t.Name,
 		t.Tag,
 		t.CountryCode,
@@ -49,7 +50,20 @@ func (r *teamRepo) GetByID(ctx context.Context, id int64) (*models.Team, error)
 	var t models.Team
 	query := `SELECT id, name, tag, country_code, discipline_id, created_at, logo_url, world_ranking, is_verified
 			  FROM teams WHERE id=$1`
-	if err := r.db.GetContext(ctx, &t, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &t, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *teamRepo) GetByTag(ctx context.Context, tag string, disciplineID int64) (*models.Team, error) {
+	var t models.Team
+	query := `SELECT id, name, tag, country_code, discipline_id, created_at, logo_url, world_ranking, is_verified
+			  FROM teams WHERE tag=$1 AND discipline_id=$2`
+	if err := conn(ctx, r.db).GetContext(ctx, &t, query, tag, disciplineID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrTeamNotFound
 		}
@@ -83,7 +97,7 @@ func (r *teamRepo) List(ctx context.Context, filter models.TeamFilter) ([]models
 
 	countQuery := `SELECT count(*) ` + base + conds.String()
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
@@ -94,7 +108,7 @@ func (r *teamRepo) List(ctx context.Context, filter models.TeamFilter) ([]models
 				 ORDER BY name ASC, id ASC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	rows := []models.Team{}
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
@@ -103,7 +117,7 @@ func (r *teamRepo) List(ctx context.Context, filter models.TeamFilter) ([]models
 func (r *teamRepo) Update(ctx context.Context, t *models.Team) error {
 	query := `UPDATE teams SET name=$1, tag=$2, country_code=$3, discipline_id=$4, logo_url=$5, world_ranking=$6, is_verified=$7
 			  WHERE id=$8 RETURNING created_at`
-	if err := r.db.QueryRowxContext(ctx, query,
+	if err := conn(ctx, r.db).QueryRowxContext(ctx, query,
 		t.Name,
 		t.Tag,
 		t.CountryCode,
@@ -122,7 +136,7 @@ func (r *teamRepo) Update(ctx context.Context, t *models.Team) error {
 }
 
 func (r *teamRepo) Delete(ctx context.Context, id int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM teams WHERE id=$1`, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM teams WHERE id=$1`, id)
 	if err != nil {
 		return err
 	}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"strconv"
 	"strings"
@@ -15,16 +16,30 @@ import (
 type TournamentRegistrationRepository interface {
 	Create(ctx context.Context, r *models.TournamentRegistration) error
 	GetByID(ctx context.Context, id int64) (*models.TournamentRegistration, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (*models.TournamentRegistration, error)
 	List(ctx context.Context, filter models.TournamentRegistrationFilter) ([]models.TournamentRegistration, int, error)
 	Update(ctx context.Context, r *models.TournamentRegistration) error
 	Delete(ctx context.Context, id int64) error
+	ListByTournament(ctx context.Context, tournamentID int64, status string) ([]models.TournamentRegistration, error)
+	Enroll(ctx context.Context, r *models.TournamentRegistration, waitlistWhenFull bool) error
+	ChangeStatus(ctx context.Context, id int64, from []string, to string) (*models.TournamentRegistration, error)
+	GetByTournamentTeam(ctx context.Context, tournamentID, teamID int64) (*models.TournamentRegistration, error)
+	SetRosterSnapshot(ctx context.Context, id int64, snapshot json.RawMessage) error
+	LockRosters(ctx context.Context, tournamentID int64) error
 }
 
 func NewTournamentRegistrationRepository(db *sqlx.DB) TournamentRegistrationRepository {
 	return &tournamentRegistrationRepo{db: db}
 }
 
-var ErrTournamentRegistrationNotFound = errors.New("tournament registration not found")
+var (
+	ErrTournamentRegistrationNotFound = errors.New("tournament registration not found")
+	ErrRegistrationStatusChanged      = errors.New("registration status was changed concurrently")
+	ErrTournamentFull                 = errors.New("tournament has no free slots")
+	ErrRosterLocked                   = errors.New("roster is locked since the tournament has started")
+)
+
+const registrationColumns = `id, tournament_id, team_id, seed_number, status, manager_contact, roster_snapshot, roster_locked_at, is_invited, registered_at`
 
 type tournamentRegistrationRepo struct {
 	db *sqlx.DB
@@ -33,7 +48,7 @@ type tournamentRegistrationRepo struct {
 func (r *tournamentRegistrationRepo) Create(ctx context.Context, reg *models.TournamentRegistration) error {
 	query := `INSERT INTO tournament_registrations (tournament_id, team_id, seed_number, status, manager_contact, roster_snapshot, is_invited)
 			  VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id, registered_at`
-	return r.db.QueryRowxContext(ctx, query,
+	return conn(ctx, r.db).QueryRowxContext(ctx, query,
 		reg.TournamentID,
 		reg.TeamID,
 		reg.SeedNumber,
@@ -45,10 +60,18 @@ func (r *tournamentRegistrationRepo) Create(ctx context.Context, reg *models.Tou
 }
 
 func (r *tournamentRegistrationRepo) GetByID(ctx context.Context, id int64) (*models.TournamentRegistration, error) {
+	return r.get(ctx, `SELECT `+registrationColumns+` FROM tournament_registrations WHERE id=$1`, id)
+}
+
+// GetByIDForUpdate reads a registration and locks its row until the end of
+// the transaction in ctx.
+func (r *tournamentRegistrationRepo) GetByIDForUpdate(ctx context.Context, id int64) (*models.TournamentRegistration, error) {
+	return r.get(ctx, `SELECT `+registrationColumns+` FROM tournament_registrations WHERE id=$1 FOR UPDATE`, id)
+}
+
+func (r *tournamentRegistrationRepo) get(ctx context.Context, query string, id int64) (*models.TournamentRegistration, error) {
 	var reg models.TournamentRegistration
-	query := `SELECT id, tournament_id, team_id, seed_number, status, manager_contact, roster_snapshot, is_invited, registered_at
-			  FROM tournament_registrations WHERE id=$1`
-	if err := r.db.GetContext(ctx, &reg, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &reg, query, id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrTournamentRegistrationNotFound
 		}
@@ -77,18 +100,18 @@ func (r *tournamentRegistrationRepo) List(ctx context.Context, filter models.Tou
 
 	countQuery := `SELECT count(*) ` + base + conds.String()
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
 	args = append(args, filter.Limit, filter.Offset)
 	limitIdx := strconv.Itoa(len(args) - 1)
 	offsetIdx := strconv.Itoa(len(args))
-	listQuery := `SELECT id, tournament_id, team_id, seed_number, status, manager_contact, roster_snapshot, is_invited, registered_at ` + base + conds.String() +
+	listQuery := `SELECT id, tournament_id, team_id, seed_number, status, manager_contact, roster_snapshot, roster_locked_at, is_invited, registered_at ` + base + conds.String() +
 		` ORDER BY registered_at DESC, id DESC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	rows := []models.TournamentRegistration{}
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
@@ -97,7 +120,7 @@ func (r *tournamentRegistrationRepo) List(ctx context.Context, filter models.Tou
 func (r *tournamentRegistrationRepo) Update(ctx context.Context, reg *models.TournamentRegistration) error {
 	query := `UPDATE tournament_registrations SET tournament_id=$1, team_id=$2, seed_number=$3, status=$4, manager_contact=$5, roster_snapshot=$6, is_invited=$7
 			  WHERE id=$8`
-	res, err := r.db.ExecContext(ctx, query,
+	res, err := conn(ctx, r.db).ExecContext(ctx, query,
 		reg.TournamentID,
 		reg.TeamID,
 		reg.SeedNumber,
@@ -117,7 +140,7 @@ func (r *tournamentRegistrationRepo) Update(ctx context.Context, reg *models.Tou
 }
 
 func (r *tournamentRegistrationRepo) Delete(ctx context.Context, id int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM tournament_registrations WHERE id=$1`, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM tournament_registrations WHERE id=$1`, id)
 	if err != nil {
 		return err
 	}
@@ -126,3 +149,200 @@ func (r *tournamentRegistrationRepo) Delete(ctx context.Context, id int64) error
 	}
 	return nil
 }
+
+func (r *tournamentRegistrationRepo) ListByTournament(ctx context.Context, tournamentID int64, status string) ([]models.TournamentRegistration, error) {
+	query := `SELECT id, tournament_id, team_id, seed_number, status, manager_contact, roster_snapshot, roster_locked_at, is_invited, registered_at
+			  FROM tournament_registrations
+			  WHERE tournament_id=$1 AND ($2 = '' OR LOWER(status) = LOWER($2))
+			  ORDER BY seed_number ASC NULLS LAST, registered_at ASC, id ASC`
+	rows := []models.TournamentRegistration{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, tournamentID, status); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Enroll inserts a registration while holding a lock on the tournament, so
+// concurrent sign-ups see a consistent number of taken slots. When the
+// tournament is full and waitlistWhenFull is set the registration is stored
+// as waitlisted instead.
+func (r *tournamentRegistrationRepo) Enroll(ctx context.Context, reg *models.TournamentRegistration, waitlistWhenFull bool) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	maxTeams, err := lockTournamentCapacity(ctx, tx.Tx, reg.TournamentID)
+	if err != nil {
+		return err
+	}
+	if waitlistWhenFull && maxTeams != nil {
+		taken, err := countTakenSlots(ctx, tx.Tx, reg.TournamentID)
+		if err != nil {
+			return err
+		}
+		if taken >= *maxTeams {
+			reg.Status = models.RegistrationWaitlisted
+		}
+	}
+
+	query := `INSERT INTO tournament_registrations (tournament_id, team_id, seed_number, status, manager_contact, roster_snapshot, is_invited)
+			  VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id, registered_at`
+	if err := tx.QueryRowxContext(ctx, query,
+		reg.TournamentID,
+		reg.TeamID,
+		reg.SeedNumber,
+		reg.Status,
+		reg.ManagerContact,
+		reg.RosterSnapshot,
+		reg.IsInvited,
+	).Scan(&reg.ID, &reg.RegisteredAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ChangeStatus moves a registration to a new status if it is currently in
+// one of the from statuses. Taking a slot fails with ErrTournamentFull when
+// there is none left; freeing a slot promotes the oldest waitlisted
+// registration to Pending, which is returned.
+func (r *tournamentRegistrationRepo) ChangeStatus(ctx context.Context, id int64, from []string, to string) (*models.TournamentRegistration, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var reg models.TournamentRegistration
+	if err := tx.GetContext(ctx, &reg, `SELECT `+registrationColumns+` FROM tournament_registrations WHERE id=$1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTournamentRegistrationNotFound
+		}
+		return nil, err
+	}
+	maxTeams, err := lockTournamentCapacity(ctx, tx.Tx, reg.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	// Re-read under the tournament lock: the status may have changed while
+	// waiting for it.
+	if err := tx.GetContext(ctx, &reg.Status, `SELECT status FROM tournament_registrations WHERE id=$1`, id); err != nil {
+		return nil, err
+	}
+	allowed := false
+	for _, status := range from {
+		if reg.Status == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, ErrRegistrationStatusChanged
+	}
+
+	wasTaken, takes := takesSlot(reg.Status), takesSlot(to)
+	if takes && !wasTaken && maxTeams != nil {
+		taken, err := countTakenSlots(ctx, tx.Tx, reg.TournamentID)
+		if err != nil {
+			return nil, err
+		}
+		if taken >= *maxTeams {
+			return nil, ErrTournamentFull
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE tournament_registrations SET status=$1 WHERE id=$2`, to, id); err != nil {
+		return nil, err
+	}
+
+	var promoted *models.TournamentRegistration
+	if wasTaken && !takes {
+		taken, err := countTakenSlots(ctx, tx.Tx, reg.TournamentID)
+		if err != nil {
+			return nil, err
+		}
+		if maxTeams == nil || taken < *maxTeams {
+			var next models.TournamentRegistration
+			query := `UPDATE tournament_registrations SET status=$1
+					  WHERE id = (
+						  SELECT id FROM tournament_registrations
+						  WHERE tournament_id=$2 AND status=$3
+						  ORDER BY registered_at ASC, id ASC
+						  LIMIT 1
+					  )
+					  RETURNING ` + registrationColumns
+			err := tx.GetContext(ctx, &next, query, models.RegistrationPending, reg.TournamentID, models.RegistrationWaitlisted)
+			switch {
+			case err == nil:
+				promoted = &next
+			case !errors.Is(err, sql.ErrNoRows):
+				return nil, err
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return promoted, nil
+}
+
+func lockTournamentCapacity(ctx context.Context, tx *sqlx.Tx, tournamentID int64) (*int, error) {
+	var maxTeams *int
+	if err := tx.GetContext(ctx, &maxTeams, `SELECT max_teams FROM tournaments WHERE id=$1 FOR UPDATE`, tournamentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTournamentNotFound
+		}
+		return nil, err
+	}
+	return maxTeams, nil
+}
+
+func countTakenSlots(ctx context.Context, tx *sqlx.Tx, tournamentID int64) (int, error) {
+	var taken int
+	query := `SELECT count(*) FROM tournament_registrations WHERE tournament_id=$1 AND status IN ($2, $3)`
+	if err := tx.GetContext(ctx, &taken, query, tournamentID, models.RegistrationPending, models.RegistrationConfirmed); err != nil {
+		return 0, err
+	}
+	return taken, nil
+}
+
+func takesSlot(status string) bool {
+	return status == models.RegistrationPending || status == models.RegistrationConfirmed
+}
+
+func (r *tournamentRegistrationRepo) GetByTournamentTeam(ctx context.Context, tournamentID, teamID int64) (*models.TournamentRegistration, error) {
+	var reg models.TournamentRegistration
+	query := `SELECT ` + registrationColumns + ` FROM tournament_registrations WHERE tournament_id=$1 AND team_id=$2`
+	if err := conn(ctx, r.db).GetContext(ctx, &reg, query, tournamentID, teamID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTournamentRegistrationNotFound
+		}
+		return nil, err
+	}
+	return &reg, nil
+}
+
+func (r *tournamentRegistrationRepo) SetRosterSnapshot(ctx context.Context, id int64, snapshot json.RawMessage) error {
+	var locked bool
+	query := `UPDATE tournament_registrations SET roster_snapshot = CASE WHEN roster_locked_at IS NULL THEN $1 ELSE roster_snapshot END
+			  WHERE id=$2
+			  RETURNING roster_locked_at IS NOT NULL`
+	if err := conn(ctx, r.db).GetContext(ctx, &locked, query, snapshot, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTournamentRegistrationNotFound
+		}
+		return err
+	}
+	if locked {
+		return ErrRosterLocked
+	}
+	return nil
+}
+
+// LockRosters freezes the roster snapshots of all confirmed registrations.
+func (r *tournamentRegistrationRepo) LockRosters(ctx context.Context, tournamentID int64) error {
+	query := `UPDATE tournament_registrations SET roster_locked_at = CURRENT_TIMESTAMP
+			  WHERE tournament_id=$1 AND status=$2 AND roster_locked_at IS NULL`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, tournamentID, models.RegistrationConfirmed)
+	return err
+}
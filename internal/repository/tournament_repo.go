@@ -15,26 +15,32 @@ import (
 type TournamentRepository interface {
 	Create(ctx context.Context, t *models.Tournament) error
 	GetByID(ctx context.Context, id int64) (*models.Tournament, error)
+	ListByName(ctx context.Context, name string) ([]models.Tournament, error)
 	List(ctx context.Context, filter models.TournamentFilter) ([]models.Tournament, int, error)
 	Update(ctx context.Context, t *models.Tournament) error
 	Delete(ctx context.Context, id int64) error
+	Transition(ctx context.Context, id int64, from, to string) error
+	StatusHistory(ctx context.Context, id int64) ([]models.TournamentStatusChange, error)
 }
 
 func NewTournamentRepository(db *sqlx.DB) TournamentRepository {
 	return &tournamentRepo{db: db}
 }
 
-var ErrTournamentNotFound = errors.New("tournament not found")
+var (
+	ErrTournamentNotFound      = errors.New("tournament not found")
+	ErrTournamentStatusChanged = errors.New("tournament status was changed concurrently")
+)
 
 type tournamentRepo struct {
 	db *sqlx.DB
 }
 
 func (r *tournamentRepo) Create(ctx context.Context, t *models.Tournament) error {
-	query := `INSERT INTO tournaments (discipline_id, name, start_date, end_date, prize_pool, currency, status, is_online, bracket_config)
-			 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+	query := `INSERT INTO tournaments (discipline_id, name, start_date, end_date, prize_pool, currency, status, is_online, bracket_config, max_teams)
+			 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
 			 RETURNING id`
-	return r.db.QueryRowxContext(ctx, query,
+	return conn(ctx, r.db).QueryRowxContext(ctx, query,
 		t.DisciplineID,
 		t.Name,
 		t.StartDate,
@@ -44,14 +50,15 @@ func (r *tournamentRepo) Create(ctx context.Context, t *models.Tournament) error
 		t.Status,
 		t.IsOnline,
 		t.BracketConfig,
+		t.MaxTeams,
 	).Scan(&t.ID)
 }
 
 func (r *tournamentRepo) GetByID(ctx context.Context, id int64) (*models.Tournament, error) {
 	var t models.Tournament
-	query := `SELECT id, discipline_id, name, start_date, end_date, prize_pool, currency, status, is_online, bracket_config
+	query := `SELECT id, discipline_id, name, start_date, end_date, prize_pool, currency, status, is_online, bracket_config, max_teams
 			  FROM tournaments WHERE id=$1`
-	if err := r.db.GetContext(ctx, &t, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &t, query, id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrTournamentNotFound
 		}
@@ -60,6 +67,16 @@ func (r *tournamentRepo) GetByID(ctx context.Context, id int64) (*models.Tournam
 	return &t, nil
 }
 
+func (r *tournamentRepo) ListByName(ctx context.Context, name string) ([]models.Tournament, error) {
+	items := []models.Tournament{}
+	query := `SELECT id, discipline_id, name, start_date, end_date, prize_pool, currency, status, is_online, bracket_config, max_teams
+			  FROM tournaments WHERE name=$1 ORDER BY id`
+	if err := conn(ctx, r.db).SelectContext(ctx, &items, query, name); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 func (r *tournamentRepo) List(ctx context.Context, filter models.TournamentFilter) ([]models.Tournament, int, error) {
 	base := `FROM tournaments WHERE 1=1`
 	args := []any{}
@@ -89,36 +106,36 @@ func (r *tournamentRepo) List(ctx context.Context, filter models.TournamentFilte
 
 	countQuery := `SELECT count(*) ` + base + conds.String()
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
 	args = append(args, filter.Limit, filter.Offset)
 	limitIdx := strconv.Itoa(len(args) - 1)
 	offsetIdx := strconv.Itoa(len(args))
-	listQuery := `SELECT id, discipline_id, name, start_date, end_date, prize_pool, currency, status, is_online, bracket_config ` + base + conds.String() + `
+	listQuery := `SELECT id, discipline_id, name, start_date, end_date, prize_pool, currency, status, is_online, bracket_config, max_teams ` + base + conds.String() + `
 				 ORDER BY start_date DESC, id DESC LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
 
 	rows := []models.Tournament{}
-	if err := r.db.SelectContext(ctx, &rows, listQuery, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
 		return nil, 0, err
 	}
 	return rows, total, nil
 }
 
 func (r *tournamentRepo) Update(ctx context.Context, t *models.Tournament) error {
-	query := `UPDATE tournaments SET discipline_id=$1, name=$2, start_date=$3, end_date=$4, prize_pool=$5, currency=$6, status=$7, is_online=$8, bracket_config=$9
+	query := `UPDATE tournaments SET discipline_id=$1, name=$2, start_date=$3, end_date=$4, prize_pool=$5, currency=$6, is_online=$7, bracket_config=$8, max_teams=$9
 			 WHERE id=$10`
-	res, err := r.db.ExecContext(ctx, query,
+	res, err := conn(ctx, r.db).ExecContext(ctx, query,
 		t.DisciplineID,
 		t.Name,
 		t.StartDate,
 		t.EndDate,
 		t.PrizePool,
 		t.Currency,
-		t.Status,
 		t.IsOnline,
 		t.BracketConfig,
+		t.MaxTeams,
 		t.ID,
 	)
 	if err != nil {
@@ -131,7 +148,7 @@ func (r *tournamentRepo) Update(ctx context.Context, t *models.Tournament) error
 }
 
 func (r *tournamentRepo) Delete(ctx context.Context, id int64) error {
-	res, err := r.db.ExecContext(ctx, `DELETE FROM tournaments WHERE id=$1`, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM tournaments WHERE id=$1`, id)
 	if err != nil {
 		return err
 	}
@@ -140,3 +157,29 @@ func (r *tournamentRepo) Delete(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+// Transition moves the tournament from one status to another. It fails when
+// the stored status is no longer from, so concurrent transitions cannot both
+// succeed. The change itself is logged by a trigger.
+func (r *tournamentRepo) Transition(ctx context.Context, id int64, from, to string) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE tournaments SET status=$1 WHERE id=$2 AND status=$3`, to, id, from)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrTournamentStatusChanged
+	}
+	return nil
+}
+
+func (r *tournamentRepo) StatusHistory(ctx context.Context, id int64) ([]models.TournamentStatusChange, error) {
+	query := `SELECT id, tournament_id, from_status, to_status, changed_at
+			  FROM tournament_status_history
+			  WHERE tournament_id=$1
+			  ORDER BY changed_at ASC, id ASC`
+	rows := []models.TournamentStatusChange{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, query, id); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+
+	"db_course_project/internal/audit"
+)
+
+type txKey struct{}
+
+// WithTx binds tx to ctx. Repository calls made with the returned context run
+// inside tx instead of taking their own connection from the pool.
+func WithTx(ctx context.Context, tx *sqlx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFrom returns the transaction bound to ctx by WithTx, if any.
+func TxFrom(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok
+}
+
+// queryer is implemented by both *sqlx.DB and *sqlx.Tx.
+type queryer interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+}
+
+func conn(ctx context.Context, db *sqlx.DB) queryer {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// scopedTx is a transaction opened by a repository method. When ctx already
+// carries a transaction it joins it and leaves Commit and Rollback to the
+// owner of that transaction.
+type scopedTx struct {
+	*sqlx.Tx
+	joined bool
+}
+
+func beginTx(ctx context.Context, db *sqlx.DB) (*scopedTx, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return &scopedTx{Tx: tx, joined: true}, nil
+	}
+	tx, err := begin(ctx, db, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &scopedTx{Tx: tx}, nil
+}
+
+// begin starts a transaction and passes the audit actor and request id of ctx
+// to the audit trigger for its duration.
+func begin(ctx context.Context, db *sqlx.DB, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	tx, err := db.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	actor, requestID := audit.Actor(ctx), audit.RequestID(ctx)
+	if actor == "" && requestID == "" {
+		return tx, nil
+	}
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('app.actor', $1, true), set_config('app.request_id', $2, true)`, actor, requestID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (t *scopedTx) Commit() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *scopedTx) Rollback() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// txAttempts is how many times a unit of work runs before a serialization
+// failure is returned to the caller.
+const txAttempts = 5
+
+// TxManager runs units of work that span several repository calls.
+type TxManager struct {
+	db *sqlx.DB
+}
+
+func NewTxManager(db *sqlx.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+// Do runs fn in a read committed transaction bound to the context it is given.
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.DoWith(ctx, nil, fn)
+}
+
+// DoWith runs fn in a transaction with the given options and commits it when
+// fn succeeds. A serialization failure or deadlock rolls the transaction back
+// and runs fn again. When ctx already carries a transaction fn joins it, and
+// retrying is left to the unit of work that opened it.
+func (m *TxManager) DoWith(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = m.run(ctx, opts, fn); err == nil || !IsRetryable(err) || attempt == txAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt*attempt) * 10 * time.Millisecond):
+		}
+	}
+}
+
+// Begin starts a transaction that the caller commits or rolls back itself.
+func (m *TxManager) Begin(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return begin(ctx, m.db, opts)
+}
+
+func (m *TxManager) run(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) error {
+	tx, err := begin(ctx, m.db, opts)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(WithTx(ctx, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// IsRetryable reports whether err aborted a transaction that may succeed when
+// run again.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
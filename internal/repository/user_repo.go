@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"db_course_project/internal/models"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, u *models.User) error
+	GetByID(ctx context.Context, id int64) (*models.User, error)
+	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	List(ctx context.Context, filter models.UserFilter) ([]models.User, int, error)
+	// Update saves everything but the password.
+	Update(ctx context.Context, u *models.User) error
+	SetPassword(ctx context.Context, id int64, hash string) error
+	Delete(ctx context.Context, id int64) error
+	Count(ctx context.Context) (int, error)
+}
+
+func NewUserRepository(db *sqlx.DB) UserRepository {
+	return &userRepo{db: db}
+}
+
+var ErrUserNotFound = errors.New("user not found")
+
+const userColumns = `id, username, password_hash, role, team_id, is_active, created_at`
+
+type userRepo struct {
+	db *sqlx.DB
+}
+
+func (r *userRepo) Create(ctx context.Context, u *models.User) error {
+	query := `INSERT INTO users (username, password_hash, role, team_id, is_active)
+			  VALUES ($1,$2,$3,$4,$5)
+			  RETURNING id, created_at`
+	return conn(ctx, r.db).QueryRowxContext(ctx, query,
+		u.Username,
+		u.PasswordHash,
+		u.Role,
+		u.TeamID,
+		u.IsActive,
+	).Scan(&u.ID, &u.CreatedAt)
+}
+
+func (r *userRepo) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	return r.get(ctx, `id=$1`, id)
+}
+
+func (r *userRepo) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	return r.get(ctx, `LOWER(username)=LOWER($1)`, username)
+}
+
+func (r *userRepo) get(ctx context.Context, where string, arg any) (*models.User, error) {
+	var u models.User
+	if err := conn(ctx, r.db).GetContext(ctx, &u, `SELECT `+userColumns+` FROM users WHERE `+where, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *userRepo) List(ctx context.Context, filter models.UserFilter) ([]models.User, int, error) {
+	base := `FROM users WHERE 1=1`
+	args := []any{}
+	conds := strings.Builder{}
+
+	if filter.Role != "" {
+		args = append(args, filter.Role)
+		conds.WriteString(` AND role = $` + strconv.Itoa(len(args)))
+	}
+
+	countQuery := `SELECT count(*) ` + base + conds.String()
+	var total int
+	if err := conn(ctx, r.db).GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	limitIdx := strconv.Itoa(len(args) - 1)
+	offsetIdx := strconv.Itoa(len(args))
+	listQuery := `SELECT ` + userColumns + ` ` + base + conds.String() +
+		` ORDER BY id LIMIT $` + limitIdx + ` OFFSET $` + offsetIdx
+
+	rows := []models.User{}
+	if err := conn(ctx, r.db).SelectContext(ctx, &rows, listQuery, args...); err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *userRepo) Update(ctx context.Context, u *models.User) error {
+	query := `UPDATE users SET username=$1, role=$2, team_id=$3, is_active=$4
+			  WHERE id=$5 RETURNING password_hash, created_at`
+	if err := conn(ctx, r.db).QueryRowxContext(ctx, query,
+		u.Username,
+		u.Role,
+		u.TeamID,
+		u.IsActive,
+		u.ID,
+	).Scan(&u.PasswordHash, &u.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *userRepo) SetPassword(ctx context.Context, id int64, hash string) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE users SET password_hash=$1 WHERE id=$2`, hash, id)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *userRepo) Delete(ctx context.Context, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM users WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *userRepo) Count(ctx context.Context) (int, error) {
+	var n int
+	err := conn(ctx, r.db).GetContext(ctx, &n, `SELECT count(*) FROM users`)
+	return n, err
+}
@@ -0,0 +1,77 @@
+package series
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format describes a best-of-N series. Formats with an even number of games
+// (bo2) are played in full and may end in a draw.
+type Format struct {
+	Name  string
+	Games int
+}
+
+var formats = map[string]Format{
+	"bo1": {Name: "bo1", Games: 1},
+	"bo2": {Name: "bo2", Games: 2},
+	"bo3": {Name: "bo3", Games: 3},
+	"bo5": {Name: "bo5", Games: 5},
+	"bo7": {Name: "bo7", Games: 7},
+}
+
+func Parse(name string) (Format, error) {
+	f, ok := formats[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Format{}, fmt.Errorf("format %q is not supported, use bo1, bo2, bo3, bo5 or bo7", name)
+	}
+	return f, nil
+}
+
+func (f Format) WinsNeeded() int {
+	if f.Games%2 == 0 {
+		return f.Games/2 + 1
+	}
+	return (f.Games + 1) / 2
+}
+
+// Score is the number of games won by each side of a match.
+type Score struct {
+	Team1 int `json:"team1"`
+	Team2 int `json:"team2"`
+}
+
+func (s Score) String() string {
+	return fmt.Sprintf("%d-%d", s.Team1, s.Team2)
+}
+
+// Result reports whether the series is over and which slot won it: 1 or 2,
+// or 0 for an unfinished series or a drawn bo2.
+func (f Format) Result(s Score) (winner int, finished bool) {
+	need := f.WinsNeeded()
+	switch {
+	case s.Team1 >= need:
+		return 1, true
+	case s.Team2 >= need:
+		return 2, true
+	case s.Team1+s.Team2 >= f.Games:
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// Tally counts game wins per side. Games won by neither team are ignored.
+func Tally(team1, team2 *int64, gameWinners []*int64) Score {
+	score := Score{}
+	for _, w := range gameWinners {
+		switch {
+		case w == nil:
+		case team1 != nil && *w == *team1:
+			score.Team1++
+		case team2 != nil && *w == *team2:
+			score.Team2++
+		}
+	}
+	return score
+}
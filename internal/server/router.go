@@ -6,29 +6,57 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"db_course_project/internal/api"
+	"db_course_project/internal/auth"
 )
 
-func NewRouter(disciplineHandler *api.DisciplineHandler, teamHandler *api.TeamHandler, playerHandler *api.PlayerHandler, tournamentHandler *api.TournamentHandler, teamProfileHandler *api.TeamProfileHandler, squadMemberHandler *api.SquadMemberHandler, tournamentRegistrationHandler *api.TournamentRegistrationHandler, matchHandler *api.MatchHandler, matchGameHandler *api.MatchGameHandler, gamePlayerStatHandler *api.GamePlayerStatHandler, utilityHandler *api.UtilityHandler) *gin.Engine {
+func NewRouter(authenticate gin.HandlerFunc, authHandler *api.AuthHandler, accountHandler *api.AccountHandler, userHandler *api.UserHandler, disciplineHandler *api.DisciplineHandler, teamHandler *api.TeamHandler, playerHandler *api.PlayerHandler, tournamentHandler *api.TournamentHandler, teamProfileHandler *api.TeamProfileHandler, squadMemberHandler *api.SquadMemberHandler, tournamentRegistrationHandler *api.TournamentRegistrationHandler, matchHandler *api.MatchHandler, matchGameHandler *api.MatchGameHandler, gamePlayerStatHandler *api.GamePlayerStatHandler, bracketHandler *api.BracketHandler, ratingHandler *api.RatingHandler, importJobHandler *api.ImportJobHandler, importErrorHandler *api.ImportErrorHandler, exportHandler *api.ExportHandler, utilityHandler *api.UtilityHandler) *gin.Engine {
 	r := gin.New()
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
+	r.Use(api.RequestID())
 
 	r.GET("/health", func(c *gin.Context) {
 		api.RespondData(c, 200, gin.H{"status": "ok"}, nil)
 	})
 
 	apiGroup := r.Group("/api")
-	disciplineHandler.Register(apiGroup)
-	teamHandler.Register(apiGroup)
-	playerHandler.Register(apiGroup)
-	tournamentHandler.Register(apiGroup)
-	teamProfileHandler.Register(apiGroup)
-	squadMemberHandler.Register(apiGroup)
-	tournamentRegistrationHandler.Register(apiGroup)
-	matchHandler.Register(apiGroup)
-	matchGameHandler.Register(apiGroup)
-	gamePlayerStatHandler.Register(apiGroup)
-	utilityHandler.Register(apiGroup)
+	authHandler.Register(apiGroup)
+
+	// Every other route needs a signed-in user; reading is open to all roles.
+	signedIn := apiGroup.Group("", authenticate)
+	accountHandler.Register(signedIn)
+	ratingHandler.Register(signedIn)
+
+	adminGroup := signedIn.Group("", api.RequireRole(auth.RoleAdmin))
+	userHandler.Register(adminGroup)
+	ratingHandler.RegisterAdmin(adminGroup)
+
+	catalogGroup := signedIn.Group("", api.RequireRoleToWrite(auth.RoleAdmin))
+	disciplineHandler.Register(catalogGroup)
+
+	organizerGroup := signedIn.Group("", api.RequireRoleToWrite(auth.RoleOrganizer))
+	teamHandler.Register(organizerGroup)
+	playerHandler.Register(organizerGroup)
+	tournamentHandler.Register(organizerGroup)
+	tournamentRegistrationHandler.Register(organizerGroup)
+	matchHandler.Register(organizerGroup)
+	matchGameHandler.Register(organizerGroup)
+	gamePlayerStatHandler.Register(organizerGroup)
+	bracketHandler.Register(organizerGroup)
+	utilityHandler.Register(organizerGroup)
+
+	// Team managers may change the squad and profile of their own team; the
+	// services check the team.
+	teamGroup := signedIn.Group("", api.RequireRoleToWrite(auth.RoleTeamManager, auth.RoleOrganizer))
+	teamProfileHandler.Register(teamGroup)
+	squadMemberHandler.Register(teamGroup)
+
+	// Imports and exports carry whole tables, so even reading them is
+	// restricted.
+	dataGroup := signedIn.Group("", api.RequireRole(auth.RoleOrganizer))
+	importJobHandler.Register(dataGroup)
+	importErrorHandler.Register(dataGroup)
+	exportHandler.Register(dataGroup)
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
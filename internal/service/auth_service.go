@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"db_course_project/internal/auth"
+	"db_course_project/internal/models"
+	"db_course_project/internal/pagination"
+	"db_course_project/internal/repository"
+)
+
+const minPasswordLength = 8
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidRole        = errors.New("role must be viewer, team_manager, organizer or admin")
+	ErrUserTeam           = errors.New("team_id is required for team managers and not allowed for other roles")
+	ErrPasswordTooShort   = fmt.Errorf("password must be at least %d characters", minPasswordLength)
+)
+
+// dummyPasswordHash is compared against when a login names no user, so that
+// unknown and known usernames take as long to reject.
+const dummyPasswordHash = "$2a$10$PnocApbeZg0ltJzGkeNmz.MytGR44LhC8OK6YZZ.YOB/JdpV2zSQa"
+
+type AuthService struct {
+	tx     *repository.TxManager
+	users  repository.UserRepository
+	keys   repository.APIKeyRepository
+	tokens *auth.Tokens
+}
+
+func NewAuthService(tx *repository.TxManager, users repository.UserRepository, keys repository.APIKeyRepository, tokens *auth.Tokens) *AuthService {
+	return &AuthService{tx: tx, users: users, keys: keys, tokens: tokens}
+}
+
+type LoginResult struct {
+	Token     string      `json:"token"`
+	TokenType string      `json:"token_type"`
+	ExpiresAt time.Time   `json:"expires_at"`
+	User      models.User `json:"user"`
+}
+
+// CreatedAPIKey is a new API key together with the key itself, which is not
+// stored and cannot be shown again.
+type CreatedAPIKey struct {
+	models.APIKey
+	Key string `json:"key"`
+}
+
+// Login checks a username and password and issues a bearer token.
+func (s *AuthService) Login(ctx context.Context, username, password string) (*LoginResult, error) {
+	u, err := s.users.GetByUsername(ctx, strings.TrimSpace(username))
+	if errors.Is(err, repository.ErrUserNotFound) {
+		auth.CheckPassword(dummyPasswordHash, password)
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !auth.CheckPassword(u.PasswordHash, password) || !u.IsActive {
+		return nil, ErrInvalidCredentials
+	}
+	token, expires, err := s.tokens.Issue(*principalOf(u))
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{Token: token, TokenType: "Bearer", ExpiresAt: expires, User: *u}, nil
+}
+
+// Authenticate returns the principal of a bearer token. The user is loaded
+// again, so a token stops working once its user is deactivated or deleted and
+// carries the role and team the user has now.
+func (s *AuthService) Authenticate(ctx context.Context, token string) (*auth.Principal, error) {
+	p, err := s.tokens.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.users.GetByID(ctx, p.UserID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, auth.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !u.IsActive {
+		return nil, auth.ErrInvalidToken
+	}
+	return principalOf(u), nil
+}
+
+// AuthenticateKey returns the principal of an API key.
+func (s *AuthService) AuthenticateKey(ctx context.Context, key string) (*auth.Principal, error) {
+	u, name, err := s.keys.Authenticate(ctx, auth.HashAPIKey(key))
+	if err != nil {
+		return nil, err
+	}
+	p := principalOf(u)
+	p.APIKey = name
+	return p, nil
+}
+
+// EnsureAdmin creates an admin with the given credentials when there are no
+// users yet, so that a fresh installation can be logged into.
+func (s *AuthService) EnsureAdmin(ctx context.Context, username, password string) (bool, error) {
+	n, err := s.users.Count(ctx)
+	if err != nil || n > 0 {
+		return false, err
+	}
+	u := &models.User{Username: username, Role: auth.RoleAdmin, IsActive: true}
+	return true, s.CreateUser(ctx, u, password)
+}
+
+func (s *AuthService) CreateUser(ctx context.Context, u *models.User, password string) error {
+	if err := validateUser(u); err != nil {
+		return err
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	u.PasswordHash = hash
+	return s.users.Create(ctx, u)
+}
+
+func (s *AuthService) GetUser(ctx context.Context, id int64) (*models.User, error) {
+	return s.users.GetByID(ctx, id)
+}
+
+func (s *AuthService) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, int, error) {
+	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
+	return s.users.List(ctx, filter)
+}
+
+// UpdateUser saves u and, when password is not empty, sets a new password.
+func (s *AuthService) UpdateUser(ctx context.Context, u *models.User, password string) error {
+	if err := validateUser(u); err != nil {
+		return err
+	}
+	var hash string
+	if password != "" {
+		var err error
+		if hash, err = hashPassword(password); err != nil {
+			return err
+		}
+	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.users.Update(ctx, u); err != nil {
+			return err
+		}
+		if hash == "" {
+			return nil
+		}
+		u.PasswordHash = hash
+		return s.users.SetPassword(ctx, u.ID, hash)
+	})
+}
+
+func (s *AuthService) DeleteUser(ctx context.Context, id int64) error {
+	return s.users.Delete(ctx, id)
+}
+
+func (s *AuthService) CreateAPIKey(ctx context.Context, userID int64, name string, expiresAt *time.Time) (*CreatedAPIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, requiredError("name")
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, errors.New("expires_at must be in the future")
+	}
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	created := &CreatedAPIKey{APIKey: models.APIKey{UserID: userID, Name: name, Prefix: prefix, ExpiresAt: expiresAt}, Key: key}
+	if err := s.keys.Create(ctx, &created.APIKey, hash); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *AuthService) ListAPIKeys(ctx context.Context, userID int64) ([]models.APIKey, error) {
+	return s.keys.ListByUser(ctx, userID)
+}
+
+func (s *AuthService) RevokeAPIKey(ctx context.Context, id, userID int64) error {
+	return s.keys.Revoke(ctx, id, userID)
+}
+
+func validateUser(u *models.User) error {
+	u.Username = strings.TrimSpace(u.Username)
+	u.Role = strings.ToLower(strings.TrimSpace(u.Role))
+	if u.Username == "" || u.Role == "" {
+		return requiredError("username", "role")
+	}
+	if !auth.ValidRole(u.Role) {
+		return ErrInvalidRole
+	}
+	if (u.Role == auth.RoleTeamManager) != (u.TeamID != nil) {
+		return ErrUserTeam
+	}
+	return nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", ErrPasswordTooShort
+	}
+	return auth.HashPassword(password)
+}
+
+func principalOf(u *models.User) *auth.Principal {
+	return &auth.Principal{UserID: u.ID, Username: u.Username, Role: u.Role, TeamID: u.TeamID}
+}
+
+// authorizeTeam lets team managers change the data of their own team only.
+// Other roles, and work done without a principal, are not restricted here.
+func authorizeTeam(ctx context.Context, teamIDs ...int64) error {
+	p, ok := auth.FromContext(ctx)
+	if !ok || !p.ManagesOnly() {
+		return nil
+	}
+	for _, id := range teamIDs {
+		if p.TeamID == nil || *p.TeamID != id {
+			return fmt.Errorf("%w: team %d is not managed by %s", auth.ErrForbidden, id, p.Username)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"db_course_project/internal/bracket"
+	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
+)
+
+var (
+	ErrBracketHasResults = errors.New("bracket already has match results")
+	ErrBracketNotSwiss   = errors.New("next round can only be generated for swiss brackets")
+	ErrSwissRoundOpen    = errors.New("current swiss round still has matches without a winner")
+	ErrSwissRoundsPlayed = errors.New("all swiss rounds have already been generated")
+	ErrBracketNotCreated = errors.New("bracket has not been generated yet")
+)
+
+type BracketService struct {
+	tournaments   repository.TournamentRepository
+	registrations repository.TournamentRegistrationRepository
+	matches       repository.MatchRepository
+}
+
+func NewBracketService(tournaments repository.TournamentRepository, registrations repository.TournamentRegistrationRepository, matches repository.MatchRepository) *BracketService {
+	return &BracketService{tournaments: tournaments, registrations: registrations, matches: matches}
+}
+
+func (s *BracketService) Generate(ctx context.Context, tournamentID int64) (*models.BracketView, error) {
+	t, cfg, err := s.tournamentConfig(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.seededTeams(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.matches.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range existing {
+		if m.BracketSection != nil && m.WinnerTeamID != nil && !isSwissBye(m) {
+			return nil, ErrBracketHasResults
+		}
+	}
+
+	plan, err := bracket.Generate(cfg, teams)
+	if err != nil {
+		return nil, err
+	}
+	matches, links := toBracketMatches(t, cfg, plan)
+	if err := s.matches.ReplaceBracket(ctx, tournamentID, matches, links); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, tournamentID)
+}
+
+func (s *BracketService) NextSwissRound(ctx context.Context, tournamentID int64) (*models.BracketView, error) {
+	t, cfg, err := s.tournamentConfig(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Type != bracket.TypeSwiss {
+		return nil, ErrBracketNotSwiss
+	}
+	teams, err := s.seededTeams(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.matches.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make(map[int64]*bracket.SwissStanding, len(teams))
+	for i, id := range teams {
+		standings[id] = &bracket.SwissStanding{TeamID: id, Seed: i + 1}
+	}
+	played := map[[2]int64]bool{}
+	round := 0
+	for _, m := range existing {
+		if m.BracketSection == nil || *m.BracketSection != bracket.SectionSwiss {
+			continue
+		}
+		if m.WinnerTeamID == nil {
+			return nil, ErrSwissRoundOpen
+		}
+		if m.BracketRound != nil && *m.BracketRound > round {
+			round = *m.BracketRound
+		}
+		if isSwissBye(m) {
+			if st, ok := standings[*m.Team1ID]; ok {
+				st.Wins++
+			}
+			continue
+		}
+		if m.Team1ID == nil || m.Team2ID == nil {
+			continue
+		}
+		played[bracket.PairKey(*m.Team1ID, *m.Team2ID)] = true
+		for _, id := range []int64{*m.Team1ID, *m.Team2ID} {
+			if st, ok := standings[id]; ok {
+				st.Played++
+				if *m.WinnerTeamID == id {
+					st.Wins++
+				}
+			}
+		}
+	}
+	if round == 0 {
+		return nil, ErrBracketNotCreated
+	}
+	if round >= cfg.SwissRounds(len(teams)) {
+		return nil, ErrSwissRoundsPlayed
+	}
+
+	list := make([]bracket.SwissStanding, 0, len(standings))
+	for _, id := range teams {
+		list = append(list, *standings[id])
+	}
+	pairs, bye := bracket.PairSwiss(list, played)
+	matches, links := toBracketMatches(t, cfg, bracket.SwissRound(cfg, round+1, pairs, bye))
+	if err := s.matches.AppendBracket(ctx, matches, links); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, tournamentID)
+}
+
+func (s *BracketService) Get(ctx context.Context, tournamentID int64) (*models.BracketView, error) {
+	_, cfg, err := s.tournamentConfig(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	all, err := s.matches.ListByTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]models.Match, 0, len(all))
+	for _, m := range all {
+		if m.BracketSection != nil {
+			matches = append(matches, m)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if sa, sb := sectionRank(*a.BracketSection), sectionRank(*b.BracketSection); sa != sb {
+			return sa < sb
+		}
+		if ra, rb := intValue(a.BracketRound), intValue(b.BracketRound); ra != rb {
+			return ra < rb
+		}
+		return intValue(a.BracketPosition) < intValue(b.BracketPosition)
+	})
+
+	view := &models.BracketView{
+		TournamentID: tournamentID,
+		Type:         cfg.Type,
+		Format:       cfg.Format,
+		Roots:        []*models.BracketNode{},
+		Rounds:       []models.BracketRound{},
+	}
+	for _, m := range matches {
+		last := len(view.Rounds) - 1
+		if last < 0 || view.Rounds[last].Section != *m.BracketSection || view.Rounds[last].Round != intValue(m.BracketRound) {
+			view.Rounds = append(view.Rounds, models.BracketRound{Section: *m.BracketSection, Round: intValue(m.BracketRound)})
+			last++
+		}
+		view.Rounds[last].Matches = append(view.Rounds[last].Matches, m)
+	}
+	if cfg.IsElimination() {
+		view.Roots = buildBracketTree(matches)
+	}
+	return view, nil
+}
+
+func (s *BracketService) tournamentConfig(ctx context.Context, tournamentID int64) (*models.Tournament, bracket.Config, error) {
+	t, err := s.tournaments.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, bracket.Config{}, err
+	}
+	cfg, err := bracket.ParseConfig(t.BracketConfig)
+	if err != nil {
+		return nil, bracket.Config{}, err
+	}
+	return t, cfg, nil
+}
+
+func (s *BracketService) seededTeams(ctx context.Context, tournamentID int64) ([]int64, error) {
+	regs, err := s.registrations.ListByTournament(ctx, tournamentID, models.RegistrationConfirmed)
+	if err != nil {
+		return nil, err
+	}
+	teams := make([]int64, 0, len(regs))
+	for _, reg := range regs {
+		teams = append(teams, reg.TeamID)
+	}
+	return teams, nil
+}
+
+func toBracketMatches(t *models.Tournament, cfg bracket.Config, plan bracket.Plan) ([]models.Match, []models.BracketLink) {
+	interval := time.Duration(cfg.MatchIntervalMinutes) * time.Minute
+	matches := make([]models.Match, 0, len(plan.Matches))
+	links := []models.BracketLink{}
+	for i, pm := range plan.Matches {
+		section, round, position, stage := pm.Section, pm.Round, pm.Position, pm.Stage
+		var winner *int64
+		if pm.Bye {
+			winner = pm.Team1ID
+		}
+		matches = append(matches, models.Match{
+			TournamentID:    t.ID,
+			Team1ID:         pm.Team1ID,
+			Team2ID:         pm.Team2ID,
+			StartTime:       t.StartDate.Add(time.Duration(pm.Order) * interval),
+			Format:          pm.Format,
+			Stage:           &stage,
+			WinnerTeamID:    winner,
+			BracketSection:  &section,
+			BracketRound:    &round,
+			BracketPosition: &position,
+		})
+		if pm.WinnerTo != nil {
+			links = append(links, models.BracketLink{From: i, To: pm.WinnerTo.Match, Slot: pm.WinnerTo.Slot})
+		}
+		if pm.LoserTo != nil {
+			links = append(links, models.BracketLink{From: i, To: pm.LoserTo.Match, Slot: pm.LoserTo.Slot, Loser: true})
+		}
+	}
+	return matches, links
+}
+
+// isSwissBye reports whether m is a Swiss bye: a swiss match without a second
+// team that was won by the first.
+func isSwissBye(m models.Match) bool {
+	return m.BracketSection != nil && *m.BracketSection == bracket.SectionSwiss &&
+		m.Team1ID != nil && m.Team2ID == nil &&
+		m.WinnerTeamID != nil && *m.WinnerTeamID == *m.Team1ID
+}
+
+// buildBracketTree links matches through the winner path: each match is a
+// child of the match its winner advances to. Loser drops are not part of the
+// tree and can be followed through loser_next_match_id.
+func buildBracketTree(matches []models.Match) []*models.BracketNode {
+	nodes := make(map[int64]*models.BracketNode, len(matches))
+	for _, m := range matches {
+		nodes[m.ID] = &models.BracketNode{Match: m, Children: []*models.BracketNode{}}
+	}
+	roots := []*models.BracketNode{}
+	for _, m := range matches {
+		node := nodes[m.ID]
+		if m.NextMatchID == nil {
+			roots = append(roots, node)
+			continue
+		}
+		parent, ok := nodes[*m.NextMatchID]
+		if !ok {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	for _, node := range nodes {
+		sort.SliceStable(node.Children, func(i, j int) bool {
+			return intValue(node.Children[i].Match.NextMatchSlot) < intValue(node.Children[j].Match.NextMatchSlot)
+		})
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		return rootRank(roots[i].Match) < rootRank(roots[j].Match)
+	})
+	return roots
+}
+
+func rootRank(m models.Match) int {
+	switch *m.BracketSection {
+	case bracket.SectionGrandFinal:
+		return 0
+	case bracket.SectionUpper:
+		return 1
+	case bracket.SectionThirdPlace:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func sectionRank(section string) int {
+	switch section {
+	case bracket.SectionUpper:
+		return 0
+	case bracket.SectionLower:
+		return 1
+	case bracket.SectionGrandFinal:
+		return 2
+	case bracket.SectionThirdPlace:
+		return 3
+	case bracket.SectionGroup:
+		return 4
+	case bracket.SectionSwiss:
+		return 5
+	default:
+		return 6
+	}
+}
+
+func intValue(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func validateBracketConfig(t *models.Tournament) error {
+	if _, err := bracket.ParseConfig(t.BracketConfig); err != nil && !errors.Is(err, bracket.ErrNoConfig) {
+		return err
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 	"strings"
 
 	"db_course_project/internal/models"
@@ -11,11 +10,12 @@ import (
 )
 
 type DisciplineService struct {
+	tx   *repository.TxManager
 	repo repository.DisciplineRepository
 }
 
-func NewDisciplineService(repo repository.DisciplineRepository) *DisciplineService {
-	return &DisciplineService{repo: repo}
+func NewDisciplineService(tx *repository.TxManager, repo repository.DisciplineRepository) *DisciplineService {
+	return &DisciplineService{tx: tx, repo: repo}
 }
 
 func (s *DisciplineService) Create(ctx context.Context, d *models.Discipline) error {
@@ -23,18 +23,21 @@ func (s *DisciplineService) Create(ctx context.Context, d *models.Discipline) er
 	d.Name = strings.TrimSpace(d.Name)
 	d.Description = strings.TrimSpace(d.Description)
 	if d.Code == "" || d.Name == "" {
-		return errors.New("code and name are required")
+		return requiredError("code", "name")
 	}
-	if err := s.repo.Create(ctx, d); err != nil {
-		return err
-	}
-	return nil
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Create(ctx, d)
+	})
 }
 
 func (s *DisciplineService) Get(ctx context.Context, id int64) (*models.Discipline, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
+func (s *DisciplineService) GetByCode(ctx context.Context, code string) (*models.Discipline, error) {
+	return s.repo.GetByCode(ctx, code)
+}
+
 func (s *DisciplineService) List(ctx context.Context, filter models.DisciplineFilter) ([]models.Discipline, int, error) {
 	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
 	return s.repo.List(ctx, filter)
@@ -45,11 +48,15 @@ func (s *DisciplineService) Update(ctx context.Context, d *models.Discipline) er
 	d.Name = strings.TrimSpace(d.Name)
 	d.Description = strings.TrimSpace(d.Description)
 	if d.Code == "" || d.Name == "" {
-		return errors.New("code and name are required")
+		return requiredError("code", "name")
 	}
-	return s.repo.Update(ctx, d)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Update(ctx, d)
+	})
 }
 
 func (s *DisciplineService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Delete(ctx, id)
+	})
 }
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+
+	"db_course_project/internal/migrate"
+	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
+)
+
+func collect[T any](t *testing.T, rows iter.Seq2[T, error]) []T {
+	t.Helper()
+	var out []T
+	for row, err := range rows {
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+// An export of a finished tournament loads back with restore=true and
+// exports again unchanged. It needs a scratch database in TEST_DATABASE_URL.
+func TestExportImportRoundTrip(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+	db, err := sqlx.Connect("pgx", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.MapperFunc(sqlx.NameMapper)
+	m, err := migrate.New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	suffix := fmt.Sprintf("%x", time.Now().UnixNano()&0xffffff)
+	insert := func(query string, args ...any) int64 {
+		t.Helper()
+		var id int64
+		if err := db.QueryRowxContext(ctx, query, args...).Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	disciplineID := insert(`INSERT INTO disciplines (name, code, team_size) VALUES ($1, $1, 1) RETURNING id`, "rt-"+suffix)
+	team1 := insert(`INSERT INTO teams (name, tag, country_code, discipline_id) VALUES ($1, $1, 'SE', $2) RETURNING id`, "A"+suffix, disciplineID)
+	team2 := insert(`INSERT INTO teams (name, tag, country_code, discipline_id) VALUES ($1, $1, 'DK', $2) RETURNING id`, "B"+suffix, disciplineID)
+	tournamentID := insert(`INSERT INTO tournaments (discipline_id, name, start_date, end_date, status)
+		VALUES ($1, $2, '2025-03-01', '2025-03-02', 'Completed') RETURNING id`, disciplineID, "Cup "+suffix)
+	matchID := insert(`INSERT INTO matches (tournament_id, team1_id, team2_id, start_time, format, winner_team_id)
+		VALUES ($1, $2, $3, '2025-03-01T12:00:00Z', 'bo1', $2) RETURNING id`, tournamentID, team1, team2)
+	gameID := insert(`INSERT INTO match_games (match_id, map_name, game_number, winner_team_id, score_team1, score_team2)
+		VALUES ($1, 'Dust', 1, $2, 16, 9) RETURNING id`, matchID, team1)
+	defer db.ExecContext(context.Background(), `DELETE FROM disciplines WHERE id = $1`, disciplineID)
+	defer db.ExecContext(context.Background(), `DELETE FROM teams WHERE discipline_id = $1`, disciplineID)
+	for i, team := range []int64{team1, team2} {
+		playerID := insert(`INSERT INTO players (nickname) VALUES ($1) RETURNING id`, fmt.Sprintf("p%d-%s", i, suffix))
+		defer db.ExecContext(context.Background(), `DELETE FROM players WHERE id = $1`, playerID)
+		for _, q := range []string{
+			`INSERT INTO squad_members (team_id, player_id, join_date) VALUES ($2, $1, '2025-01-01')`,
+			`INSERT INTO tournament_registrations (tournament_id, team_id, status, seed_number) VALUES ($3, $2, 'Confirmed', $4)`,
+			`INSERT INTO game_player_stats (game_id, player_id, team_id, kills, deaths, was_mvp) VALUES ($5, $1, $2, 20, 5, $4 = 1)`,
+		} {
+			if _, err := db.ExecContext(ctx, q, playerID, team, tournamentID, i+1, gameID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	txManager := repository.NewTxManager(db)
+	tournamentRepo := repository.NewTournamentRepository(db)
+	disciplineRepo := repository.NewDisciplineRepository(db)
+	teamRepo := repository.NewTeamRepository(db)
+	squadMemberRepo := repository.NewSquadMemberRepository(db)
+	registrationRepo := repository.NewTournamentRegistrationRepository(db)
+	matchRepo := repository.NewMatchRepository(db)
+	matchGameRepo := repository.NewMatchGameRepository(db)
+	ratingRepo := repository.NewRatingRepository(db)
+	playerRepo := repository.NewPlayerRepository(db)
+
+	registrationSvc := NewTournamentRegistrationService(txManager, registrationRepo, tournamentRepo, teamRepo, squadMemberRepo, disciplineRepo)
+	ratingSvc := NewRatingService(txManager, ratingRepo, tournamentRepo, disciplineRepo, teamRepo, playerRepo)
+	matchSvc := NewMatchService(txManager, matchRepo, matchGameRepo, tournamentRepo, ratingSvc)
+	importSvc := NewImportService(txManager, repository.NewImportErrorRepository(db),
+		NewDisciplineService(txManager, disciplineRepo),
+		NewTeamService(txManager, teamRepo, ratingRepo),
+		NewPlayerService(txManager, playerRepo, ratingRepo),
+		NewTournamentService(txManager, tournamentRepo, matchRepo, registrationSvc),
+		registrationSvc,
+		matchSvc,
+		NewMatchGameService(txManager, matchGameRepo, matchSvc),
+		NewGamePlayerStatService(txManager, repository.NewGamePlayerStatRepository(db), matchGameRepo, matchRepo, registrationRepo, teamRepo, disciplineRepo),
+		NewSquadMemberService(txManager, squadMemberRepo, teamRepo, disciplineRepo),
+		NewTeamProfileService(txManager, repository.NewTeamProfileRepository(db)))
+	exportSvc := NewExportService(db, repository.NewExportRepository(db), tournamentRepo)
+
+	type export struct {
+		Tournaments   []TournamentImportInput
+		Registrations []TournamentRegistrationImportInput
+		Matches       []MatchImportInput
+		Games         []MatchGameImportInput
+		Stats         []GamePlayerStatImportInput
+	}
+	exportAll := func(id int64) export {
+		filter := models.ExportFilter{TournamentID: &id}
+		return export{
+			Tournaments:   collect(t, exportSvc.ExportTournaments(ctx, filter)),
+			Registrations: collect(t, exportSvc.ExportTournamentRegistrations(ctx, filter)),
+			Matches:       collect(t, exportSvc.ExportMatches(ctx, filter)),
+			Games:         collect(t, exportSvc.ExportMatchGames(ctx, filter)),
+			Stats:         collect(t, exportSvc.ExportGamePlayerStats(ctx, filter)),
+		}
+	}
+	before := exportAll(tournamentID)
+	if len(before.Registrations) != 2 || len(before.Games) != 1 || len(before.Stats) != 2 {
+		t.Fatalf("unexpected export %+v", before)
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM tournaments WHERE id = $1`, tournamentID); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := ImportOptions{Restore: true}
+	check := func(entity string, summary ImportSummary, err error) {
+		t.Helper()
+		if err != nil || summary.Failed > 0 {
+			t.Fatalf("import %s: %v %+v", entity, err, summary.Errors)
+		}
+	}
+	summary, err := importSvc.ImportTournaments(ctx, "test", RowsOf(before.Tournaments), opts)
+	check("tournaments", summary, err)
+	summary, err = importSvc.ImportTournamentRegistrations(ctx, "test", RowsOf(before.Registrations), opts)
+	check("registrations", summary, err)
+	summary, err = importSvc.ImportMatches(ctx, "test", RowsOf(before.Matches), opts)
+	check("matches", summary, err)
+	summary, err = importSvc.ImportMatchGames(ctx, "test", RowsOf(before.Games), opts)
+	check("match games", summary, err)
+	summary, err = importSvc.ImportGamePlayerStats(ctx, "test", RowsOf(before.Stats), opts)
+	check("game player stats", summary, err)
+
+	var restoredID int64
+	if err := db.GetContext(ctx, &restoredID, `SELECT id FROM tournaments WHERE name = $1`, "Cup "+suffix); err != nil {
+		t.Fatal(err)
+	}
+	defer db.ExecContext(context.Background(), `DELETE FROM tournaments WHERE id = $1`, restoredID)
+	if after := exportAll(restoredID); !reflect.DeepEqual(after, before) {
+		t.Errorf("export after the restore = %+v, want %+v", after, before)
+	}
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
+)
+
+// ExportEntities lists every exportable entity in the order an import has to
+// load them.
+var ExportEntities = []string{
+	models.ImportEntityDisciplines,
+	models.ImportEntityTeams,
+	models.ImportEntityTeamProfiles,
+	models.ImportEntityPlayers,
+	models.ImportEntitySquadMembers,
+	models.ImportEntityTournaments,
+	models.ImportEntityTournamentRegistrations,
+	models.ImportEntityMatches,
+	models.ImportEntityMatchGames,
+	models.ImportEntityGamePlayerStats,
+}
+
+// ExportService streams entities as the rows of their import types, so that an
+// export can be imported again, or as a SQL dump of the stored rows.
+type ExportService struct {
+	db          *sqlx.DB
+	repo        repository.ExportRepository
+	tournaments repository.TournamentRepository
+}
+
+func NewExportService(db *sqlx.DB, repo repository.ExportRepository, tournaments repository.TournamentRepository) *ExportService {
+	return &ExportService{db: db, repo: repo, tournaments: tournaments}
+}
+
+// CheckFilter reports a tournament filter that points at no tournament before
+// anything is streamed.
+func (s *ExportService) CheckFilter(ctx context.Context, filter models.ExportFilter) error {
+	if filter.TournamentID == nil {
+		return nil
+	}
+	_, err := s.tournaments.GetByID(ctx, *filter.TournamentID)
+	return err
+}
+
+// Snapshot runs fn in a read-only repeatable read transaction, so that the
+// entities exported by fn are consistent with each other.
+func (s *ExportService) Snapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	return fn(repository.WithTx(ctx, tx))
+}
+
+func (s *ExportService) ExportPlayers(ctx context.Context, filter models.ExportFilter) iter.Seq2[PlayerImportInput, error] {
+	return exportRows[PlayerImportInput](ctx, s.repo, models.ImportEntityPlayers, filter)
+}
+
+func (s *ExportService) ExportDisciplines(ctx context.Context, filter models.ExportFilter) iter.Seq2[DisciplineImportInput, error] {
+	return exportRows[DisciplineImportInput](ctx, s.repo, models.ImportEntityDisciplines, filter)
+}
+
+func (s *ExportService) ExportTeams(ctx context.Context, filter models.ExportFilter) iter.Seq2[TeamImportInput, error] {
+	return exportRows[TeamImportInput](ctx, s.repo, models.ImportEntityTeams, filter)
+}
+
+func (s *ExportService) ExportTournaments(ctx context.Context, filter models.ExportFilter) iter.Seq2[TournamentImportInput, error] {
+	return exportRows[TournamentImportInput](ctx, s.repo, models.ImportEntityTournaments, filter)
+}
+
+func (s *ExportService) ExportTournamentRegistrations(ctx context.Context, filter models.ExportFilter) iter.Seq2[TournamentRegistrationImportInput, error] {
+	return exportRows[TournamentRegistrationImportInput](ctx, s.repo, models.ImportEntityTournamentRegistrations, filter)
+}
+
+func (s *ExportService) ExportMatches(ctx context.Context, filter models.ExportFilter) iter.Seq2[MatchImportInput, error] {
+	return exportRows[MatchImportInput](ctx, s.repo, models.ImportEntityMatches, filter)
+}
+
+// ExportMatchGames exports games with their match named by tournament, start
+// time and teams, as matches have no natural key.
+func (s *ExportService) ExportMatchGames(ctx context.Context, filter models.ExportFilter) iter.Seq2[MatchGameImportInput, error] {
+	return exportRows[MatchGameImportInput](ctx, s.repo, models.ImportEntityMatchGames, filter)
+}
+
+func (s *ExportService) ExportGamePlayerStats(ctx context.Context, filter models.ExportFilter) iter.Seq2[GamePlayerStatImportInput, error] {
+	return exportRows[GamePlayerStatImportInput](ctx, s.repo, models.ImportEntityGamePlayerStats, filter)
+}
+
+func (s *ExportService) ExportSquadMembers(ctx context.Context, filter models.ExportFilter) iter.Seq2[SquadMemberImportInput, error] {
+	return exportRows[SquadMemberImportInput](ctx, s.repo, models.ImportEntitySquadMembers, filter)
+}
+
+func (s *ExportService) ExportTeamProfiles(ctx context.Context, filter models.ExportFilter) iter.Seq2[TeamProfileImportInput, error] {
+	return exportRows[TeamProfileImportInput](ctx, s.repo, models.ImportEntityTeamProfiles, filter)
+}
+
+func exportRows[T any](ctx context.Context, repo repository.ExportRepository, entity string, filter models.ExportFilter) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		rows, err := repo.Rows(ctx, entity, filter)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var row T
+			if err := rows.StructScan(&row); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(row, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// Dump writes the stored rows of entities as a SQL script that loads them, ids
+// included, into another database with the same schema. Rows whose key already
+// exists there are left alone.
+func (s *ExportService) Dump(ctx context.Context, w io.Writer, entities []string, filter models.ExportFilter) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "-- %s export generated at %s\n\nBEGIN;\n", strings.Join(entities, ", "), time.Now().UTC().Format(time.RFC3339))
+	var sequences []string
+	for _, entity := range entities {
+		table, err := s.repo.Table(ctx, entity)
+		if err != nil {
+			return err
+		}
+		if err := s.dumpTable(ctx, bw, table, filter); err != nil {
+			return err
+		}
+		if table.Identity != "" {
+			sequences = append(sequences, fmt.Sprintf(
+				"SELECT setval(pg_get_serial_sequence('%[1]s', '%[2]s'), GREATEST((SELECT max(%[2]s) FROM %[1]s), 1));\n",
+				table.Name, table.Identity))
+		}
+	}
+	bw.WriteString("\n")
+	for _, seq := range sequences {
+		bw.WriteString(seq)
+	}
+	bw.WriteString("\nCOMMIT;\n")
+	return bw.Flush()
+}
+
+func (s *ExportService) dumpTable(ctx context.Context, w *bufio.Writer, table *repository.DumpTable, filter models.ExportFilter) error {
+	rows, err := s.repo.TableRows(ctx, table, filter)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	deferred := map[int]bool{}
+	identity := -1
+	for i, col := range table.Columns {
+		if col == table.Identity {
+			identity = i
+		}
+		for _, d := range table.Deferred {
+			if col == d {
+				deferred[i] = true
+			}
+		}
+	}
+	overriding := ""
+	if table.Identity != "" {
+		overriding = " OVERRIDING SYSTEM VALUE"
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (%s)%s VALUES (", table.Name, strings.Join(table.Columns, ", "), overriding)
+
+	fmt.Fprintf(w, "\n-- %s\n", table.Name)
+	var updates []string
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			return err
+		}
+		w.WriteString(insert)
+		var sets []string
+		for i, v := range values {
+			if i > 0 {
+				w.WriteString(", ")
+			}
+			if deferred[i] {
+				w.WriteString("NULL")
+				if v != nil {
+					sets = append(sets, table.Columns[i]+" = "+sqlLiteral(v, types[i].DatabaseTypeName()))
+				}
+				continue
+			}
+			w.WriteString(sqlLiteral(v, types[i].DatabaseTypeName()))
+		}
+		w.WriteString(") ON CONFLICT DO NOTHING;\n")
+		if len(sets) > 0 && identity >= 0 {
+			updates = append(updates, fmt.Sprintf("UPDATE %s SET %s WHERE %s = %s;\n",
+				table.Name, strings.Join(sets, ", "), table.Identity, sqlLiteral(values[identity], types[identity].DatabaseTypeName())))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, u := range updates {
+		w.WriteString(u)
+	}
+	return nil
+}
+
+// sqlLiteral formats a scanned value as a SQL literal of a column of type
+// dbType.
+func sqlLiteral(v any, dbType string) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		if dbType == "DATE" {
+			return quoteSQL(v.Format("2006-01-02"))
+		}
+		return quoteSQL(v.Format(time.RFC3339Nano))
+	case []byte:
+		return quoteSQL(string(v))
+	case string:
+		return quoteSQL(v)
+	default:
+		return quoteSQL(fmt.Sprint(v))
+	}
+}
+
+func quoteSQL(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+
+	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
+)
+
+// statBatchCache is the number of cached games, matches and lineups after
+// which a batch drops its caches.
+const statBatchCache = 10000
+
+// statBatch checks stat lines for a bulk import with the rules of Create.
+// Games, matches, registrations, teams and disciplines are looked up once, and
+// lineup sizes are counted in memory so that lines checked but not yet written
+// count as well.
+type statBatch struct {
+	base    *GamePlayerStatService
+	svc     *GamePlayerStatService
+	lineups *lineupCounter
+	caches  []interface{ reset() }
+}
+
+type gameTeam struct {
+	gameID int64
+	teamID int64
+}
+
+func (s *GamePlayerStatService) newBatch() *statBatch {
+	games := &cachedGames{MatchGameRepository: s.games, items: map[int64]*models.MatchGame{}}
+	matches := &cachedMatches{MatchRepository: s.matches, items: map[int64]*models.Match{}}
+	registrations := &cachedRegistrations{TournamentRegistrationRepository: s.registrations, items: map[[2]int64]*models.TournamentRegistration{}}
+	teams := &cachedTeams{TeamRepository: s.teams, items: map[int64]*models.Team{}}
+	disciplines := &cachedDisciplines{DisciplineRepository: s.disciplines, items: map[int64]*models.Discipline{}}
+	lineups := &lineupCounter{GamePlayerStatRepository: s.repo, counts: map[gameTeam]int{}}
+	return &statBatch{
+		base:    s,
+		svc:     &GamePlayerStatService{repo: lineups, games: games, matches: matches, registrations: registrations, teams: teams, disciplines: disciplines},
+		lineups: lineups,
+		caches:  []interface{ reset() }{games, matches, registrations, teams, disciplines, lineups},
+	}
+}
+
+// check validates st and reserves its place in the lineup.
+func (b *statBatch) check(ctx context.Context, st *models.GamePlayerStat) error {
+	if err := b.svc.check(ctx, st); err != nil {
+		return err
+	}
+	b.lineups.add(st, 1)
+	return nil
+}
+
+// release gives back the lineup place of a line that was not written.
+func (b *statBatch) release(st *models.GamePlayerStat) {
+	b.lineups.add(st, -1)
+}
+
+func (b *statBatch) insertMany(ctx context.Context, stats []*models.GamePlayerStat) error {
+	return b.base.tx.Do(ctx, func(ctx context.Context) error {
+		return b.base.repo.CreateMany(ctx, stats)
+	})
+}
+
+func (b *statBatch) insert(ctx context.Context, st *models.GamePlayerStat) error {
+	return b.base.tx.Do(ctx, func(ctx context.Context) error {
+		return b.base.repo.Create(ctx, st)
+	})
+}
+
+// trim drops the caches once they grow past statBatchCache. It is called
+// between chunks, when every checked line has been written, so the lineup
+// counts read back from the database are exact.
+func (b *statBatch) trim() {
+	if len(b.lineups.counts) < statBatchCache {
+		return
+	}
+	for _, c := range b.caches {
+		c.reset()
+	}
+}
+
+func cached[K comparable, V any](items map[K]*V, key K, load func() (*V, error)) (*V, error) {
+	if v, ok := items[key]; ok {
+		return v, nil
+	}
+	v, err := load()
+	if err != nil {
+		return nil, err
+	}
+	items[key] = v
+	return v, nil
+}
+
+type cachedGames struct {
+	repository.MatchGameRepository
+	items map[int64]*models.MatchGame
+}
+
+func (c *cachedGames) GetByID(ctx context.Context, id int64) (*models.MatchGame, error) {
+	return cached(c.items, id, func() (*models.MatchGame, error) { return c.MatchGameRepository.GetByID(ctx, id) })
+}
+
+func (c *cachedGames) reset() { clear(c.items) }
+
+type cachedMatches struct {
+	repository.MatchRepository
+	items map[int64]*models.Match
+}
+
+func (c *cachedMatches) GetByID(ctx context.Context, id int64) (*models.Match, error) {
+	return cached(c.items, id, func() (*models.Match, error) { return c.MatchRepository.GetByID(ctx, id) })
+}
+
+func (c *cachedMatches) reset() { clear(c.items) }
+
+type cachedRegistrations struct {
+	repository.TournamentRegistrationRepository
+	items map[[2]int64]*models.TournamentRegistration
+}
+
+func (c *cachedRegistrations) GetByTournamentTeam(ctx context.Context, tournamentID, teamID int64) (*models.TournamentRegistration, error) {
+	return cached(c.items, [2]int64{tournamentID, teamID}, func() (*models.TournamentRegistration, error) {
+		return c.TournamentRegistrationRepository.GetByTournamentTeam(ctx, tournamentID, teamID)
+	})
+}
+
+func (c *cachedRegistrations) reset() { clear(c.items) }
+
+type cachedTeams struct {
+	repository.TeamRepository
+	items map[int64]*models.Team
+}
+
+func (c *cachedTeams) GetByID(ctx context.Context, id int64) (*models.Team, error) {
+	return cached(c.items, id, func() (*models.Team, error) { return c.TeamRepository.GetByID(ctx, id) })
+}
+
+func (c *cachedTeams) reset() { clear(c.items) }
+
+type cachedDisciplines struct {
+	repository.DisciplineRepository
+	items map[int64]*models.Discipline
+}
+
+func (c *cachedDisciplines) GetByID(ctx context.Context, id int64) (*models.Discipline, error) {
+	return cached(c.items, id, func() (*models.Discipline, error) { return c.DisciplineRepository.GetByID(ctx, id) })
+}
+
+func (c *cachedDisciplines) reset() { clear(c.items) }
+
+// lineupCounter reads the number of stat lines of a game and team once and
+// then keeps counting the lines of the batch.
+type lineupCounter struct {
+	repository.GamePlayerStatRepository
+	counts map[gameTeam]int
+}
+
+func (c *lineupCounter) CountByGameTeam(ctx context.Context, gameID, teamID, excludeID int64) (int, error) {
+	key := gameTeam{gameID: gameID, teamID: teamID}
+	if n, ok := c.counts[key]; ok {
+		return n, nil
+	}
+	n, err := c.GamePlayerStatRepository.CountByGameTeam(ctx, gameID, teamID, excludeID)
+	if err != nil {
+		return 0, err
+	}
+	c.counts[key] = n
+	return n, nil
+}
+
+// add counts a line for games and teams whose count has been read; the others
+// are read from the database when first checked.
+func (c *lineupCounter) add(st *models.GamePlayerStat, delta int) {
+	if st.TeamID == nil {
+		return
+	}
+	key := gameTeam{gameID: st.GameID, teamID: *st.TeamID}
+	if n, ok := c.counts[key]; ok {
+		c.counts[key] = n + delta
+	}
+}
+
+func (c *lineupCounter) reset() { clear(c.counts) }
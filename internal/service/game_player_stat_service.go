@@ -3,43 +3,147 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"db_course_project/internal/models"
 	"db_course_project/internal/pagination"
 	"db_course_project/internal/repository"
 )
 
+var ErrLineupOversize = errors.New("game already has team_size stat lines for this team")
+
 type GamePlayerStatService struct {
-	repo repository.GamePlayerStatRepository
+	tx            *repository.TxManager
+	repo          repository.GamePlayerStatRepository
+	games         repository.MatchGameRepository
+	matches       repository.MatchRepository
+	registrations repository.TournamentRegistrationRepository
+	teams         repository.TeamRepository
+	disciplines   repository.DisciplineRepository
 }
 
-func NewGamePlayerStatService(repo repository.GamePlayerStatRepository) *GamePlayerStatService {
-	return &GamePlayerStatService{repo: repo}
+func NewGamePlayerStatService(tx *repository.TxManager, repo repository.GamePlayerStatRepository, games repository.MatchGameRepository, matches repository.MatchRepository, registrations repository.TournamentRegistrationRepository, teams repository.TeamRepository, disciplines repository.DisciplineRepository) *GamePlayerStatService {
+	return &GamePlayerStatService{tx: tx, repo: repo, games: games, matches: matches, registrations: registrations, teams: teams, disciplines: disciplines}
 }
 
 func (s *GamePlayerStatService) Create(ctx context.Context, st *models.GamePlayerStat) error {
-	if st.GameID == 0 || st.PlayerID == 0 {
-		return errors.New("game_id and player_id are required")
+	if err := s.check(ctx, st); err != nil {
+		return err
 	}
-	return s.repo.Create(ctx, st)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Create(ctx, st)
+	})
 }
 
 func (s *GamePlayerStatService) Get(ctx context.Context, id int64) (*models.GamePlayerStat, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
+func (s *GamePlayerStatService) GetByGamePlayer(ctx context.Context, gameID, playerID int64) (*models.GamePlayerStat, error) {
+	return s.repo.GetByGamePlayer(ctx, gameID, playerID)
+}
+
 func (s *GamePlayerStatService) List(ctx context.Context, filter models.GamePlayerStatFilter) ([]models.GamePlayerStat, int, error) {
 	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
 	return s.repo.List(ctx, filter)
 }
 
 func (s *GamePlayerStatService) Update(ctx context.Context, st *models.GamePlayerStat) error {
-	if st.GameID == 0 || st.PlayerID == 0 {
-		return errors.New("game_id and player_id are required")
+	if err := s.check(ctx, st); err != nil {
+		return err
 	}
-	return s.repo.Update(ctx, st)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Update(ctx, st)
+	})
 }
 
 func (s *GamePlayerStatService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Delete(ctx, id)
+	})
+}
+
+func (s *GamePlayerStatService) check(ctx context.Context, st *models.GamePlayerStat) error {
+	if st.GameID == 0 || st.PlayerID == 0 {
+		return requiredError("game_id", "player_id")
+	}
+	if err := s.checkRoster(ctx, st); err != nil {
+		return err
+	}
+	return s.checkLineupSize(ctx, st)
+}
+
+// checkRoster compares the player against the locked roster snapshots of the
+// match teams. Without team_id the team whose roster lists the player is
+// used. Players outside the locked rosters are flagged as unregistered
+// substitutes; nothing is flagged while a candidate team has no locked roster.
+func (s *GamePlayerStatService) checkRoster(ctx context.Context, st *models.GamePlayerStat) error {
+	st.IsUnregisteredSub = false
+	game, err := s.games.GetByID(ctx, st.GameID)
+	if err != nil {
+		return err
+	}
+	m, err := s.matches.GetByID(ctx, game.MatchID)
+	if err != nil {
+		return err
+	}
+	if st.TeamID != nil && !sameTeam(st.TeamID, m.Team1ID) && !sameTeam(st.TeamID, m.Team2ID) {
+		return errors.New("team_id must be one of the match teams")
+	}
+
+	teams := []*int64{st.TeamID}
+	if st.TeamID == nil {
+		teams = []*int64{m.Team1ID, m.Team2ID}
+	}
+	checked, unlocked := 0, false
+	for _, teamID := range teams {
+		if teamID == nil {
+			continue
+		}
+		reg, err := s.registrations.GetByTournamentTeam(ctx, m.TournamentID, *teamID)
+		if errors.Is(err, repository.ErrTournamentRegistrationNotFound) {
+			unlocked = true
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if reg.RosterLockedAt == nil {
+			unlocked = true
+			continue
+		}
+		checked++
+		snapshot, _ := parseRosterSnapshot(reg.RosterSnapshot)
+		for _, p := range snapshot.Players {
+			if p.PlayerID == st.PlayerID {
+				if st.TeamID == nil {
+					team := *teamID
+					st.TeamID = &team
+				}
+				return nil
+			}
+		}
+	}
+	st.IsUnregisteredSub = checked > 0 && !unlocked
+	return nil
+}
+
+// checkLineupSize rejects a stat line when the team already has team_size
+// players recorded for the game.
+func (s *GamePlayerStatService) checkLineupSize(ctx context.Context, st *models.GamePlayerStat) error {
+	if st.TeamID == nil {
+		return nil
+	}
+	size, err := teamSize(ctx, s.teams, s.disciplines, *st.TeamID)
+	if err != nil || size == 0 {
+		return err
+	}
+	lines, err := s.repo.CountByGameTeam(ctx, st.GameID, *st.TeamID, st.ID)
+	if err != nil {
+		return err
+	}
+	if lines >= size {
+		return fmt.Errorf("%w (team_size %d)", ErrLineupOversize, size)
+	}
+	return nil
 }
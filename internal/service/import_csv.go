@@ -0,0 +1,62 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/gocarina/gocsv"
+)
+
+// DecodeCSVRecord decodes one CSV record into T by its header. A value that
+// does not fit its field is reported as a FieldError.
+func DecodeCSVRecord[T any](header, record []string) (T, error) {
+	var zero T
+	var out []T
+	if err := gocsv.UnmarshalCSV(&csvRecord{rows: [][]string{header, record}}, &out); err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && parseErr.Column > 0 && parseErr.Column <= len(header) {
+			field := header[parseErr.Column-1]
+			return zero, &FieldError{Field: field, Code: ImportCodeParse, Err: fmt.Errorf("%s: %w", field, parseErr.Err)}
+		}
+		return zero, err
+	}
+	if len(out) == 0 {
+		return zero, errors.New("empty record")
+	}
+	return out[0], nil
+}
+
+// decodeCSVFields decodes a CSV record logged as a column to value object, as
+// rows that failed to decode are.
+func decodeCSVFields[T any](fields map[string]string) (T, error) {
+	header := slices.Sorted(maps.Keys(fields))
+	record := make([]string, len(header))
+	for i, column := range header {
+		record[i] = fields[column]
+	}
+	return DecodeCSVRecord[T](header, record)
+}
+
+// csvRecord feeds a header and a single record to gocsv.
+type csvRecord struct {
+	rows [][]string
+}
+
+func (r *csvRecord) Read() ([]string, error) {
+	if len(r.rows) == 0 {
+		return nil, io.EOF
+	}
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	return row, nil
+}
+
+func (r *csvRecord) ReadAll() ([][]string, error) {
+	rows := r.rows
+	r.rows = nil
+	return rows, nil
+}
@@ -0,0 +1,26 @@
+package service
+
+import "testing"
+
+func TestDecodeCSVFields(t *testing.T) {
+	row, err := decodeCSVFields[GamePlayerStatImportInput](map[string]string{
+		"game_id":         "7",
+		"player_nickname": "s1mple",
+		"team_id":         "3",
+		"kills":           "21",
+		"was_mvp":         "true",
+		"column_14":       "ignored",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row.GameID != 7 || row.PlayerNickname != "s1mple" || row.TeamID == nil || *row.TeamID != 3 || row.Kills != 21 || row.WasMVP == nil || !*row.WasMVP {
+		t.Errorf("row = %+v", row)
+	}
+
+	_, err = decodeCSVFields[GamePlayerStatImportInput](map[string]string{"game_id": "7", "kills": "many"})
+	fieldErr, ok := err.(*FieldError)
+	if !ok || fieldErr.Field != "kills" {
+		t.Errorf("err = %v, want a FieldError for kills", err)
+	}
+}
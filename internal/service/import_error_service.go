@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"time"
+
+	"db_course_project/internal/models"
+	"db_course_project/internal/pagination"
+	"db_course_project/internal/repository"
+)
+
+var (
+	ErrInvalidRowData   = errors.New("row_data must be a JSON object")
+	ErrNoRetryIDs       = errors.New("ids are required")
+	ErrInvalidRetention = errors.New("older_than_days must not be negative")
+)
+
+const (
+	RetryImported    = "imported"
+	RetryFailed      = "failed"
+	RetryNotFound    = "not_found"
+	RetryUnsupported = "unsupported"
+	// RetryError marks an error whose retry could not run; nothing of it
+	// was kept.
+	RetryError = "error"
+)
+
+// importEntityMatchDocument is the retry key of nested match documents, which
+// are logged with the source matches_nested_api.
+const importEntityMatchDocument = "matches-nested"
+
+// ImportRetryResult is the outcome of retrying one logged error.
+type ImportRetryResult struct {
+	ID     int64           `json:"id"`
+	Status string          `json:"status"`
+	Error  *ImportRowError `json:"error,omitempty"`
+}
+
+type ImportPurgeResult struct {
+	Deleted int64     `json:"deleted"`
+	Before  time.Time `json:"before"`
+}
+
+// importRetrier imports the row_data of one logged error and returns the error
+// the row failed with, or err when the import itself could not run.
+type importRetrier func(ctx context.Context, source string, rowData json.RawMessage, onConflict string) (rowErr *ImportRowError, err error)
+
+type ImportErrorService struct {
+	tx        *repository.TxManager
+	repo      repository.ImportErrorRepository
+	retriers  map[string]importRetrier
+	retention time.Duration
+}
+
+func NewImportErrorService(tx *repository.TxManager, repo repository.ImportErrorRepository, importer *ImportService, retention time.Duration) *ImportErrorService {
+	return &ImportErrorService{
+		tx:   tx,
+		repo: repo,
+		retriers: map[string]importRetrier{
+			models.ImportEntityPlayers:                 rowRetrier(importer.ImportPlayers),
+			models.ImportEntityDisciplines:             rowRetrier(importer.ImportDisciplines),
+			models.ImportEntityTeams:                   rowRetrier(importer.ImportTeams),
+			models.ImportEntityTournaments:             rowRetrier(importer.ImportTournaments),
+			models.ImportEntityTournamentRegistrations: rowRetrier(importer.ImportTournamentRegistrations),
+			models.ImportEntityMatches:                 rowRetrier(importer.ImportMatches),
+			models.ImportEntityMatchGames:              rowRetrier(importer.ImportMatchGames),
+			models.ImportEntityGamePlayerStats:         rowRetrier(importer.ImportGamePlayerStats),
+			models.ImportEntitySquadMembers:            rowRetrier(importer.ImportSquadMembers),
+			models.ImportEntityTeamProfiles:            rowRetrier(importer.ImportTeamProfiles),
+			importEntityMatchDocument: func(ctx context.Context, source string, rowData json.RawMessage, onConflict string) (*ImportRowError, error) {
+				var doc MatchDocumentInput
+				if err := json.Unmarshal(rowData, &doc); err != nil {
+					return rowDataError(err), nil
+				}
+				// Retries run in a transaction; a failing document is rolled
+				// back to a savepoint so that its error can still be recorded.
+				tx, _ := repository.TxFrom(ctx)
+				docErr, err := savepoint(ctx, tx, func() error {
+					_, err := importer.saveMatchDocument(ctx, doc)
+					return err
+				})
+				if err != nil || docErr == nil {
+					return nil, err
+				}
+				rowErr := newImportRowError(1, docErr)
+				return &rowErr, nil
+			},
+		},
+		retention: retention,
+	}
+}
+
+func rowRetrier[T any](importFn func(context.Context, string, iter.Seq2[T, error], ImportOptions) (ImportSummary, error)) importRetrier {
+	return func(ctx context.Context, source string, rowData json.RawMessage, onConflict string) (*ImportRowError, error) {
+		var row T
+		if err := json.Unmarshal(rowData, &row); err != nil {
+			// CSV rows that failed to decode are logged with their raw
+			// string values.
+			var fields map[string]string
+			if json.Unmarshal(rowData, &fields) != nil {
+				return rowDataError(err), nil
+			}
+			if row, err = decodeCSVFields[T](fields); err != nil {
+				return rowDataError(err), nil
+			}
+		}
+		summary, err := importFn(ctx, source, RowsOf([]T{row}), ImportOptions{OnConflict: onConflict, quiet: true})
+		if err != nil {
+			return nil, err
+		}
+		if summary.Failed > 0 {
+			return &summary.Errors[0], nil
+		}
+		return nil, nil
+	}
+}
+
+func rowDataError(err error) *ImportRowError {
+	rowErr := newImportRowError(1, fmt.Errorf("row_data: %w", err))
+	rowErr.Code = ImportCodeParse
+	return &rowErr
+}
+
+// retryEntity maps an error source such as game_player_stats_csv back to the
+// entity whose import logged it.
+func retryEntity(source string) string {
+	for _, suffix := range []string{"_api", "_csv", "_stream"} {
+		if base, ok := strings.CutSuffix(source, suffix); ok {
+			return strings.ReplaceAll(base, "_", "-")
+		}
+	}
+	return ""
+}
+
+func (s *ImportErrorService) Get(ctx context.Context, id int64) (*models.ImportError, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *ImportErrorService) List(ctx context.Context, filter models.ImportErrorFilter) ([]models.ImportError, int, error) {
+	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
+	return s.repo.List(ctx, filter)
+}
+
+// Fix replaces the stored row of an error, so that a retry imports the
+// corrected data.
+func (s *ImportErrorService) Fix(ctx context.Context, id int64, rowData json.RawMessage) (*models.ImportError, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(rowData, &obj); err != nil || obj == nil {
+		return nil, ErrInvalidRowData
+	}
+	return s.repo.UpdateRowData(ctx, id, rowData)
+}
+
+// Retry imports the stored rows of the given errors again. Rows that import
+// are removed from the log; the others keep their row and get the new error.
+// Every error is retried in a transaction of its own, so one whose retry
+// cannot run is reported as RetryError and the others are kept.
+func (s *ImportErrorService) Retry(ctx context.Context, ids []int64, onConflict string) ([]ImportRetryResult, error) {
+	if len(ids) == 0 {
+		return nil, ErrNoRetryIDs
+	}
+	if err := (ImportOptions{OnConflict: onConflict}).validate(); err != nil {
+		return nil, err
+	}
+	results := make([]ImportRetryResult, 0, len(ids))
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		var res ImportRetryResult
+		err := s.tx.Do(ctx, func(ctx context.Context) error {
+			var err error
+			res, err = s.retry(ctx, id, onConflict)
+			return err
+		})
+		if err != nil {
+			res = ImportRetryResult{ID: id, Status: RetryError, Error: &ImportRowError{Code: ImportCodeInvalid, Message: err.Error()}}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func (s *ImportErrorService) retry(ctx context.Context, id int64, onConflict string) (ImportRetryResult, error) {
+	res := ImportRetryResult{ID: id}
+	logged, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrImportErrorNotFound) {
+		res.Status = RetryNotFound
+		return res, nil
+	}
+	if err != nil {
+		return res, err
+	}
+	retrier, ok := s.retriers[retryEntity(logged.Source)]
+	if !ok {
+		res.Status = RetryUnsupported
+		res.Error = &ImportRowError{Code: ImportCodeInvalid, Message: fmt.Sprintf("rows of source %q cannot be retried", logged.Source)}
+		return res, nil
+	}
+	rowErr, err := retrier(ctx, logged.Source, logged.RowData, onConflict)
+	if err != nil {
+		return res, err
+	}
+	if rowErr != nil {
+		rowErr.Row, rowErr.Line = 0, 0
+		if logged.RowIndex != nil {
+			rowErr.Row = *logged.RowIndex
+		}
+		if logged.LineNumber != nil {
+			rowErr.Line = *logged.LineNumber
+		}
+		res.Status, res.Error = RetryFailed, rowErr
+		return res, s.repo.MarkRetried(ctx, id, rowErr.Code, rowErr.Field, rowErr.Message)
+	}
+	res.Status = RetryImported
+	return res, s.repo.Delete(ctx, id)
+}
+
+// Purge deletes errors older than olderThanDays, or than the configured
+// retention when it is nil.
+func (s *ImportErrorService) Purge(ctx context.Context, source string, olderThanDays *int) (ImportPurgeResult, error) {
+	retention := s.retention
+	if olderThanDays != nil {
+		if *olderThanDays < 0 {
+			return ImportPurgeResult{}, ErrInvalidRetention
+		}
+		retention = time.Duration(*olderThanDays) * 24 * time.Hour
+	}
+	before := time.Now().Add(-retention)
+	deleted, err := s.repo.Purge(ctx, before, source)
+	if err != nil {
+		return ImportPurgeResult{}, err
+	}
+	return ImportPurgeResult{Deleted: deleted, Before: before}, nil
+}
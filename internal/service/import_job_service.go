@@ -0,0 +1,325 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"db_course_project/internal/audit"
+	"db_course_project/internal/models"
+	"db_course_project/internal/pagination"
+	"db_course_project/internal/repository"
+)
+
+var (
+	ErrUnknownImportEntity = errors.New("unknown import entity")
+	ErrInvalidJobStatus    = errors.New("status must be queued, running, completed, failed or cancelled")
+)
+
+const (
+	// importJobChunk is the number of rows committed together with one
+	// progress update. Atomic and dry-run jobs are imported as a single chunk.
+	importJobChunk = 500
+	// importJobPoll is how often idle workers check the queue when no
+	// Enqueue wakes them.
+	importJobPoll = 5 * time.Second
+)
+
+var errImportJobInterrupted = errors.New("import job interrupted by shutdown")
+
+// importJobRunner imports the rows of a job from job.Processed on. Each chunk
+// is reported to progress in the transaction that commits it, and next is
+// called between chunks; an error from either stops the job.
+type importJobRunner func(ctx context.Context, job *models.ImportJob, opts ImportOptions, progress func(ctx context.Context, processed int, summary ImportSummary) error, next func() error) error
+
+type ImportJobService struct {
+	tx      *repository.TxManager
+	repo    repository.ImportJobRepository
+	runners map[string]importJobRunner
+	wake    chan struct{}
+
+	mu      sync.Mutex
+	running map[int64]context.CancelFunc
+}
+
+func NewImportJobService(tx *repository.TxManager, repo repository.ImportJobRepository, importer *ImportService) *ImportJobService {
+	return &ImportJobService{
+		tx:   tx,
+		repo: repo,
+		runners: map[string]importJobRunner{
+			models.ImportEntityPlayers:                 jobRunner(importer.ImportPlayers),
+			models.ImportEntityDisciplines:             jobRunner(importer.ImportDisciplines),
+			models.ImportEntityTeams:                   jobRunner(importer.ImportTeams),
+			models.ImportEntityTournaments:             jobRunner(importer.ImportTournaments),
+			models.ImportEntityTournamentRegistrations: jobRunner(importer.ImportTournamentRegistrations),
+			models.ImportEntityMatches:                 jobRunner(importer.ImportMatches),
+			models.ImportEntityMatchGames:              jobRunner(importer.ImportMatchGames),
+			models.ImportEntityGamePlayerStats:         jobRunner(importer.ImportGamePlayerStats),
+			models.ImportEntitySquadMembers:            jobRunner(importer.ImportSquadMembers),
+			models.ImportEntityTeamProfiles:            jobRunner(importer.ImportTeamProfiles),
+		},
+		wake:    make(chan struct{}, 1),
+		running: map[int64]context.CancelFunc{},
+	}
+}
+
+// jobRunner streams the payload of a job, an array of T, and imports it chunk
+// by chunk. Rows committed by an earlier run are skipped without decoding
+// them, so only the chunk in progress is held as T.
+func jobRunner[T any](importFn func(context.Context, string, iter.Seq2[T, error], ImportOptions) (ImportSummary, error)) importJobRunner {
+	return func(ctx context.Context, job *models.ImportJob, opts ImportOptions, progress func(context.Context, int, ImportSummary) error, next func() error) error {
+		dec := json.NewDecoder(bytes.NewReader(job.Payload))
+		if tok, err := dec.Token(); err != nil {
+			return err
+		} else if tok != json.Delim('[') {
+			return errors.New("import job payload is not an array")
+		}
+		for i := 0; i < job.Processed && dec.More(); i++ {
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return err
+			}
+		}
+		chunk := importJobChunk
+		if opts.Atomic || opts.DryRun {
+			chunk = math.MaxInt
+		}
+		for start := job.Processed; dec.More(); {
+			rows := make([]T, 0, min(chunk, importJobChunk))
+			for len(rows) < chunk && dec.More() {
+				var row T
+				if err := dec.Decode(&row); err != nil {
+					return err
+				}
+				rows = append(rows, row)
+			}
+			end := start + len(rows)
+			opts.rowOffset = start
+			opts.progress = func(ctx context.Context, summary ImportSummary) error {
+				return progress(ctx, end, summary)
+			}
+			if _, err := importFn(ctx, job.Source, RowsOf(rows), opts); err != nil {
+				return err
+			}
+			if err := next(); err != nil {
+				return err
+			}
+			start = end
+		}
+		return nil
+	}
+}
+
+// Enqueue stores rows as a new job and wakes a worker. rows must be a slice of
+// the *ImportInput type of entity.
+func (s *ImportJobService) Enqueue(ctx context.Context, entity, source string, rows any, total int, opts ImportOptions) (*models.ImportJob, error) {
+	if _, ok := s.runners[entity]; !ok {
+		return nil, ErrUnknownImportEntity
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(rows)
+	if err != nil {
+		return nil, err
+	}
+	job := &models.ImportJob{
+		Entity:     entity,
+		Source:     source,
+		IsAtomic:   opts.Atomic,
+		IsDryRun:   opts.DryRun,
+		IsRestore:  opts.Restore,
+		OnConflict: opts.OnConflict,
+		Payload:    payload,
+		Total:      total,
+	}
+	if job.OnConflict == "" {
+		job.OnConflict = OnConflictError
+	}
+	if actor := audit.Actor(ctx); actor != "" {
+		job.CreatedBy = &actor
+	}
+	if id := audit.RequestID(ctx); id != "" {
+		job.RequestID = &id
+	}
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Create(ctx, job)
+	})
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+func (s *ImportJobService) Get(ctx context.Context, id int64) (*models.ImportJob, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *ImportJobService) List(ctx context.Context, filter models.ImportJobFilter) ([]models.ImportJob, int, error) {
+	switch filter.Status {
+	case "", models.ImportJobQueued, models.ImportJobRunning, models.ImportJobCompleted, models.ImportJobFailed, models.ImportJobCancelled:
+	default:
+		return nil, 0, ErrInvalidJobStatus
+	}
+	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
+	return s.repo.List(ctx, filter)
+}
+
+// Cancel stops a queued or running job. A running job is interrupted in the
+// chunk it is importing, which is rolled back; chunks committed before stay
+// unless the job is atomic.
+func (s *ImportJobService) Cancel(ctx context.Context, id int64) (*models.ImportJob, error) {
+	var job *models.ImportJob
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		job, err = s.repo.Cancel(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if cancel, ok := s.running[id]; ok {
+		cancel()
+	}
+	s.mu.Unlock()
+	return job, nil
+}
+
+// Run processes queued jobs with the given number of workers until ctx is
+// done. Jobs left running by a previous run of the server are queued again
+// first, so only one server instance should run workers. On
+// shutdown workers finish their current chunk, and the job stays running so
+// the next start resumes it.
+func (s *ImportJobService) Run(ctx context.Context, workers int) {
+	var n int64
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		n, err = s.repo.Requeue(ctx)
+		return err
+	})
+	if err != nil {
+		log.Printf("import jobs: requeue failed: %v", err)
+	} else if n > 0 {
+		log.Printf("import jobs: resuming %d interrupted jobs", n)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < max(workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *ImportJobService) work(ctx context.Context) {
+	ticker := time.NewTicker(importJobPoll)
+	defer ticker.Stop()
+	for {
+		for ctx.Err() == nil {
+			job, err := s.repo.Claim(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("import jobs: claim failed: %v", err)
+				}
+				break
+			}
+			if job == nil {
+				break
+			}
+			s.process(ctx, job)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// process runs one job. The import itself runs on a context of its own, so a
+// shutdown does not abort a chunk half way; only Cancel does. Its changes are
+// audited for whoever queued the job.
+func (s *ImportJobService) process(ctx context.Context, job *models.ImportJob) {
+	jobCtx, cancel := context.WithCancel(jobAuditContext(job))
+	defer cancel()
+	s.mu.Lock()
+	s.running[job.ID] = cancel
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, job.ID)
+		s.mu.Unlock()
+	}()
+
+	opts := ImportOptions{Atomic: job.IsAtomic, DryRun: job.IsDryRun, Restore: job.IsRestore, OnConflict: job.OnConflict}
+	progress := func(txCtx context.Context, processed int, summary ImportSummary) error {
+		errs, err := json.Marshal(summary.Errors)
+		if err != nil {
+			return err
+		}
+		return s.repo.Progress(txCtx, job.ID, models.ImportJobProgress{
+			Processed: processed,
+			Inserted:  summary.Inserted,
+			Updated:   summary.Updated,
+			Skipped:   summary.Skipped,
+			Failed:    summary.Failed,
+			Errors:    errs,
+		})
+	}
+	err := s.runners[job.Entity](jobCtx, job, opts, progress, func() error {
+		if ctx.Err() != nil {
+			return errImportJobInterrupted
+		}
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, repository.ErrImportJobNotRunning), jobCtx.Err() != nil:
+		// Cancelled: the status was already set by Cancel.
+	case errors.Is(err, errImportJobInterrupted):
+		log.Printf("import jobs: job %d interrupted, it resumes on the next start", job.ID)
+	case err != nil:
+		msg := err.Error()
+		s.finish(job, models.ImportJobFailed, &msg)
+	default:
+		s.finish(job, models.ImportJobCompleted, nil)
+	}
+}
+
+// finish records the outcome of a job. It runs on a context of its own, like
+// the import, so that a shutdown does not lose it.
+func (s *ImportJobService) finish(job *models.ImportJob, status string, msg *string) {
+	err := s.tx.Do(jobAuditContext(job), func(ctx context.Context) error {
+		return s.repo.Finish(ctx, job.ID, status, msg)
+	})
+	if err != nil {
+		log.Printf("import jobs: finishing job %d failed: %v", job.ID, err)
+	}
+}
+
+func jobAuditContext(job *models.ImportJob) context.Context {
+	actor := fmt.Sprintf("import job %d", job.ID)
+	if job.CreatedBy != nil {
+		actor = fmt.Sprintf("%s (import job %d)", *job.CreatedBy, job.ID)
+	}
+	ctx := audit.WithActor(context.Background(), actor)
+	if job.RequestID != nil {
+		ctx = audit.WithRequestID(ctx, *job.RequestID)
+	}
+	return ctx
+}
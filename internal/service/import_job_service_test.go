@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"testing"
+
+	"db_course_project/internal/models"
+)
+
+type jobRow struct {
+	N int `json:"n"`
+}
+
+func TestJobRunnerResumes(t *testing.T) {
+	rows := make([]jobRow, 1203)
+	for i := range rows {
+		rows[i].N = i
+	}
+	payload, err := json.Marshal(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		processed int
+		atomic    bool
+		want      [][2]int
+	}{
+		{name: "from the start", want: [][2]int{{0, 500}, {500, 1000}, {1000, 1203}}},
+		{name: "resumed", processed: 502, want: [][2]int{{502, 1002}, {1002, 1203}}},
+		{name: "resumed at the end", processed: 1203},
+		{name: "atomic", atomic: true, want: [][2]int{{0, 1203}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var chunks [][2]int
+			var reported []int
+			importFn := func(ctx context.Context, source string, seq iter.Seq2[jobRow, error], opts ImportOptions) (ImportSummary, error) {
+				n := opts.rowOffset
+				for row, err := range seq {
+					if err != nil || row.N != n {
+						return ImportSummary{}, fmt.Errorf("row %d: got %+v, %v", n, row, err)
+					}
+					n++
+				}
+				chunks = append(chunks, [2]int{opts.rowOffset, n})
+				return ImportSummary{}, opts.progress(ctx, ImportSummary{})
+			}
+			job := &models.ImportJob{Payload: payload, Processed: tt.processed}
+			progress := func(_ context.Context, processed int, _ ImportSummary) error {
+				reported = append(reported, processed)
+				return nil
+			}
+			err := jobRunner(importFn)(context.Background(), job, ImportOptions{Atomic: tt.atomic}, progress, func() error { return nil })
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(chunks) != fmt.Sprint(tt.want) {
+				t.Fatalf("chunks = %v, want %v", chunks, tt.want)
+			}
+			for i, c := range chunks {
+				if reported[i] != c[1] {
+					t.Errorf("chunk %v reported %d processed", c, reported[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
+)
+
+// importRefs resolves the natural references of import rows (discipline code,
+// team tag, player nickname or steam id, tournament name, match start time and
+// teams, game number) to ids. It lives for
+// one batch and caches every lookup, so a file that names the same team on
+// each row queries it once. Numeric ids, when given, win over natural keys.
+type importRefs struct {
+	s                     *ImportService
+	disciplines           map[string]int64
+	tournaments           map[string]int64
+	teams                 map[teamRef]int64
+	players               map[string]int64
+	tournamentDisciplines map[int64]int64
+	matchDisciplines      map[int64]int64
+	gameDisciplines       map[int64]int64
+	tournamentMatches     map[int64][]models.Match
+	games                 map[gameRef]int64
+}
+
+type teamRef struct {
+	tag          string
+	disciplineID int64
+}
+
+// matchRef names a match the way exports do, as matches have no natural key:
+// by its tournament, start time and teams.
+type matchRef struct {
+	tournamentName string
+	startTime      string
+	team1Tag       string
+	team2Tag       string
+}
+
+type gameRef struct {
+	matchID    int64
+	gameNumber int
+}
+
+func (s *ImportService) newRefs() *importRefs {
+	return &importRefs{
+		s:                     s,
+		disciplines:           map[string]int64{},
+		tournaments:           map[string]int64{},
+		teams:                 map[teamRef]int64{},
+		players:               map[string]int64{},
+		tournamentDisciplines: map[int64]int64{},
+		matchDisciplines:      map[int64]int64{},
+		gameDisciplines:       map[int64]int64{},
+		tournamentMatches:     map[int64][]models.Match{},
+		games:                 map[gameRef]int64{},
+	}
+}
+
+func (r *importRefs) disciplineID(ctx context.Context, id int64, code string) (int64, error) {
+	code = strings.TrimSpace(code)
+	if id != 0 || code == "" {
+		return id, nil
+	}
+	if cached, ok := r.disciplines[code]; ok {
+		return cached, nil
+	}
+	d, err := r.s.disciplineSvc.GetByCode(ctx, code)
+	if errors.Is(err, repository.ErrDisciplineNotFound) {
+		return 0, &FieldError{Field: "discipline_code", Code: ImportCodeForeignKey, Err: fmt.Errorf("discipline_code %q not found", code)}
+	}
+	if err != nil {
+		return 0, err
+	}
+	r.disciplines[code] = d.ID
+	return d.ID, nil
+}
+
+// tournamentID resolves a tournament by name. Names are not unique, so a name
+// shared by several tournaments has to be replaced by tournament_id.
+func (r *importRefs) tournamentID(ctx context.Context, id int64, name string) (int64, error) {
+	name = strings.TrimSpace(name)
+	if id != 0 || name == "" {
+		return id, nil
+	}
+	if cached, ok := r.tournaments[name]; ok {
+		return cached, nil
+	}
+	items, err := r.s.tournamentSvc.ListByName(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	switch len(items) {
+	case 0:
+		return 0, &FieldError{Field: "tournament_name", Code: ImportCodeForeignKey, Err: fmt.Errorf("tournament_name %q not found", name)}
+	case 1:
+	default:
+		return 0, &FieldError{Field: "tournament_name", Code: ImportCodeInvalid, Err: fmt.Errorf("tournament_name %q matches %d tournaments, use tournament_id", name, len(items))}
+	}
+	r.tournaments[name] = items[0].ID
+	r.tournamentDisciplines[items[0].ID] = items[0].DisciplineID
+	return items[0].ID, nil
+}
+
+// teamID resolves a team tag. Tags are unique per discipline only, so the
+// discipline is asked for when a tag has to be looked up.
+func (r *importRefs) teamID(ctx context.Context, id int64, tag string, discipline func() (int64, error)) (int64, error) {
+	tag = strings.TrimSpace(tag)
+	if id != 0 || tag == "" {
+		return id, nil
+	}
+	disciplineID, err := discipline()
+	if err != nil {
+		return 0, err
+	}
+	if disciplineID == 0 {
+		return 0, &FieldError{Field: "discipline_id", Code: ImportCodeRequired, Err: fmt.Errorf("team_tag %q needs a discipline", tag)}
+	}
+	key := teamRef{tag: tag, disciplineID: disciplineID}
+	if cached, ok := r.teams[key]; ok {
+		return cached, nil
+	}
+	t, err := r.s.teamSvc.GetByTag(ctx, tag, disciplineID)
+	if errors.Is(err, repository.ErrTeamNotFound) {
+		return 0, &FieldError{Field: "team_tag", Code: ImportCodeForeignKey, Err: fmt.Errorf("team_tag %q not found in discipline %d", tag, disciplineID)}
+	}
+	if err != nil {
+		return 0, err
+	}
+	r.teams[key] = t.ID
+	return t.ID, nil
+}
+
+func (r *importRefs) optionalTeamID(ctx context.Context, id *int64, tag string, discipline func() (int64, error)) (*int64, error) {
+	if id != nil || strings.TrimSpace(tag) == "" {
+		return id, nil
+	}
+	resolved, err := r.teamID(ctx, 0, tag, discipline)
+	if err != nil {
+		return nil, err
+	}
+	return &resolved, nil
+}
+
+func (r *importRefs) playerID(ctx context.Context, id int64, nickname, steamID string) (int64, error) {
+	nickname, steamID = strings.TrimSpace(nickname), strings.TrimSpace(steamID)
+	switch {
+	case id != 0:
+		return id, nil
+	case nickname != "":
+		return r.player(ctx, "nickname:"+nickname, func() (int64, error) {
+			p, err := r.s.playerSvc.GetByNickname(ctx, nickname)
+			if errors.Is(err, repository.ErrPlayerNotFound) {
+				return 0, &FieldError{Field: "player_nickname", Code: ImportCodeForeignKey, Err: fmt.Errorf("player_nickname %q not found", nickname)}
+			}
+			if err != nil {
+				return 0, err
+			}
+			return p.ID, nil
+		})
+	case steamID != "":
+		return r.player(ctx, "steam_id:"+steamID, func() (int64, error) {
+			p, err := r.s.playerSvc.GetBySteamID(ctx, steamID)
+			if errors.Is(err, repository.ErrPlayerNotFound) {
+				return 0, &FieldError{Field: "player_steam_id", Code: ImportCodeForeignKey, Err: fmt.Errorf("player_steam_id %q not found", steamID)}
+			}
+			if err != nil {
+				return 0, err
+			}
+			return p.ID, nil
+		})
+	}
+	return 0, nil
+}
+
+func (r *importRefs) player(ctx context.Context, key string, lookup func() (int64, error)) (int64, error) {
+	if cached, ok := r.players[key]; ok {
+		return cached, nil
+	}
+	id, err := lookup()
+	if err != nil {
+		return 0, err
+	}
+	r.players[key] = id
+	return id, nil
+}
+
+func (r *importRefs) tournamentDiscipline(ctx context.Context, tournamentID int64) (int64, error) {
+	if cached, ok := r.tournamentDisciplines[tournamentID]; ok {
+		return cached, nil
+	}
+	t, err := r.s.tournamentSvc.Get(ctx, tournamentID)
+	if err != nil {
+		return 0, err
+	}
+	r.tournamentDisciplines[tournamentID] = t.DisciplineID
+	return t.DisciplineID, nil
+}
+
+func (r *importRefs) matchDiscipline(ctx context.Context, matchID int64) (int64, error) {
+	if cached, ok := r.matchDisciplines[matchID]; ok {
+		return cached, nil
+	}
+	m, err := r.s.matchSvc.Get(ctx, matchID)
+	if err != nil {
+		return 0, err
+	}
+	disciplineID, err := r.tournamentDiscipline(ctx, m.TournamentID)
+	if err != nil {
+		return 0, err
+	}
+	r.matchDisciplines[matchID] = disciplineID
+	return disciplineID, nil
+}
+
+func (r *importRefs) gameDiscipline(ctx context.Context, gameID int64) (int64, error) {
+	if cached, ok := r.gameDisciplines[gameID]; ok {
+		return cached, nil
+	}
+	g, err := r.s.matchGameSvc.Get(ctx, gameID)
+	if err != nil {
+		return 0, err
+	}
+	disciplineID, err := r.matchDiscipline(ctx, g.MatchID)
+	if err != nil {
+		return 0, err
+	}
+	r.gameDisciplines[gameID] = disciplineID
+	return disciplineID, nil
+}
+
+// matchID resolves a match reference. Start times are compared as instants, so
+// an export in UTC finds matches stored in any zone.
+func (r *importRefs) matchID(ctx context.Context, id int64, ref matchRef) (int64, error) {
+	if id != 0 || strings.TrimSpace(ref.startTime) == "" {
+		return id, nil
+	}
+	tournamentID, err := r.tournamentID(ctx, 0, ref.tournamentName)
+	if err != nil {
+		return 0, err
+	}
+	if tournamentID == 0 {
+		return 0, requiredError("tournament_name")
+	}
+	start, err := parseDateTime("match_start_time", ref.startTime)
+	if err != nil {
+		return 0, err
+	}
+	discipline := func() (int64, error) { return r.tournamentDiscipline(ctx, tournamentID) }
+	team1ID, err := r.optionalTeamID(ctx, nil, ref.team1Tag, discipline)
+	if err != nil {
+		return 0, err
+	}
+	team2ID, err := r.optionalTeamID(ctx, nil, ref.team2Tag, discipline)
+	if err != nil {
+		return 0, err
+	}
+	matches, ok := r.tournamentMatches[tournamentID]
+	if !ok {
+		if matches, err = r.s.matchSvc.ListByTournament(ctx, tournamentID); err != nil {
+			return 0, err
+		}
+		r.tournamentMatches[tournamentID] = matches
+	}
+	var found []int64
+	for _, m := range matches {
+		if m.StartTime.Equal(*start) && sameTeam(m.Team1ID, team1ID) && sameTeam(m.Team2ID, team2ID) {
+			found = append(found, m.ID)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return 0, &FieldError{Field: "match_start_time", Code: ImportCodeForeignKey, Err: fmt.Errorf("no match of tournament %q starts at %s with these teams", ref.tournamentName, ref.startTime)}
+	case 1:
+	default:
+		return 0, &FieldError{Field: "match_start_time", Code: ImportCodeInvalid, Err: fmt.Errorf("match_start_time %s matches %d matches, use match_id", ref.startTime, len(found))}
+	}
+	return found[0], nil
+}
+
+// gameID resolves a game by its match reference and game_number.
+func (r *importRefs) gameID(ctx context.Context, id int64, ref matchRef, gameNumber int) (int64, error) {
+	if id != 0 || strings.TrimSpace(ref.startTime) == "" {
+		return id, nil
+	}
+	matchID, err := r.matchID(ctx, 0, ref)
+	if err != nil {
+		return 0, err
+	}
+	key := gameRef{matchID: matchID, gameNumber: gameNumber}
+	if cached, ok := r.games[key]; ok {
+		return cached, nil
+	}
+	g, err := r.s.matchGameSvc.GetByMatchNumber(ctx, matchID, gameNumber)
+	if errors.Is(err, repository.ErrMatchGameNotFound) {
+		return 0, &FieldError{Field: "game_number", Code: ImportCodeForeignKey, Err: fmt.Errorf("game %d of the match starting at %s not found", gameNumber, ref.startTime)}
+	}
+	if err != nil {
+		return 0, err
+	}
+	r.games[key] = g.ID
+	return g.ID, nil
+}
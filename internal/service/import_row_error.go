@@ -0,0 +1,95 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"db_course_project/internal/repository"
+)
+
+const (
+	ImportCodeParse      = "parse_error"
+	ImportCodeRequired   = "required"
+	ImportCodeUnique     = repository.ViolationUnique
+	ImportCodeForeignKey = repository.ViolationForeignKey
+	ImportCodeCheck      = repository.ViolationCheck
+	// ImportCodeInvalid covers the remaining rules checked by the services.
+	ImportCodeInvalid = "invalid"
+)
+
+// ImportRowError tells why one row of a batch failed. Row is the 1-based
+// position of the row in the batch; Line is set when the row came from a file
+// and could not be decoded.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Line    int    `json:"line,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// FieldError ties an error to the row field that caused it.
+type FieldError struct {
+	Field string
+	Code  string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// missingReferences maps the not-found errors of referenced records to the
+// field that pointed at them.
+var missingReferences = []struct {
+	err   error
+	field string
+}{
+	{repository.ErrDisciplineNotFound, "discipline_id"},
+	{repository.ErrTournamentNotFound, "tournament_id"},
+	{repository.ErrTeamNotFound, "team_id"},
+	{repository.ErrPlayerNotFound, "player_id"},
+	{repository.ErrMatchNotFound, "match_id"},
+	{repository.ErrMatchGameNotFound, "game_id"},
+}
+
+func newImportRowError(row int, err error) ImportRowError {
+	e := ImportRowError{Row: row, Code: ImportCodeInvalid, Message: err.Error()}
+	var decodeErr *RowDecodeError
+	if errors.As(err, &decodeErr) {
+		e.Line, e.Code = decodeErr.Line, ImportCodeParse
+	}
+	var fieldErr *FieldError
+	var requiredErr *RequiredError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &fieldErr):
+		e.Field, e.Code = fieldErr.Field, fieldErr.Code
+	case errors.As(err, &requiredErr):
+		e.Field, e.Code = strings.Join(requiredErr.Fields, ", "), ImportCodeRequired
+	case errors.As(err, &typeErr):
+		e.Field = typeErr.Field
+	}
+	if e.Code != ImportCodeInvalid {
+		return e
+	}
+	if v, ok := repository.ConstraintViolation(err); ok {
+		e.Field, e.Code, e.Message = v.Field, v.Code, v.Message()
+		if v.Code == repository.ViolationNotNull {
+			e.Code = ImportCodeRequired
+		}
+		return e
+	}
+	for _, ref := range missingReferences {
+		if errors.Is(err, ref.err) {
+			e.Field, e.Code = ref.field, ImportCodeForeignKey
+			break
+		}
+	}
+	return e
+}
@@ -3,12 +3,16 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 
 	"db_course_project/internal/models"
+	"db_course_project/internal/repository"
 )
 
 type PlayerImportInput struct {
@@ -33,55 +37,70 @@ type DisciplineImportInput struct {
 }
 
 type TeamImportInput struct {
-	Name         string   `json:"name" csv:"name"`
-	Tag          string   `json:"tag" csv:"tag"`
-	CountryCode  string   `json:"country_code" csv:"country_code"`
-	DisciplineID int64    `json:"discipline_id" csv:"discipline_id"`
-	LogoURL      *string  `json:"logo_url" csv:"logo_url"`
-	WorldRanking *float64 `json:"world_ranking" csv:"world_ranking"`
-	IsVerified   *bool    `json:"is_verified" csv:"is_verified"`
+	Name           string   `json:"name" csv:"name"`
+	Tag            string   `json:"tag" csv:"tag"`
+	CountryCode    string   `json:"country_code" csv:"country_code"`
+	DisciplineID   int64    `json:"discipline_id" csv:"discipline_id"`
+	DisciplineCode string   `json:"discipline_code" csv:"discipline_code"`
+	LogoURL        *string  `json:"logo_url" csv:"logo_url"`
+	WorldRanking   *float64 `json:"world_ranking" csv:"world_ranking"`
+	IsVerified     *bool    `json:"is_verified" csv:"is_verified"`
 }
 
 type TournamentImportInput struct {
-	DisciplineID  int64           `json:"discipline_id" csv:"discipline_id"`
-	Name          string          `json:"name" csv:"name"`
-	StartDate     string          `json:"start_date" csv:"start_date"`
-	EndDate       string          `json:"end_date" csv:"end_date"`
-	PrizePool     float64         `json:"prize_pool" csv:"prize_pool"`
-	Currency      string          `json:"currency" csv:"currency"`
-	Status        string          `json:"status" csv:"status"`
-	IsOnline      *bool           `json:"is_online" csv:"is_online"`
-	BracketConfig json.RawMessage `json:"bracket_config" swaggertype:"object" csv:"bracket_config"`
+	DisciplineID   int64           `json:"discipline_id" csv:"discipline_id"`
+	DisciplineCode string          `json:"discipline_code" csv:"discipline_code"`
+	Name           string          `json:"name" csv:"name"`
+	StartDate      string          `json:"start_date" csv:"start_date"`
+	EndDate        string          `json:"end_date" csv:"end_date"`
+	PrizePool      float64         `json:"prize_pool" csv:"prize_pool"`
+	Currency       string          `json:"currency" csv:"currency"`
+	Status         string          `json:"status" csv:"status"`
+	IsOnline       *bool           `json:"is_online" csv:"is_online"`
+	BracketConfig  json.RawMessage `json:"bracket_config" swaggertype:"object" csv:"bracket_config"`
+	MaxTeams       *int            `json:"max_teams" csv:"max_teams"`
 }
 
 type TournamentRegistrationImportInput struct {
-	TournamentID   int64           `json:"tournament_id" csv:"tournament_id"`
-	TeamID         int64           `json:"team_id" csv:"team_id"`
-	SeedNumber     *int            `json:"seed_number" csv:"seed_number"`
-	Status         string          `json:"status" csv:"status"`
-	ManagerContact *string         `json:"manager_contact" csv:"manager_contact"`
-	RosterSnapshot json.RawMessage `json:"roster_snapshot" swaggertype:"object" csv:"roster_snapshot"`
-	IsInvited      *bool           `json:"is_invited" csv:"is_invited"`
+	TournamentID   int64   `json:"tournament_id" csv:"tournament_id"`
+	TournamentName string  `json:"tournament_name" csv:"tournament_name"`
+	TeamID         int64   `json:"team_id" csv:"team_id"`
+	TeamTag        string  `json:"team_tag" csv:"team_tag"`
+	SeedNumber     *int    `json:"seed_number" csv:"seed_number"`
+	Status         string  `json:"status" csv:"status"`
+	ManagerContact *string `json:"manager_contact" csv:"manager_contact"`
+	IsInvited      *bool   `json:"is_invited" csv:"is_invited"`
 }
 
 type MatchImportInput struct {
-	TournamentID int64           `json:"tournament_id" csv:"tournament_id"`
-	Team1ID      *int64          `json:"team1_id" csv:"team1_id"`
-	Team2ID      *int64          `json:"team2_id" csv:"team2_id"`
-	StartTime    string          `json:"start_time" csv:"start_time"`
-	Format       string          `json:"format" csv:"format"`
-	Stage        *string         `json:"stage" csv:"stage"`
-	WinnerTeamID *int64          `json:"winner_team_id" csv:"winner_team_id"`
-	IsForfeit    *bool           `json:"is_forfeit" csv:"is_forfeit"`
-	MatchNotes   json.RawMessage `json:"match_notes" swaggertype:"object" csv:"match_notes"`
+	TournamentID   int64           `json:"tournament_id" csv:"tournament_id"`
+	TournamentName string          `json:"tournament_name" csv:"tournament_name"`
+	Team1ID        *int64          `json:"team1_id" csv:"team1_id"`
+	Team1Tag       string          `json:"team1_tag" csv:"team1_tag"`
+	Team2ID        *int64          `json:"team2_id" csv:"team2_id"`
+	Team2Tag       string          `json:"team2_tag" csv:"team2_tag"`
+	StartTime      string          `json:"start_time" csv:"start_time"`
+	Format         string          `json:"format" csv:"format"`
+	Stage          *string         `json:"stage" csv:"stage"`
+	WinnerTeamID   *int64          `json:"winner_team_id" csv:"winner_team_id"`
+	WinnerTeamTag  string          `json:"winner_team_tag" csv:"winner_team_tag"`
+	IsForfeit      *bool           `json:"is_forfeit" csv:"is_forfeit"`
+	MatchNotes     json.RawMessage `json:"match_notes" swaggertype:"object" csv:"match_notes"`
 }
 
+// MatchGameImportInput names its match by match_id or, as exports do, by
+// tournament_name, match_start_time and the match team tags.
 type MatchGameImportInput struct {
 	MatchID           int64           `json:"match_id" csv:"match_id"`
+	TournamentName    string          `json:"tournament_name" csv:"tournament_name"`
+	MatchStartTime    string          `json:"match_start_time" csv:"match_start_time"`
+	MatchTeam1Tag     string          `json:"match_team1_tag" csv:"match_team1_tag"`
+	MatchTeam2Tag     string          `json:"match_team2_tag" csv:"match_team2_tag"`
 	MapName           string          `json:"map_name" csv:"map_name"`
 	GameNumber        int             `json:"game_number" csv:"game_number"`
 	DurationSeconds   *int            `json:"duration_seconds" csv:"duration_seconds"`
 	WinnerTeamID      *int64          `json:"winner_team_id" csv:"winner_team_id"`
+	WinnerTeamTag     string          `json:"winner_team_tag" csv:"winner_team_tag"`
 	ScoreTeam1        *int            `json:"score_team1" csv:"score_team1"`
 	ScoreTeam2        *int            `json:"score_team2" csv:"score_team2"`
 	StartedAt         *string         `json:"started_at" csv:"started_at"`
@@ -89,22 +108,36 @@ type MatchGameImportInput struct {
 	PickBanPhase      json.RawMessage `json:"pick_ban_phase" swaggertype:"object" csv:"pick_ban_phase"`
 }
 
+// GamePlayerStatImportInput names its game by game_id or by the match
+// reference of MatchGameImportInput and game_number.
 type GamePlayerStatImportInput struct {
-	GameID      int64   `json:"game_id" csv:"game_id"`
-	PlayerID    int64   `json:"player_id" csv:"player_id"`
-	TeamID      *int64  `json:"team_id" csv:"team_id"`
-	Kills       int     `json:"kills" csv:"kills"`
-	Deaths      int     `json:"deaths" csv:"deaths"`
-	Assists     int     `json:"assists" csv:"assists"`
-	HeroName    *string `json:"hero_name" csv:"hero_name"`
-	DamageDealt int     `json:"damage_dealt" csv:"damage_dealt"`
-	GoldEarned  int     `json:"gold_earned" csv:"gold_earned"`
-	WasMVP      *bool   `json:"was_mvp" csv:"was_mvp"`
+	GameID         int64   `json:"game_id" csv:"game_id"`
+	TournamentName string  `json:"tournament_name" csv:"tournament_name"`
+	MatchStartTime string  `json:"match_start_time" csv:"match_start_time"`
+	MatchTeam1Tag  string  `json:"match_team1_tag" csv:"match_team1_tag"`
+	MatchTeam2Tag  string  `json:"match_team2_tag" csv:"match_team2_tag"`
+	GameNumber     int     `json:"game_number" csv:"game_number"`
+	PlayerID       int64   `json:"player_id" csv:"player_id"`
+	PlayerNickname string  `json:"player_nickname" csv:"player_nickname"`
+	PlayerSteamID  string  `json:"player_steam_id" csv:"player_steam_id"`
+	TeamID         *int64  `json:"team_id" csv:"team_id"`
+	TeamTag        string  `json:"team_tag" csv:"team_tag"`
+	Kills          int     `json:"kills" csv:"kills"`
+	Deaths         int     `json:"deaths" csv:"deaths"`
+	Assists        int     `json:"assists" csv:"assists"`
+	HeroName       *string `json:"hero_name" csv:"hero_name"`
+	DamageDealt    int     `json:"damage_dealt" csv:"damage_dealt"`
+	GoldEarned     int     `json:"gold_earned" csv:"gold_earned"`
+	WasMVP         *bool   `json:"was_mvp" csv:"was_mvp"`
 }
 
 type SquadMemberImportInput struct {
 	TeamID          int64    `json:"team_id" csv:"team_id"`
+	TeamTag         string   `json:"team_tag" csv:"team_tag"`
+	DisciplineCode  string   `json:"discipline_code" csv:"discipline_code"`
 	PlayerID        int64    `json:"player_id" csv:"player_id"`
+	PlayerNickname  string   `json:"player_nickname" csv:"player_nickname"`
+	PlayerSteamID   string   `json:"player_steam_id" csv:"player_steam_id"`
 	Role            string   `json:"role" csv:"role"`
 	IsStandin       *bool    `json:"is_standin" csv:"is_standin"`
 	JoinDate        *string  `json:"join_date" csv:"join_date"`
@@ -114,21 +147,121 @@ type SquadMemberImportInput struct {
 }
 
 type TeamProfileImportInput struct {
-	TeamID       int64   `json:"team_id" csv:"team_id"`
-	CoachName    *string `json:"coach_name" csv:"coach_name"`
-	SponsorInfo  *string `json:"sponsor_info" csv:"sponsor_info"`
-	Headquarters *string `json:"headquarters" csv:"headquarters"`
-	Website      *string `json:"website" csv:"website"`
-	ContactEmail *string `json:"contact_email" csv:"contact_email"`
+	TeamID         int64   `json:"team_id" csv:"team_id"`
+	TeamTag        string  `json:"team_tag" csv:"team_tag"`
+	DisciplineCode string  `json:"discipline_code" csv:"discipline_code"`
+	CoachName      *string `json:"coach_name" csv:"coach_name"`
+	SponsorInfo    *string `json:"sponsor_info" csv:"sponsor_info"`
+	Headquarters   *string `json:"headquarters" csv:"headquarters"`
+	Website        *string `json:"website" csv:"website"`
+	ContactEmail   *string `json:"contact_email" csv:"contact_email"`
 }
 
+// MatchDocumentInput is a whole series: the match, its games and the player
+// stats of every game. The match_id of games and the game_id of stats are
+// filled in by the import.
+type MatchDocumentInput struct {
+	MatchImportInput
+	Games []GameDocumentInput `json:"games"`
+}
+
+type GameDocumentInput struct {
+	MatchGameImportInput
+	PlayerStats []GamePlayerStatImportInput `json:"player_stats"`
+}
+
+// MatchDocumentResult lists the ids created by a match document import.
+type MatchDocumentResult struct {
+	MatchID      int64                `json:"match_id"`
+	WinnerTeamID *int64               `json:"winner_team_id"`
+	Games        []GameDocumentResult `json:"games"`
+}
+
+type GameDocumentResult struct {
+	GameID        int64   `json:"game_id"`
+	GameNumber    int     `json:"game_number"`
+	PlayerStatIDs []int64 `json:"player_stat_ids"`
+}
+
+var ErrSeriesWinnerMismatch = errors.New("winner_team_id does not match the result of the games")
+
+const (
+	OnConflictError  = "error"
+	OnConflictSkip   = "skip"
+	OnConflictUpdate = "update"
+)
+
+var ErrInvalidOnConflict = errors.New("on_conflict must be error, skip or update")
+
+// ImportOptions controls how a batch is applied. An atomic batch runs in a
+// single transaction that is rolled back when any row fails; the remaining rows
+// are still checked so the summary lists every failing row. A dry run goes
+// through the same checks, always rolls back and logs nothing.
+//
+// OnConflict decides what happens to a row whose natural key already exists:
+// players by nickname or steam_id, disciplines by code, teams by tag and
+// discipline, registrations by tournament and team, games by match and number,
+// player stats by game and player, team profiles by team. Tournaments, matches
+// and squad members have no natural key and are always inserted.
+//
+// Restore loads the rows of an export: new tournaments and registrations keep
+// their status instead of starting at the beginning of their lifecycle.
+type ImportOptions struct {
+	Atomic     bool
+	DryRun     bool
+	OnConflict string
+	Restore    bool
+
+	// quiet leaves failed rows out of batch_import_errors; retries of logged
+	// errors use it.
+	quiet bool
+	// rowOffset is added to row positions, for batches that continue an
+	// earlier one.
+	rowOffset int
+	// progress records the summary of a run in the transaction that commits
+	// its rows, so import jobs never keep rows without their progress.
+	progress func(ctx context.Context, summary ImportSummary) error
+}
+
+func (o ImportOptions) validate() error {
+	switch o.OnConflict {
+	case "", OnConflictError, OnConflictSkip, OnConflictUpdate:
+		return nil
+	}
+	return ErrInvalidOnConflict
+}
+
+// importChunk is the number of rows written by one multi-row insert.
+const importChunk = 1000
+
+// importMaxErrors caps the row errors kept in a summary; Failed keeps
+// counting past it.
+const importMaxErrors = 1000
+
+// ImportSummary reports a batch. In a dry run the counters tell what would
+// have happened.
 type ImportSummary struct {
-	Inserted int      `json:"inserted"`
-	Failed   int      `json:"failed"`
-	Errors   []string `json:"errors"`
+	Inserted   int              `json:"inserted"`
+	Updated    int              `json:"updated"`
+	Skipped    int              `json:"skipped"`
+	Failed     int              `json:"failed"`
+	RolledBack bool             `json:"rolled_back"`
+	DryRun     bool             `json:"dry_run"`
+	Errors     []ImportRowError `json:"errors"`
 }
+
+type importResult int
+
+const (
+	rowFailed importResult = iota
+	rowInserted
+	rowUpdated
+	rowSkipped
+)
+
 type ImportService struct {
-	db              *sqlx.DB
+	tx              *repository.TxManager
+	importErrors    repository.ImportErrorRepository
 	disciplineSvc   *DisciplineService
 	teamSvc         *TeamService
 	playerSvc       *PlayerService
@@ -141,9 +274,10 @@ type ImportService struct {
 	teamProfileSvc  *TeamProfileService
 }
 
-func NewImportService(db *sqlx.DB, disciplineSvc *DisciplineService, teamSvc *TeamService, playerSvc *PlayerService, tournamentSvc *TournamentService, registrationSvc *TournamentRegistrationService, matchSvc *MatchService, matchGameSvc *MatchGameService, statSvc *GamePlayerStatService, squadSvc *SquadMemberService, teamProfileSvc *TeamProfileService) *ImportService {
+func NewImportService(tx *repository.TxManager, importErrors repository.ImportErrorRepository, disciplineSvc *DisciplineService, teamSvc *TeamService, playerSvc *PlayerService, tournamentSvc *TournamentService, registrationSvc *TournamentRegistrationService, matchSvc *MatchService, matchGameSvc *MatchGameService, statSvc *GamePlayerStatService, squadSvc *SquadMemberService, teamProfileSvc *TeamProfileService) *ImportService {
 	return &ImportService{
-		db:              db,
+		tx:              tx,
+		importErrors:    importErrors,
 		disciplineSvc:   disciplineSvc,
 		teamSvc:         teamSvc,
 		playerSvc:       playerSvc,
@@ -157,26 +291,30 @@ func NewImportService(db *sqlx.DB, disciplineSvc *DisciplineService, teamSvc *Te
 	}
 }
 
-func (s *ImportService) ImportPlayers(ctx context.Context, source string, payload []PlayerImportInput) (ImportSummary, error) {
-	summary := ImportSummary{}
-	for _, row := range payload {
+func (s *ImportService) ImportPlayers(ctx context.Context, source string, rows iter.Seq2[PlayerImportInput, error], opts ImportOptions) (ImportSummary, error) {
+	return importRows(ctx, s, source, opts, rows, func(ctx context.Context, row PlayerImportInput) (importResult, error) {
 		player, err := s.toPlayer(row)
 		if err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
+			return rowFailed, err
 		}
-		if err := s.playerSvc.Create(ctx, player); err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
+		find := func() (*int64, error) {
+			existing, err := s.playerSvc.GetByNickname(ctx, strings.TrimSpace(player.Nickname))
+			if errors.Is(err, repository.ErrPlayerNotFound) && player.SteamID != nil {
+				existing, err = s.playerSvc.GetBySteamID(ctx, *player.SteamID)
+			}
+			return existingID(existing, err, repository.ErrPlayerNotFound, func(p *models.Player) int64 { return p.ID })
 		}
-		summary.Inserted++
-	}
-	return summary, nil
+		return upsert(opts.OnConflict, find,
+			func() error { return s.playerSvc.Create(ctx, player) },
+			func(id int64) error {
+				player.ID = id
+				return s.playerSvc.Update(ctx, player)
+			})
+	})
 }
 
-func (s *ImportService) ImportDisciplines(ctx context.Context, source string, payload []DisciplineImportInput) (ImportSummary, error) {
-	summary := ImportSummary{}
-	for _, row := range payload {
+func (s *ImportService) ImportDisciplines(ctx context.Context, source string, rows iter.Seq2[DisciplineImportInput, error], opts ImportOptions) (ImportSummary, error) {
+	return importRows(ctx, s, source, opts, rows, func(ctx context.Context, row DisciplineImportInput) (importResult, error) {
 		d := &models.Discipline{
 			Code:        row.Code,
 			Name:        row.Name,
@@ -193,23 +331,31 @@ func (s *ImportService) ImportDisciplines(ctx context.Context, source string, pa
 		if len(d.Metadata) == 0 {
 			d.Metadata = json.RawMessage(`{}`)
 		}
-		if err := s.disciplineSvc.Create(ctx, d); err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
+		find := func() (*int64, error) {
+			existing, err := s.disciplineSvc.GetByCode(ctx, strings.TrimSpace(d.Code))
+			return existingID(existing, err, repository.ErrDisciplineNotFound, func(d *models.Discipline) int64 { return d.ID })
 		}
-		summary.Inserted++
-	}
-	return summary, nil
+		return upsert(opts.OnConflict, find,
+			func() error { return s.disciplineSvc.Create(ctx, d) },
+			func(id int64) error {
+				d.ID = id
+				return s.disciplineSvc.Update(ctx, d)
+			})
+	})
 }
 
-func (s *ImportService) ImportTeams(ctx context.Context, source string, payload []TeamImportInput) (ImportSummary, error) {
-	summary := ImportSummary{}
-	for _, row := range payload {
+func (s *ImportService) ImportTeams(ctx context.Context, source string, rows iter.Seq2[TeamImportInput, error], opts ImportOptions) (ImportSummary, error) {
+	refs := s.newRefs()
+	return importRows(ctx, s, source, opts, rows, func(ctx context.Context, row TeamImportInput) (importResult, error) {
+		disciplineID, err := refs.disciplineID(ctx, row.DisciplineID, row.DisciplineCode)
+		if err != nil {
+			return rowFailed, err
+		}
 		team := &models.Team{
 			Name:         row.Name,
 			Tag:          row.Tag,
 			CountryCode:  row.CountryCode,
-			DisciplineID: row.DisciplineID,
+			DisciplineID: disciplineID,
 			LogoURL:      row.LogoURL,
 			WorldRanking: 0,
 			IsVerified:   false,
@@ -220,34 +366,40 @@ func (s *ImportService) ImportTeams(ctx context.Context, source string, payload
 		if row.IsVerified != nil {
 			team.IsVerified = *row.IsVerified
 		}
-		if err := s.teamSvc.Create(ctx, team); err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
+		find := func() (*int64, error) {
+			existing, err := s.teamSvc.GetByTag(ctx, strings.TrimSpace(team.Tag), team.DisciplineID)
+			return existingID(existing, err, repository.ErrTeamNotFound, func(t *models.Team) int64 { return t.ID })
 		}
-		summary.Inserted++
-	}
-	return summary, nil
+		return upsert(opts.OnConflict, find,
+			func() error { return s.teamSvc.Create(ctx, team) },
+			func(id int64) error {
+				team.ID = id
+				return s.teamSvc.Update(ctx, team)
+			})
+	})
 }
 
-func (s *ImportService) ImportTournaments(ctx context.Context, source string, payload []TournamentImportInput) (ImportSummary, error) {
-	summary := ImportSummary{}
-	for _, row := range payload {
-		start, err := parseDate(row.StartDate)
+func (s *ImportService) ImportTournaments(ctx context.Context, source string, rows iter.Seq2[TournamentImportInput, error], opts ImportOptions) (ImportSummary, error) {
+	refs := s.newRefs()
+	return importRows(ctx, s, source, opts, rows, func(ctx context.Context, row TournamentImportInput) (importResult, error) {
+		disciplineID, err := refs.disciplineID(ctx, row.DisciplineID, row.DisciplineCode)
 		if err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
+			return rowFailed, err
 		}
-		end, err := parseDate(row.EndDate)
+		start, err := parseDate("start_date", row.StartDate)
 		if err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
+			return rowFailed, err
+		}
+		end, err := parseDate("end_date", row.EndDate)
+		if err != nil {
+			return rowFailed, err
 		}
 		isOnline := false
 		if row.IsOnline != nil {
 			isOnline = *row.IsOnline
 		}
 		t := &models.Tournament{
-			DisciplineID:  row.DisciplineID,
+			DisciplineID:  disciplineID,
 			Name:          row.Name,
 			StartDate:     *start,
 			EndDate:       *end,
@@ -256,170 +408,223 @@ func (s *ImportService) ImportTournaments(ctx context.Context, source string, pa
 			Status:        row.Status,
 			IsOnline:      isOnline,
 			BracketConfig: row.BracketConfig,
+			MaxTeams:      row.MaxTeams,
 		}
-		if err := s.tournamentSvc.Create(ctx, t); err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
+		if opts.Restore {
+			return rowInserted, s.tournamentSvc.Restore(ctx, t)
 		}
-		summary.Inserted++
-	}
-	return summary, nil
+		return rowInserted, s.tournamentSvc.Create(ctx, t)
+	})
 }
 
-func (s *ImportService) ImportTournamentRegistrations(ctx context.Context, source string, payload []TournamentRegistrationImportInput) (ImportSummary, error) {
-	summary := ImportSummary{}
-	for _, row := range payload {
+func (s *ImportService) ImportTournamentRegistrations(ctx context.Context, source string, rows iter.Seq2[TournamentRegistrationImportInput, error], opts ImportOptions) (ImportSummary, error) {
+	refs := s.newRefs()
+	return importRows(ctx, s, source, opts, rows, func(ctx context.Context, row TournamentRegistrationImportInput) (importResult, error) {
+		tournamentID, err := refs.tournamentID(ctx, row.TournamentID, row.TournamentName)
+		if err != nil {
+			return rowFailed, err
+		}
+		teamID, err := refs.teamID(ctx, row.TeamID, row.TeamTag, func() (int64, error) {
+			return refs.tournamentDiscipline(ctx, tournamentID)
+		})
+		if err != nil {
+			return rowFailed, err
+		}
 		status := row.Status
 		isInvited := false
 		if row.IsInvited != nil {
 			isInvited = *row.IsInvited
 		}
 		reg := &models.TournamentRegistration{
-			TournamentID:   row.TournamentID,
-			TeamID:         row.TeamID,
+			TournamentID:   tournamentID,
+			TeamID:         teamID,
 			SeedNumber:     row.SeedNumber,
 			Status:         status,
 			ManagerContact: row.ManagerContact,
-			RosterSnapshot: row.RosterSnapshot,
 			IsInvited:      isInvited,
 		}
-		if err := s.registrationSvc.Create(ctx, reg); err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
+		find := func() (*int64, error) {
+			existing, err := s.registrationSvc.GetByTournamentTeam(ctx, reg.TournamentID, reg.TeamID)
+			return existingID(existing, err, repository.ErrTournamentRegistrationNotFound, func(r *models.TournamentRegistration) int64 { return r.ID })
 		}
-		summary.Inserted++
-	}
-	return summary, nil
+		create := s.registrationSvc.Create
+		if opts.Restore {
+			create = s.registrationSvc.Restore
+		}
+		return upsert(opts.OnConflict, find,
+			func() error { return create(ctx, reg) },
+			func(id int64) error {
+				reg.ID = id
+				return s.registrationSvc.Update(ctx, reg)
+			})
+	})
 }
 
-func (s *ImportService) ImportMatches(ctx context.Context, source string, payload []MatchImportInput) (ImportSummary, error) {
-	summary := ImportSummary{}
-	for _, row := range payload {
-		start, err := parseDateTime(row.StartTime)
+func (s *ImportService) ImportMatches(ctx context.Context, source string, rows iter.Seq2[MatchImportInput, error], opts ImportOptions) (ImportSummary, error) {
+	refs := s.newRefs()
+	return importRows(ctx, s, source, opts, rows, func(ctx context.Context, row MatchImportInput) (importResult, error) {
+		m, err := s.toMatch(ctx, refs, row)
 		if err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
+			return rowFailed, err
 		}
-		isForfeit := false
-		if row.IsForfeit != nil {
-			isForfeit = *row.IsForfeit
-		}
-		var notes *json.RawMessage
-		if row.MatchNotes != nil {
-			n := row.MatchNotes
-			notes = &n
-		}
-		m := &models.Match{
-			TournamentID: row.TournamentID,
-			Team1ID:      row.Team1ID,
-			Team2ID:      row.Team2ID,
-			StartTime:    *start,
-			Format:       row.Format,
-			Stage:        row.Stage,
-			WinnerTeamID: row.WinnerTeamID,
-			IsForfeit:    isForfeit,
-			MatchNotes:   notes,
-		}
-		if err := s.matchSvc.Create(ctx, m); err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
+		return rowInserted, s.matchSvc.Create(ctx, m)
+	})
+}
+
+func (s *ImportService) ImportMatchGames(ctx context.Context, source string, rows iter.Seq2[MatchGameImportInput, error], opts ImportOptions) (ImportSummary, error) {
+	refs := s.newRefs()
+	return importRows(ctx, s, source, opts, rows, func(ctx context.Context, row MatchGameImportInput) (importResult, error) {
+		g, err := s.toMatchGame(ctx, refs, row)
+		if err != nil {
+			return rowFailed, err
 		}
-		summary.Inserted++
+		find := func() (*int64, error) {
+			existing, err := s.matchGameSvc.GetByMatchNumber(ctx, g.MatchID, g.GameNumber)
+			return existingID(existing, err, repository.ErrMatchGameNotFound, func(g *models.MatchGame) int64 { return g.ID })
+		}
+		return upsert(opts.OnConflict, find,
+			func() error { return s.matchGameSvc.Create(ctx, g) },
+			func(id int64) error {
+				g.ID = id
+				return s.matchGameSvc.Update(ctx, g)
+			})
+	})
+}
+
+// ImportGamePlayerStats writes new stat lines in chunks of importChunk with
+// one multi-row insert each. Upserting modes look every row up and go row by
+// row.
+func (s *ImportService) ImportGamePlayerStats(ctx context.Context, source string, rows iter.Seq2[GamePlayerStatImportInput, error], opts ImportOptions) (ImportSummary, error) {
+	if opts.OnConflict == "" || opts.OnConflict == OnConflictError {
+		return s.importStatsBulk(ctx, source, rows, opts)
 	}
-	return summary, nil
+	refs := s.newRefs()
+	return importRows(ctx, s, source, opts, rows, func(ctx context.Context, row GamePlayerStatImportInput) (importResult, error) {
+		stat, err := s.toGamePlayerStat(ctx, refs, row)
+		if err != nil {
+			return rowFailed, err
+		}
+		find := func() (*int64, error) {
+			existing, err := s.statSvc.GetByGamePlayer(ctx, stat.GameID, stat.PlayerID)
+			return existingID(existing, err, repository.ErrGamePlayerStatNotFound, func(st *models.GamePlayerStat) int64 { return st.ID })
+		}
+		return upsert(opts.OnConflict, find,
+			func() error { return s.statSvc.Create(ctx, stat) },
+			func(id int64) error {
+				stat.ID = id
+				return s.statSvc.Update(ctx, stat)
+			})
+	})
 }
 
-func (s *ImportService) ImportMatchGames(ctx context.Context, source string, payload []MatchGameImportInput) (ImportSummary, error) {
-	summary := ImportSummary{}
-	for _, row := range payload {
-		var startedAt *time.Time
-		if row.StartedAt != nil && *row.StartedAt != "" {
-			parsed, err := parseDateTime(*row.StartedAt)
-			if err != nil {
-				s.recordError(ctx, source, row, err, &summary)
-				continue
-			}
-			startedAt = parsed
-		}
-		hasTech := false
-		if row.HadTechnicalPause != nil {
-			hasTech = *row.HadTechnicalPause
-		}
-		g := &models.MatchGame{
-			MatchID:           row.MatchID,
-			MapName:           row.MapName,
-			GameNumber:        row.GameNumber,
-			DurationSeconds:   row.DurationSeconds,
-			WinnerTeamID:      row.WinnerTeamID,
-			ScoreTeam1:        row.ScoreTeam1,
-			ScoreTeam2:        row.ScoreTeam2,
-			StartedAt:         startedAt,
-			HadTechnicalPause: hasTech,
-			PickBanPhase:      row.PickBanPhase,
+// importStatsBulk checks each row like a single insert, with lookups cached
+// for the batch, and writes the checked rows importChunk at a time. A chunk the
+// database rejects is written again row by row, so only the offending rows
+// fail.
+func (s *ImportService) importStatsBulk(ctx context.Context, source string, rows iter.Seq2[GamePlayerStatImportInput, error], opts ImportOptions) (ImportSummary, error) {
+	run, err := s.beginImport(ctx, source, opts)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+	defer run.abort()
+	refs := s.newRefs()
+	batch := s.statSvc.newBatch()
+	pendingIndexes := make([]int, 0, importChunk)
+	pendingRows := make([]GamePlayerStatImportInput, 0, importChunk)
+	pending := make([]*models.GamePlayerStat, 0, importChunk)
+
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
 		}
-		if err := s.matchGameSvc.Create(ctx, g); err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
+		rowErr, err := run.exec(func(ctx context.Context) error { return batch.insertMany(ctx, pending) })
+		if err != nil {
+			return err
+		}
+		if rowErr == nil {
+			run.summary.Inserted += len(pending)
+		} else {
+			for i, stat := range pending {
+				failed := run.summary.Failed
+				if err := run.row(pendingIndexes[i], pendingRows[i], func(ctx context.Context) (importResult, error) {
+					return rowInserted, batch.insert(ctx, stat)
+				}); err != nil {
+					return err
+				}
+				if run.summary.Failed > failed {
+					batch.release(stat)
+				}
+			}
 		}
-		summary.Inserted++
+		pendingIndexes, pendingRows, pending = pendingIndexes[:0], pendingRows[:0], pending[:0]
+		batch.trim()
+		return nil
 	}
-	return summary, nil
-}
 
-func (s *ImportService) ImportGamePlayerStats(ctx context.Context, source string, payload []GamePlayerStatImportInput) (ImportSummary, error) {
-	summary := ImportSummary{}
-	for _, row := range payload {
-		stat := &models.GamePlayerStat{
-			GameID:      row.GameID,
-			PlayerID:    row.PlayerID,
-			TeamID:      row.TeamID,
-			Kills:       row.Kills,
-			Deaths:      row.Deaths,
-			Assists:     row.Assists,
-			HeroName:    row.HeroName,
-			DamageDealt: row.DamageDealt,
-			GoldEarned:  row.GoldEarned,
-			WasMVP:      false,
+	for row, err := range rows {
+		index := run.next()
+		if err != nil {
+			if err := run.decodeFailed(index, row, err); err != nil {
+				return run.summary, err
+			}
+			continue
 		}
-		if row.WasMVP != nil {
-			stat.WasMVP = *row.WasMVP
+		stat, err := s.toGamePlayerStat(run.txCtx, refs, row)
+		if err == nil {
+			err = batch.check(run.txCtx, stat)
 		}
-		if err := s.statSvc.Create(ctx, stat); err != nil {
-			s.recordError(ctx, source, row, err, &summary)
+		if err != nil {
+			run.fail(index, row, err)
 			continue
 		}
-		summary.Inserted++
+		pendingIndexes = append(pendingIndexes, index)
+		pendingRows, pending = append(pendingRows, row), append(pending, stat)
+		if len(pending) == importChunk {
+			if err := flush(); err != nil {
+				return run.summary, err
+			}
+		}
 	}
-	return summary, nil
+	if err := flush(); err != nil {
+		return run.summary, err
+	}
+	return run.finish()
 }
 
-func (s *ImportService) ImportSquadMembers(ctx context.Context, source string, payload []SquadMemberImportInput) (ImportSummary, error) {
-	summary := ImportSummary{}
-	for _, row := range payload {
+func (s *ImportService) ImportSquadMembers(ctx context.Context, source string, rows iter.Seq2[SquadMemberImportInput, error], opts ImportOptions) (ImportSummary, error) {
+	refs := s.newRefs()
+	return importRows(ctx, s, source, opts, rows, func(ctx context.Context, row SquadMemberImportInput) (importResult, error) {
+		teamID, err := refs.teamID(ctx, row.TeamID, row.TeamTag, func() (int64, error) {
+			return refs.disciplineID(ctx, 0, row.DisciplineCode)
+		})
+		if err != nil {
+			return rowFailed, err
+		}
+		playerID, err := refs.playerID(ctx, row.PlayerID, row.PlayerNickname, row.PlayerSteamID)
+		if err != nil {
+			return rowFailed, err
+		}
 		joinDate := time.Time{}
 		if row.JoinDate != nil && *row.JoinDate != "" {
-			parsed, err := parseDate(*row.JoinDate)
+			parsed, err := parseDate("join_date", *row.JoinDate)
 			if err != nil {
-				s.recordError(ctx, source, row, err, &summary)
-				continue
+				return rowFailed, err
 			}
 			joinDate = *parsed
 		}
 		var contract *time.Time
 		if row.ContractEndDate != nil && *row.ContractEndDate != "" {
-			parsed, err := parseDate(*row.ContractEndDate)
+			parsed, err := parseDate("contract_end_date", *row.ContractEndDate)
 			if err != nil {
-				s.recordError(ctx, source, row, err, &summary)
-				continue
+				return rowFailed, err
 			}
 			contract = parsed
 		}
 		var leave *time.Time
 		if row.LeaveDate != nil && *row.LeaveDate != "" {
-			parsed, err := parseDate(*row.LeaveDate)
+			parsed, err := parseDate("leave_date", *row.LeaveDate)
 			if err != nil {
-				s.recordError(ctx, source, row, err, &summary)
-				continue
+				return rowFailed, err
 			}
 			leave = parsed
 		}
@@ -428,8 +633,8 @@ func (s *ImportService) ImportSquadMembers(ctx context.Context, source string, p
 			isStandin = *row.IsStandin
 		}
 		m := &models.SquadMember{
-			TeamID:          row.TeamID,
-			PlayerID:        row.PlayerID,
+			TeamID:          teamID,
+			PlayerID:        playerID,
 			Role:            row.Role,
 			IsStandin:       isStandin,
 			JoinDate:        joinDate,
@@ -437,78 +642,531 @@ func (s *ImportService) ImportSquadMembers(ctx context.Context, source string, p
 			LeaveDate:       leave,
 			SalaryMonthly:   row.SalaryMonthly,
 		}
-		if err := s.squadSvc.Create(ctx, m); err != nil {
-			s.recordError(ctx, source, row, err, &summary)
-			continue
-		}
-		summary.Inserted++
-	}
-	return summary, nil
+		return rowInserted, s.squadSvc.Create(ctx, m)
+	})
 }
 
-func (s *ImportService) ImportTeamProfiles(ctx context.Context, source string, payload []TeamProfileImportInput) (ImportSummary, error) {
-	summary := ImportSummary{}
-	for _, row := range payload {
+func (s *ImportService) ImportTeamProfiles(ctx context.Context, source string, rows iter.Seq2[TeamProfileImportInput, error], opts ImportOptions) (ImportSummary, error) {
+	refs := s.newRefs()
+	return importRows(ctx, s, source, opts, rows, func(ctx context.Context, row TeamProfileImportInput) (importResult, error) {
+		teamID, err := refs.teamID(ctx, row.TeamID, row.TeamTag, func() (int64, error) {
+			return refs.disciplineID(ctx, 0, row.DisciplineCode)
+		})
+		if err != nil {
+			return rowFailed, err
+		}
 		profile := &models.TeamProfile{
-			TeamID:       row.TeamID,
+			TeamID:       teamID,
 			CoachName:    row.CoachName,
 			SponsorInfo:  row.SponsorInfo,
 			Headquarters: row.Headquarters,
 			Website:      row.Website,
 			ContactEmail: row.ContactEmail,
 		}
-		if err := s.teamProfileSvc.Create(ctx, profile); err != nil {
-			s.recordError(ctx, source, row, err, &summary)
+		find := func() (*int64, error) {
+			existing, err := s.teamProfileSvc.Get(ctx, profile.TeamID)
+			return existingID(existing, err, repository.ErrTeamProfileNotFound, func(p *models.TeamProfile) int64 { return p.TeamID })
+		}
+		return upsert(opts.OnConflict, find,
+			func() error { return s.teamProfileSvc.Create(ctx, profile) },
+			func(int64) error { return s.teamProfileSvc.Update(ctx, profile) })
+	})
+}
+
+// ImportMatchDocument inserts a match with its games and their player stats in
+// one transaction. When games are given and the match is not a forfeit, the
+// winner is derived from them by the series rules; a winner_team_id in the
+// document has to agree with it.
+func (s *ImportService) ImportMatchDocument(ctx context.Context, source string, doc MatchDocumentInput) (*MatchDocumentResult, error) {
+	res, err := s.saveMatchDocument(ctx, doc)
+	if err != nil {
+		s.logError(ctx, source, doc, newImportRowError(1, err))
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *ImportService) saveMatchDocument(ctx context.Context, doc MatchDocumentInput) (*MatchDocumentResult, error) {
+	var res *MatchDocumentResult
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = s.importMatchDocument(ctx, doc)
+		return err
+	})
+	return res, err
+}
+
+func (s *ImportService) importMatchDocument(ctx context.Context, doc MatchDocumentInput) (*MatchDocumentResult, error) {
+	refs := s.newRefs()
+	m, err := s.toMatch(ctx, refs, doc.MatchImportInput)
+	if err != nil {
+		return nil, err
+	}
+	declared := m.WinnerTeamID
+	derived := len(doc.Games) > 0 && !m.IsForfeit
+	if derived {
+		m.WinnerTeamID = nil
+	}
+	if err := s.matchSvc.Create(ctx, m); err != nil {
+		return nil, err
+	}
+
+	res := &MatchDocumentResult{MatchID: m.ID, WinnerTeamID: m.WinnerTeamID, Games: []GameDocumentResult{}}
+	for _, game := range doc.Games {
+		game.MatchID = m.ID
+		g, err := s.toMatchGame(ctx, refs, game.MatchGameImportInput)
+		if err != nil {
+			return nil, fmt.Errorf("game %d: %w", game.GameNumber, err)
+		}
+		if err := s.matchGameSvc.Create(ctx, g); err != nil {
+			return nil, fmt.Errorf("game %d: %w", game.GameNumber, err)
+		}
+		created := GameDocumentResult{GameID: g.ID, GameNumber: g.GameNumber, PlayerStatIDs: []int64{}}
+		for _, stat := range game.PlayerStats {
+			stat.GameID = g.ID
+			st, err := s.toGamePlayerStat(ctx, refs, stat)
+			if err != nil {
+				return nil, fmt.Errorf("game %d: %w", game.GameNumber, err)
+			}
+			if err := s.statSvc.Create(ctx, st); err != nil {
+				return nil, fmt.Errorf("game %d: %w", game.GameNumber, err)
+			}
+			created.PlayerStatIDs = append(created.PlayerStatIDs, st.ID)
+		}
+		res.Games = append(res.Games, created)
+	}
+	if len(doc.Games) == 0 {
+		return res, nil
+	}
+
+	// Synced once more so the rating replay sees the lineups of every game.
+	if err := s.matchSvc.SyncSeries(ctx, m.ID); err != nil {
+		return nil, err
+	}
+	saved, err := s.matchSvc.Get(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	if derived && declared != nil && !sameTeam(declared, saved.WinnerTeamID) {
+		return nil, ErrSeriesWinnerMismatch
+	}
+	res.WinnerTeamID = saved.WinnerTeamID
+	return res, nil
+}
+
+// RowsOf turns a decoded batch into a row sequence for the Import methods.
+func RowsOf[T any](payload []T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, row := range payload {
+			if !yield(row, nil) {
+				return
+			}
+		}
+	}
+}
+
+// RowDecodeError is yielded by a row sequence for a row that could not be
+// decoded. The import counts it as a failed row and goes on with the next one;
+// any other error yielded ends the import.
+type RowDecodeError struct {
+	Line int
+	Row  any
+	Err  error
+}
+
+func (e *RowDecodeError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowDecodeError) Unwrap() error {
+	return e.Err
+}
+
+func importRows[T any](ctx context.Context, s *ImportService, source string, opts ImportOptions, rows iter.Seq2[T, error], insert func(context.Context, T) (importResult, error)) (ImportSummary, error) {
+	run, err := s.beginImport(ctx, source, opts)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+	defer run.abort()
+	for row, err := range rows {
+		index := run.next()
+		if err != nil {
+			if err := run.decodeFailed(index, row, err); err != nil {
+				return run.summary, err
+			}
 			continue
 		}
-		summary.Inserted++
+		if err := run.row(index, row, func(ctx context.Context) (importResult, error) { return insert(ctx, row) }); err != nil {
+			return run.summary, err
+		}
 	}
-	return summary, nil
+	return run.finish()
 }
-func (s *ImportService) recordError(ctx context.Context, source string, row any, err error, summary *ImportSummary) {
-	summary.Failed++
-	summary.Errors = append(summary.Errors, err.Error())
-	s.logError(ctx, source, row, err)
+
+// importRun applies the rows of one batch. Atomic batches and dry runs share a
+// transaction in which every row runs in a savepoint.
+type importRun struct {
+	s       *ImportService
+	ctx     context.Context
+	txCtx   context.Context
+	tx      *sqlx.Tx
+	source  string
+	opts    ImportOptions
+	summary ImportSummary
+	rows    int
+	ratings *deferredRatings
 }
 
-func (s *ImportService) logError(ctx context.Context, source string, row any, logErr error) {
+func (s *ImportService) beginImport(ctx context.Context, source string, opts ImportOptions) (*importRun, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	run := &importRun{s: s, ctx: ctx, source: source, opts: opts}
+	// Rating pools are replayed once at the end instead of after every match.
+	deferred, ratings := deferRatings(ctx)
+	run.txCtx, run.ratings = deferred, ratings
+	if opts.Atomic || opts.DryRun || opts.progress != nil {
+		tx, err := s.tx.Begin(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		run.tx = tx
+		run.txCtx = repository.WithTx(deferred, tx)
+	}
+	return run, nil
+}
+
+// exec runs fn, inside a savepoint when the run has a transaction or joins
+// the one of its caller. rowErr is the error of fn, err a failure of the
+// transaction itself.
+func (r *importRun) exec(fn func(ctx context.Context) error) (rowErr, err error) {
+	tx := r.tx
+	if tx == nil {
+		tx, _ = repository.TxFrom(r.txCtx)
+	}
+	if tx == nil {
+		return fn(r.txCtx), nil
+	}
+	return savepoint(r.txCtx, tx, func() error { return fn(r.txCtx) })
+}
+
+// next returns the position of the next row of the batch.
+func (r *importRun) next() int {
+	r.rows++
+	return r.opts.rowOffset + r.rows
+}
+
+func (r *importRun) row(index int, row any, insert func(ctx context.Context) (importResult, error)) error {
+	var res importResult
+	rowErr, err := r.exec(func(ctx context.Context) (err error) {
+		res, err = insert(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if rowErr != nil {
+		r.fail(index, row, rowErr)
+		return nil
+	}
+	r.summary.count(res)
+	return nil
+}
+
+// fail counts a failed row. Errors of atomic runs are logged outside their
+// transaction, so they survive its rollback; other runs log them in theirs,
+// so they commit with the rows. Dry runs and quiet runs log nothing.
+func (r *importRun) fail(index int, row any, err error) {
+	rowErr := newImportRowError(index, err)
+	r.summary.fail(rowErr)
+	if r.opts.DryRun || r.opts.quiet {
+		return
+	}
+	var decodeErr *RowDecodeError
+	if errors.As(err, &decodeErr) {
+		row = decodeErr.Row
+	}
+	ctx := r.ctx
+	if r.tx != nil && !r.opts.Atomic {
+		ctx = repository.WithTx(r.ctx, r.tx)
+	}
+	r.s.logError(ctx, r.source, row, rowErr)
+}
+
+func (r *importRun) decodeFailed(index int, row any, err error) error {
+	var decodeErr *RowDecodeError
+	if !errors.As(err, &decodeErr) {
+		return err
+	}
+	r.fail(index, row, err)
+	return nil
+}
+
+// finish commits or rolls back the run. A run with a transaction that is not
+// atomic commits the rows that succeeded; their failures were rolled back to
+// a savepoint.
+func (r *importRun) finish() (ImportSummary, error) {
+	if r.tx == nil {
+		return r.summary, r.s.matchSvc.ratings.replayDeferred(r.ctx, r.ratings)
+	}
+	if r.opts.DryRun {
+		r.summary.DryRun = true
+		return r.summary, r.rollback()
+	}
+	if r.opts.Atomic && r.summary.Failed > 0 {
+		r.summary.Inserted, r.summary.Updated = 0, 0
+		r.summary.RolledBack = true
+		return r.summary, r.rollback()
+	}
+	ctx := repository.WithTx(r.ctx, r.tx)
+	if err := r.s.matchSvc.ratings.replayDeferred(ctx, r.ratings); err != nil {
+		return r.summary, err
+	}
+	if r.opts.progress != nil {
+		if err := r.opts.progress(ctx, r.summary); err != nil {
+			return r.summary, err
+		}
+	}
+	return r.summary, r.tx.Commit()
+}
+
+// rollback rolls the run back. Nothing of it is kept, so its progress is
+// recorded in a transaction of its own.
+func (r *importRun) rollback() error {
+	if err := r.tx.Rollback(); err != nil {
+		return err
+	}
+	if r.opts.progress == nil {
+		return nil
+	}
+	return r.s.tx.Do(r.ctx, func(ctx context.Context) error {
+		return r.opts.progress(ctx, r.summary)
+	})
+}
+
+// abort rolls back a transaction that finish did not get to.
+func (r *importRun) abort() {
+	if r.tx != nil {
+		_ = r.tx.Rollback()
+	}
+}
+
+// savepoint runs fn so that a failing row leaves the surrounding transaction
+// usable. rowErr is the error of fn, err a failure of the transaction itself.
+func savepoint(ctx context.Context, tx *sqlx.Tx, fn func() error) (rowErr, err error) {
+	if _, err := tx.ExecContext(ctx, `SAVEPOINT import_row`); err != nil {
+		return nil, err
+	}
+	if rowErr := fn(); rowErr != nil {
+		if _, err := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT import_row`); err != nil {
+			return nil, err
+		}
+		return rowErr, nil
+	}
+	_, err = tx.ExecContext(ctx, `RELEASE SAVEPOINT import_row`)
+	return nil, err
+}
+
+// upsert inserts a row or applies the on_conflict mode when find reports an
+// existing record with the same natural key. The lookup is skipped in the
+// default error mode, where the unique constraint rejects duplicates.
+func upsert(mode string, find func() (*int64, error), create func() error, update func(id int64) error) (importResult, error) {
+	if mode == "" || mode == OnConflictError {
+		return rowInserted, create()
+	}
+	existing, err := find()
+	if err != nil {
+		return rowFailed, err
+	}
+	switch {
+	case existing == nil:
+		return rowInserted, create()
+	case mode == OnConflictSkip:
+		return rowSkipped, nil
+	default:
+		return rowUpdated, update(*existing)
+	}
+}
+
+// existingID returns the id of a record looked up by natural key, or nil when
+// the lookup failed with notFound.
+func existingID[T any](v *T, err, notFound error, id func(*T) int64) (*int64, error) {
+	if errors.Is(err, notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	n := id(v)
+	return &n, nil
+}
+
+func (s *ImportSummary) count(res importResult) {
+	switch res {
+	case rowInserted:
+		s.Inserted++
+	case rowUpdated:
+		s.Updated++
+	case rowSkipped:
+		s.Skipped++
+	}
+}
+
+func (s *ImportSummary) fail(rowErr ImportRowError) {
+	s.Failed++
+	if len(s.Errors) < importMaxErrors {
+		s.Errors = append(s.Errors, rowErr)
+	}
+}
+
+func (s *ImportService) logError(ctx context.Context, source string, row any, rowErr ImportRowError) {
 	rowData, _ := json.Marshal(row)
-	_, _ = s.db.ExecContext(ctx,
-		`INSERT INTO batch_import_errors (source, row_data, error_message) VALUES ($1, $2::jsonb, $3)`,
-		source,
-		string(rowData),
-		logErr.Error(),
-	)
+	_ = s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.importErrors.Log(ctx, source, rowData, rowErr.Code, rowErr.Field, rowErr.Message, rowErr.Row, rowErr.Line)
+	})
 }
 
-func parseDate(value string) (*time.Time, error) {
-	if value == "" {
-		return nil, fmt.Errorf("date is required")
+func (s *ImportService) toMatch(ctx context.Context, refs *importRefs, row MatchImportInput) (*models.Match, error) {
+	tournamentID, err := refs.tournamentID(ctx, row.TournamentID, row.TournamentName)
+	if err != nil {
+		return nil, err
 	}
-	t, err := time.Parse("2006-01-02", value)
+	discipline := func() (int64, error) { return refs.tournamentDiscipline(ctx, tournamentID) }
+	team1ID, err := refs.optionalTeamID(ctx, row.Team1ID, row.Team1Tag, discipline)
 	if err != nil {
 		return nil, err
 	}
-	return &t, nil
+	team2ID, err := refs.optionalTeamID(ctx, row.Team2ID, row.Team2Tag, discipline)
+	if err != nil {
+		return nil, err
+	}
+	winnerID, err := refs.optionalTeamID(ctx, row.WinnerTeamID, row.WinnerTeamTag, discipline)
+	if err != nil {
+		return nil, err
+	}
+	start, err := parseDateTime("start_time", row.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	isForfeit := false
+	if row.IsForfeit != nil {
+		isForfeit = *row.IsForfeit
+	}
+	var notes *json.RawMessage
+	if row.MatchNotes != nil {
+		n := row.MatchNotes
+		notes = &n
+	}
+	m := &models.Match{
+		TournamentID: tournamentID,
+		Team1ID:      team1ID,
+		Team2ID:      team2ID,
+		StartTime:    *start,
+		Format:       row.Format,
+		Stage:        row.Stage,
+		WinnerTeamID: winnerID,
+		IsForfeit:    isForfeit,
+		MatchNotes:   notes,
+	}
+	return m, nil
 }
 
-func parseDateTime(value string) (*time.Time, error) {
-	if value == "" {
-		return nil, fmt.Errorf("datetime is required")
+func (s *ImportService) toMatchGame(ctx context.Context, refs *importRefs, row MatchGameImportInput) (*models.MatchGame, error) {
+	matchID, err := refs.matchID(ctx, row.MatchID, matchRef{row.TournamentName, row.MatchStartTime, row.MatchTeam1Tag, row.MatchTeam2Tag})
+	if err != nil {
+		return nil, err
+	}
+	winnerID, err := refs.optionalTeamID(ctx, row.WinnerTeamID, row.WinnerTeamTag, func() (int64, error) {
+		return refs.matchDiscipline(ctx, matchID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var startedAt *time.Time
+	if row.StartedAt != nil && *row.StartedAt != "" {
+		parsed, err := parseDateTime("started_at", *row.StartedAt)
+		if err != nil {
+			return nil, err
+		}
+		startedAt = parsed
+	}
+	hasTech := false
+	if row.HadTechnicalPause != nil {
+		hasTech = *row.HadTechnicalPause
+	}
+	g := &models.MatchGame{
+		MatchID:           matchID,
+		MapName:           row.MapName,
+		GameNumber:        row.GameNumber,
+		DurationSeconds:   row.DurationSeconds,
+		WinnerTeamID:      winnerID,
+		ScoreTeam1:        row.ScoreTeam1,
+		ScoreTeam2:        row.ScoreTeam2,
+		StartedAt:         startedAt,
+		HadTechnicalPause: hasTech,
+		PickBanPhase:      row.PickBanPhase,
+	}
+	return g, nil
+}
+
+func (s *ImportService) toGamePlayerStat(ctx context.Context, refs *importRefs, row GamePlayerStatImportInput) (*models.GamePlayerStat, error) {
+	gameID, err := refs.gameID(ctx, row.GameID, matchRef{row.TournamentName, row.MatchStartTime, row.MatchTeam1Tag, row.MatchTeam2Tag}, row.GameNumber)
+	if err != nil {
+		return nil, err
+	}
+	playerID, err := refs.playerID(ctx, row.PlayerID, row.PlayerNickname, row.PlayerSteamID)
+	if err != nil {
+		return nil, err
 	}
-	t, err := time.Parse(time.RFC3339, value)
+	teamID, err := refs.optionalTeamID(ctx, row.TeamID, row.TeamTag, func() (int64, error) {
+		return refs.gameDiscipline(ctx, gameID)
+	})
 	if err != nil {
 		return nil, err
 	}
+	stat := &models.GamePlayerStat{
+		GameID:      gameID,
+		PlayerID:    playerID,
+		TeamID:      teamID,
+		Kills:       row.Kills,
+		Deaths:      row.Deaths,
+		Assists:     row.Assists,
+		HeroName:    row.HeroName,
+		DamageDealt: row.DamageDealt,
+		GoldEarned:  row.GoldEarned,
+		WasMVP:      false,
+	}
+	if row.WasMVP != nil {
+		stat.WasMVP = *row.WasMVP
+	}
+	return stat, nil
+}
+
+func parseDate(field, value string) (*time.Time, error) {
+	return parseTime(field, "2006-01-02", value)
+}
+
+func parseDateTime(field, value string) (*time.Time, error) {
+	return parseTime(field, time.RFC3339, value)
+}
+
+func parseTime(field, layout, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, requiredError(field)
+	}
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return nil, &FieldError{Field: field, Code: ImportCodeParse, Err: fmt.Errorf("%s: %w", field, err)}
+	}
 	return &t, nil
 }
 
 func (s *ImportService) toPlayer(row PlayerImportInput) (*models.Player, error) {
 	var birth *time.Time
 	if row.BirthDate != "" {
-		parsed, err := parseDate(row.BirthDate)
+		parsed, err := parseDate("birth_date", row.BirthDate)
 		if err != nil {
-			return nil, fmt.Errorf("invalid birth_date for %s", row.Nickname)
+			return nil, &FieldError{Field: "birth_date", Code: ImportCodeParse, Err: fmt.Errorf("invalid birth_date for %s", row.Nickname)}
 		}
 		birth = parsed
 	}
@@ -3,33 +3,50 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 
 	"db_course_project/internal/models"
 	"db_course_project/internal/pagination"
 	"db_course_project/internal/repository"
+	"db_course_project/internal/series"
 )
 
 type MatchGameService struct {
-	repo repository.MatchGameRepository
+	tx      *repository.TxManager
+	repo    repository.MatchGameRepository
+	matches *MatchService
 }
 
-func NewMatchGameService(repo repository.MatchGameRepository) *MatchGameService {
-	return &MatchGameService{repo: repo}
+func NewMatchGameService(tx *repository.TxManager, repo repository.MatchGameRepository, matches *MatchService) *MatchGameService {
+	return &MatchGameService{tx: tx, repo: repo, matches: matches}
 }
 
 func (s *MatchGameService) Create(ctx context.Context, g *models.MatchGame) error {
 	g.MapName = strings.TrimSpace(g.MapName)
 	if g.MatchID == 0 || g.MapName == "" || g.GameNumber <= 0 {
-		return errors.New("match_id, map_name, game_number are required")
+		return requiredError("match_id", "map_name", "game_number")
 	}
-	return s.repo.Create(ctx, g)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.validateSeries(ctx, g); err != nil {
+			return err
+		}
+		if err := s.repo.Create(ctx, g); err != nil {
+			return err
+		}
+		return s.matches.SyncSeries(ctx, g.MatchID)
+	})
 }
 
 func (s *MatchGameService) Get(ctx context.Context, id int64) (*models.MatchGame, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
+func (s *MatchGameService) GetByMatchNumber(ctx context.Context, matchID int64, gameNumber int) (*models.MatchGame, error) {
+	return s.repo.GetByMatchNumber(ctx, matchID, gameNumber)
+}
+
 func (s *MatchGameService) List(ctx context.Context, filter models.MatchGameFilter) ([]models.MatchGame, int, error) {
 	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
 	return s.repo.List(ctx, filter)
@@ -38,11 +55,86 @@ func (s *MatchGameService) List(ctx context.Context, filter models.MatchGameFilt
 func (s *MatchGameService) Update(ctx context.Context, g *models.MatchGame) error {
 	g.MapName = strings.TrimSpace(g.MapName)
 	if g.MatchID == 0 || g.MapName == "" || g.GameNumber <= 0 {
-		return errors.New("match_id, map_name, game_number are required")
+		return requiredError("match_id", "map_name", "game_number")
 	}
-	return s.repo.Update(ctx, g)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		prev, err := s.repo.GetByID(ctx, g.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.validateSeries(ctx, g); err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, g); err != nil {
+			return err
+		}
+		if prev.MatchID != g.MatchID {
+			if err := s.matches.SyncSeries(ctx, prev.MatchID); err != nil {
+				return err
+			}
+		}
+		return s.matches.SyncSeries(ctx, g.MatchID)
+	})
 }
 
 func (s *MatchGameService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		g, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		m, err := s.matches.Get(ctx, g.MatchID)
+		if err != nil {
+			return err
+		}
+		if err := s.matches.ensureAcceptingResults(ctx, m.TournamentID); err != nil {
+			return err
+		}
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.matches.SyncSeries(ctx, g.MatchID)
+	})
+}
+
+// validateSeries checks a new or changed game against the match format: the
+// game must fit into the series and must not be played after the series was
+// already decided.
+func (s *MatchGameService) validateSeries(ctx context.Context, g *models.MatchGame) error {
+	m, err := s.matches.Get(ctx, g.MatchID)
+	if err != nil {
+		return err
+	}
+	if err := s.matches.ensureAcceptingResults(ctx, m.TournamentID); err != nil {
+		return err
+	}
+	f, err := series.Parse(m.Format)
+	if err != nil {
+		return err
+	}
+	if g.GameNumber > f.Games {
+		return fmt.Errorf("game_number %d exceeds the series length of %s", g.GameNumber, f.Name)
+	}
+	if g.WinnerTeamID != nil && !sameTeam(g.WinnerTeamID, m.Team1ID) && !sameTeam(g.WinnerTeamID, m.Team2ID) {
+		return errors.New("winner_team_id must be one of the match teams")
+	}
+
+	existing, err := s.repo.ListByMatch(ctx, g.MatchID)
+	if err != nil {
+		return err
+	}
+	games := []models.MatchGame{*g}
+	for _, other := range existing {
+		if other.ID != g.ID {
+			games = append(games, other)
+		}
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].GameNumber < games[j].GameNumber })
+	for i := range games {
+		score := seriesScore(m, games[:i])
+		if _, finished := f.Result(score); finished {
+			return fmt.Errorf("series was already decided at %s before game %d", score, games[i].GameNumber)
+		}
+	}
+	return nil
 }
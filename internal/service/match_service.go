@@ -3,29 +3,34 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
 	"db_course_project/internal/models"
 	"db_course_project/internal/pagination"
 	"db_course_project/internal/repository"
+	"db_course_project/internal/series"
 )
 
 type MatchService struct {
-	repo repository.MatchRepository
+	tx          *repository.TxManager
+	repo        repository.MatchRepository
+	games       repository.MatchGameRepository
+	tournaments repository.TournamentRepository
+	ratings     *RatingService
 }
 
-func NewMatchService(repo repository.MatchRepository) *MatchService {
-	return &MatchService{repo: repo}
+func NewMatchService(tx *repository.TxManager, repo repository.MatchRepository, games repository.MatchGameRepository, tournaments repository.TournamentRepository, ratings *RatingService) *MatchService {
+	return &MatchService{tx: tx, repo: repo, games: games, tournaments: tournaments, ratings: ratings}
 }
 
 func (s *MatchService) Create(ctx context.Context, m *models.Match) error {
-	m.Format = strings.TrimSpace(m.Format)
-	if m.Format == "" {
-		m.Format = "bo3"
+	if err := normalizeFormat(m); err != nil {
+		return err
 	}
 	if m.TournamentID == 0 || m.StartTime.IsZero() {
-		return errors.New("tournament_id and start_time are required")
+		return requiredError("tournament_id", "start_time")
 	}
 	if m.Team1ID != nil && m.Team2ID != nil && *m.Team1ID == *m.Team2ID {
 		return errors.New("team1_id and team2_id must differ")
@@ -33,32 +38,292 @@ func (s *MatchService) Create(ctx context.Context, m *models.Match) error {
 	if m.StartTime.Before(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)) {
 		return errors.New("start_time looks invalid")
 	}
-	return s.repo.Create(ctx, m)
+	if err := validateWinner(m); err != nil {
+		return err
+	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if m.WinnerTeamID != nil || m.IsForfeit {
+			if err := s.ensureAcceptingResults(ctx, m.TournamentID); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.Create(ctx, m); err != nil {
+			return err
+		}
+		if m.WinnerTeamID == nil {
+			return nil
+		}
+		return s.ratings.MatchResultChanged(ctx, m.TournamentID, m.ID)
+	})
 }
 
 func (s *MatchService) Get(ctx context.Context, id int64) (*models.Match, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
+func (s *MatchService) ListByTournament(ctx context.Context, tournamentID int64) ([]models.Match, error) {
+	return s.repo.ListByTournament(ctx, tournamentID)
+}
+
 func (s *MatchService) List(ctx context.Context, filter models.MatchFilter) ([]models.Match, int, error) {
 	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
 	return s.repo.List(ctx, filter)
 }
 
 func (s *MatchService) Update(ctx context.Context, m *models.Match) error {
-	m.Format = strings.TrimSpace(m.Format)
-	if m.Format == "" {
-		m.Format = "bo3"
+	if err := normalizeFormat(m); err != nil {
+		return err
 	}
 	if m.TournamentID == 0 || m.StartTime.IsZero() {
-		return errors.New("tournament_id and start_time are required")
+		return requiredError("tournament_id", "start_time")
 	}
 	if m.Team1ID != nil && m.Team2ID != nil && *m.Team1ID == *m.Team2ID {
 		return errors.New("team1_id and team2_id must differ")
 	}
-	return s.repo.Update(ctx, m)
+	if err := validateWinner(m); err != nil {
+		return err
+	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.update(ctx, m)
+	})
+}
+
+// update saves a validated match and updates the ratings its result feeds.
+func (s *MatchService) update(ctx context.Context, m *models.Match) error {
+	prev, err := s.repo.GetByID(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+	games, err := s.games.ListByMatch(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+	if err := reconcileSeries(m, games); err != nil {
+		return err
+	}
+	if !sameTeam(prev.WinnerTeamID, m.WinnerTeamID) || prev.IsForfeit != m.IsForfeit {
+		if err := s.ensureAcceptingResults(ctx, m.TournamentID); err != nil {
+			return err
+		}
+	}
+	m.BracketSection, m.BracketRound, m.BracketPosition = prev.BracketSection, prev.BracketRound, prev.BracketPosition
+	m.NextMatchID, m.NextMatchSlot = prev.NextMatchID, prev.NextMatchSlot
+	m.LoserNextMatchID, m.LoserNextMatchSlot = prev.LoserNextMatchID, prev.LoserNextMatchSlot
+	if err := s.save(ctx, prev, m); err != nil {
+		return err
+	}
+	if prev.WinnerTeamID == nil && m.WinnerTeamID == nil && len(games) == 0 || !ratedFieldsChanged(prev, m) {
+		return nil
+	}
+	if prev.TournamentID != m.TournamentID {
+		if err := s.ratings.MatchResultChanged(ctx, prev.TournamentID, m.ID); err != nil {
+			return err
+		}
+	}
+	return s.ratings.MatchResultChanged(ctx, m.TournamentID, m.ID)
+}
+
+func (s *MatchService) save(ctx context.Context, prev, m *models.Match) error {
+	if m.NextMatchID == nil && m.LoserNextMatchID == nil {
+		return s.repo.Update(ctx, m)
+	}
+	if sameTeam(prev.WinnerTeamID, m.WinnerTeamID) && sameTeam(prev.Team1ID, m.Team1ID) && sameTeam(prev.Team2ID, m.Team2ID) {
+		return s.repo.Update(ctx, m)
+	}
+	return s.repo.UpdateWithAdvancement(ctx, m, advancementSlots(m))
 }
 
 func (s *MatchService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		m, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		rated, err := s.ratings.IsRated(ctx, m.TournamentID, id)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		if !rated {
+			return nil
+		}
+		return s.ratings.Replay(ctx, m.TournamentID)
+	})
+}
+
+// SyncSeries recomputes the match winner from its games after they change.
+// Forfeited matches keep their manually set winner.
+func (s *MatchService) SyncSeries(ctx context.Context, matchID int64) error {
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.syncSeries(ctx, matchID)
+	})
+}
+
+func (s *MatchService) syncSeries(ctx context.Context, matchID int64) error {
+	m, err := s.repo.GetByID(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	if m.IsForfeit {
+		return nil
+	}
+	games, err := s.games.ListByMatch(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	f, err := series.Parse(m.Format)
+	if err != nil {
+		return err
+	}
+	winner, finished := f.Result(seriesScore(m, games))
+	var derived *int64
+	if finished {
+		derived = slotTeam(m, winner)
+	}
+	if sameTeam(derived, m.WinnerTeamID) {
+		// The winner is unchanged, but a draw may have been completed or
+		// undone.
+		return s.ratings.MatchResultChanged(ctx, m.TournamentID, m.ID)
+	}
+	m.WinnerTeamID = derived
+	return s.update(ctx, m)
+}
+
+// ensureAcceptingResults rejects results for tournaments that have not
+// started yet or were cancelled. Completed tournaments still accept
+// corrections.
+func (s *MatchService) ensureAcceptingResults(ctx context.Context, tournamentID int64) error {
+	status, err := tournamentStatus(ctx, s.tournaments, tournamentID)
+	if err != nil {
+		return err
+	}
+	if status != models.TournamentOngoing && status != models.TournamentCompleted {
+		return ErrResultsNotAllowed
+	}
+	return nil
+}
+
+// validateWinner requires the winner to be one of the teams. A match with only
+// team1 is a bye, which team1 wins.
+func validateWinner(m *models.Match) error {
+	if m.WinnerTeamID == nil {
+		return nil
+	}
+	if m.Team1ID != nil && m.Team2ID == nil && *m.WinnerTeamID == *m.Team1ID {
+		return nil
+	}
+	if m.Team1ID == nil || m.Team2ID == nil {
+		return errors.New("winner_team_id requires both team1_id and team2_id")
+	}
+	if *m.WinnerTeamID != *m.Team1ID && *m.WinnerTeamID != *m.Team2ID {
+		return errors.New("winner_team_id must be team1_id or team2_id")
+	}
+	return nil
+}
+
+// advancementSlots places the winner (and, in double elimination, the loser)
+// into the matches this one feeds. Without a winner the slots are cleared.
+func advancementSlots(m *models.Match) []models.MatchSlotAssignment {
+	var winner, loser *int64
+	if m.WinnerTeamID != nil {
+		winner = m.WinnerTeamID
+		loser = m.Team1ID
+		if *m.Team1ID == *m.WinnerTeamID {
+			loser = m.Team2ID
+		}
+	}
+	slots := []models.MatchSlotAssignment{}
+	if m.NextMatchID != nil && m.NextMatchSlot != nil {
+		slots = append(slots, models.MatchSlotAssignment{MatchID: *m.NextMatchID, Slot: *m.NextMatchSlot, TeamID: winner})
+	}
+	if m.LoserNextMatchID != nil && m.LoserNextMatchSlot != nil {
+		slots = append(slots, models.MatchSlotAssignment{MatchID: *m.LoserNextMatchID, Slot: *m.LoserNextMatchSlot, TeamID: loser})
+	}
+	return slots
+}
+
+// ratedFieldsChanged reports whether an update touches anything the rating
+// of a match depends on.
+func ratedFieldsChanged(prev, m *models.Match) bool {
+	return prev.TournamentID != m.TournamentID || prev.Format != m.Format || prev.IsForfeit != m.IsForfeit ||
+		!prev.StartTime.Equal(m.StartTime) || !sameTeam(prev.WinnerTeamID, m.WinnerTeamID) ||
+		!sameTeam(prev.Team1ID, m.Team1ID) || !sameTeam(prev.Team2ID, m.Team2ID)
+}
+
+func sameTeam(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func normalizeFormat(m *models.Match) error {
+	if strings.TrimSpace(m.Format) == "" {
+		m.Format = "bo3"
+	}
+	f, err := series.Parse(m.Format)
+	if err != nil {
+		return err
+	}
+	m.Format = f.Name
+	return nil
+}
+
+// reconcileSeries checks the games of a match against its format and derives
+// the winner from them: it is filled in when missing and rejected when it
+// contradicts the series score. Matches without games keep a manual winner.
+func reconcileSeries(m *models.Match, games []models.MatchGame) error {
+	f, err := series.Parse(m.Format)
+	if err != nil {
+		return err
+	}
+	for _, g := range games {
+		if g.GameNumber > f.Games {
+			return fmt.Errorf("game %d exceeds the series length of %s", g.GameNumber, f.Name)
+		}
+	}
+	if m.IsForfeit || len(games) == 0 {
+		return nil
+	}
+	score := seriesScore(m, games)
+	winner, finished := f.Result(score)
+	switch {
+	case !finished:
+		if m.WinnerTeamID != nil {
+			return fmt.Errorf("series is not finished yet (%s), winner_team_id cannot be set", score)
+		}
+	case winner == 0:
+		if m.WinnerTeamID != nil {
+			return fmt.Errorf("series ended in a draw (%s), winner_team_id must be empty", score)
+		}
+	default:
+		derived := slotTeam(m, winner)
+		if m.WinnerTeamID == nil {
+			m.WinnerTeamID = derived
+		} else if !sameTeam(m.WinnerTeamID, derived) {
+			return fmt.Errorf("winner_team_id does not match the series score %s", score)
+		}
+	}
+	return nil
+}
+
+func seriesScore(m *models.Match, games []models.MatchGame) series.Score {
+	winners := make([]*int64, 0, len(games))
+	for _, g := range games {
+		winners = append(winners, g.WinnerTeamID)
+	}
+	return series.Tally(m.Team1ID, m.Team2ID, winners)
+}
+
+func slotTeam(m *models.Match, slot int) *int64 {
+	switch slot {
+	case 1:
+		return m.Team1ID
+	case 2:
+		return m.Team2ID
+	default:
+		return nil
+	}
 }
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"testing"
+
+	"db_course_project/internal/models"
+)
+
+func TestReconcileSeries(t *testing.T) {
+	team1, team2, other := int64(1), int64(2), int64(3)
+	games := func(winners ...*int64) []models.MatchGame {
+		gs := make([]models.MatchGame, len(winners))
+		for i, w := range winners {
+			gs[i] = models.MatchGame{GameNumber: i + 1, WinnerTeamID: w}
+		}
+		return gs
+	}
+	tests := []struct {
+		name    string
+		format  string
+		winner  *int64
+		forfeit bool
+		games   []models.MatchGame
+		want    *int64
+		wantErr bool
+	}{
+		{name: "no games keeps a manual winner", format: "bo3", winner: &team2, want: &team2},
+		{name: "no games and no winner", format: "bo3"},
+		{name: "derives the winner", format: "bo3", games: games(&team1, &team2, &team1), want: &team1},
+		{name: "derives the winner before all games", format: "bo5", games: games(&team2, &team2, &team2), want: &team2},
+		{name: "matching winner", format: "bo3", winner: &team1, games: games(&team1, &team1), want: &team1},
+		{name: "contradicting winner", format: "bo3", winner: &team2, games: games(&team1, &team1), wantErr: true},
+		{name: "winner of an unfinished series", format: "bo3", winner: &team1, games: games(&team1), wantErr: true},
+		{name: "unfinished series", format: "bo3", games: games(&team1, &team2)},
+		{name: "drawn bo2", format: "bo2", games: games(&team1, &team2)},
+		{name: "winner of a drawn bo2", format: "bo2", winner: &team1, games: games(&team1, &team2), wantErr: true},
+		{name: "game beyond the series", format: "bo1", games: games(&team1, &team2), wantErr: true},
+		{name: "forfeit keeps its winner", format: "bo3", winner: &team2, forfeit: true, games: games(&team1, &team1), want: &team2},
+		{name: "games won by another team do not count", format: "bo3", games: games(&other, &team1), want: nil},
+		{name: "unknown format", format: "bo4", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &models.Match{Team1ID: &team1, Team2ID: &team2, Format: tt.format, WinnerTeamID: tt.winner, IsForfeit: tt.forfeit}
+			err := reconcileSeries(m, tt.games)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !sameTeam(m.WinnerTeamID, tt.want) {
+				t.Errorf("winner = %v, want %v", m.WinnerTeamID, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWinner(t *testing.T) {
+	team1, team2, other := int64(1), int64(2), int64(3)
+	tests := []struct {
+		name    string
+		team1   *int64
+		team2   *int64
+		winner  *int64
+		wantErr bool
+	}{
+		{name: "no winner", team1: &team1, team2: &team2},
+		{name: "team1 wins", team1: &team1, team2: &team2, winner: &team1},
+		{name: "team2 wins", team1: &team1, team2: &team2, winner: &team2},
+		{name: "winner not in the match", team1: &team1, team2: &team2, winner: &other, wantErr: true},
+		{name: "bye won by team1", team1: &team1, winner: &team1},
+		{name: "bye won by another team", team1: &team1, winner: &other, wantErr: true},
+		{name: "winner without team1", team2: &team2, winner: &team2, wantErr: true},
+		{name: "winner without teams", winner: &team1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &models.Match{Team1ID: tt.team1, Team2ID: tt.team2, WinnerTeamID: tt.winner}
+			if err := validateWinner(m); (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
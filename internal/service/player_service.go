@@ -12,28 +12,46 @@ import (
 )
 
 type PlayerService struct {
-	repo repository.PlayerRepository
+	tx      *repository.TxManager
+	repo    repository.PlayerRepository
+	ratings repository.RatingRepository
 }
 
-func NewPlayerService(repo repository.PlayerRepository) *PlayerService {
-	return &PlayerService{repo: repo}
+func NewPlayerService(tx *repository.TxManager, repo repository.PlayerRepository, ratings repository.RatingRepository) *PlayerService {
+	return &PlayerService{tx: tx, repo: repo, ratings: ratings}
 }
 
 func (s *PlayerService) Create(ctx context.Context, p *models.Player) error {
 	p.Nickname = strings.TrimSpace(p.Nickname)
 	if p.Nickname == "" {
-		return errors.New("nickname is required")
+		return requiredError("nickname")
 	}
 	if p.BirthDate != nil && p.BirthDate.After(time.Now()) {
 		return errors.New("birth_date cannot be in the future")
 	}
-	return s.repo.Create(ctx, p)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, p); err != nil {
+			return err
+		}
+		if p.MMRRating == 0 {
+			return nil
+		}
+		return s.recordManualRating(ctx, p, nil)
+	})
 }
 
 func (s *PlayerService) Get(ctx context.Context, id int64) (*models.Player, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
+func (s *PlayerService) GetByNickname(ctx context.Context, nickname string) (*models.Player, error) {
+	return s.repo.GetByNickname(ctx, nickname)
+}
+
+func (s *PlayerService) GetBySteamID(ctx context.Context, steamID string) (*models.Player, error) {
+	return s.repo.GetBySteamID(ctx, steamID)
+}
+
 func (s *PlayerService) List(ctx context.Context, filter models.PlayerFilter) ([]models.Player, int, error) {
 	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
 	return s.repo.List(ctx, filter)
@@ -42,14 +60,39 @@ func (s *PlayerService) List(ctx context.Context, filter models.PlayerFilter) ([
 func (s *PlayerService) Update(ctx context.Context, p *models.Player) error {
 	p.Nickname = strings.TrimSpace(p.Nickname)
 	if p.Nickname == "" {
-		return errors.New("nickname is required")
+		return requiredError("nickname")
 	}
 	if p.BirthDate != nil && p.BirthDate.After(time.Now()) {
 		return errors.New("birth_date cannot be in the future")
 	}
-	return s.repo.Update(ctx, p)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		prev, err := s.repo.GetByID(ctx, p.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, p); err != nil {
+			return err
+		}
+		if prev.MMRRating == p.MMRRating {
+			return nil
+		}
+		return s.recordManualRating(ctx, p, &prev.MMRRating)
+	})
+}
+
+// recordManualRating logs an mmr_rating set by hand in the rating history.
+func (s *PlayerService) recordManualRating(ctx context.Context, p *models.Player, previous *float64) error {
+	id := p.ID
+	return s.ratings.AddHistory(ctx, &models.RatingChange{
+		PlayerID:       &id,
+		Rating:         p.MMRRating,
+		PreviousRating: previous,
+		Cause:          models.RatingCauseManual,
+	})
 }
 
 func (s *PlayerService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Delete(ctx, id)
+	})
 }
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"db_course_project/internal/models"
+	"db_course_project/internal/pagination"
+	"db_course_project/internal/rating"
+	"db_course_project/internal/repository"
+)
+
+var (
+	ErrInvalidRatingInterval = errors.New("interval must be daily or weekly")
+	ErrInvalidRatingRange    = errors.New("from must be before to")
+)
+
+type RatingService struct {
+	tx          *repository.TxManager
+	repo        repository.RatingRepository
+	tournaments repository.TournamentRepository
+	disciplines repository.DisciplineRepository
+	teams       repository.TeamRepository
+	players     repository.PlayerRepository
+}
+
+func NewRatingService(tx *repository.TxManager, repo repository.RatingRepository, tournaments repository.TournamentRepository, disciplines repository.DisciplineRepository, teams repository.TeamRepository, players repository.PlayerRepository) *RatingService {
+	return &RatingService{tx: tx, repo: repo, tournaments: tournaments, disciplines: disciplines, teams: teams, players: players}
+}
+
+// Recompute replays the pool of one discipline, or of every discipline with
+// matches when disciplineID is nil.
+func (s *RatingService) Recompute(ctx context.Context, disciplineID *int64) ([]models.RatingRecompute, error) {
+	ids := []int64{}
+	if disciplineID != nil {
+		if _, err := s.disciplines.GetByID(ctx, *disciplineID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, *disciplineID)
+	} else {
+		all, err := s.repo.RatedDisciplines(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids = all
+	}
+	results := make([]models.RatingRecompute, 0, len(ids))
+	for _, id := range ids {
+		res, err := s.replay(ctx, id, true)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *res)
+	}
+	return results, nil
+}
+
+// MatchResultChanged brings the pool of the tournament's discipline up to
+// date after a match was created or changed. A newly decided match played
+// after every match of the pool is rated on top of the stored ratings; the
+// pool is replayed when a rated result is corrected or withdrawn, or when a
+// match is decided out of order, so those end up with the same ratings as a
+// full recompute.
+func (s *RatingService) MatchResultChanged(ctx context.Context, tournamentID, matchID int64) error {
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		t, err := s.tournaments.GetByID(ctx, tournamentID)
+		if err != nil {
+			return err
+		}
+		if deferred, ok := ctx.Value(deferredRatingsKey{}).(*deferredRatings); ok {
+			deferred.add(t.DisciplineID)
+			return nil
+		}
+		if err := s.repo.LockPool(ctx, t.DisciplineID); err != nil {
+			return err
+		}
+		rated, err := s.repo.IsRated(ctx, t.DisciplineID, matchID)
+		if err != nil {
+			return err
+		}
+		m, err := s.repo.GetRatedMatch(ctx, t.DisciplineID, matchID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case rated:
+			_, err = s.replay(ctx, t.DisciplineID, false)
+			return err
+		case m == nil:
+			return nil
+		}
+		playedAt, lastID, err := s.repo.LastRated(ctx, t.DisciplineID)
+		if err != nil {
+			return err
+		}
+		if m.StartTime.Before(playedAt) || m.StartTime.Equal(playedAt) && m.MatchID < lastID {
+			_, err = s.replay(ctx, t.DisciplineID, false)
+			return err
+		}
+		return s.append(ctx, t.DisciplineID, *m)
+	})
+}
+
+// IsRated reports whether the result of a match is part of the pool of the
+// tournament's discipline.
+func (s *RatingService) IsRated(ctx context.Context, tournamentID, matchID int64) (bool, error) {
+	t, err := s.tournaments.GetByID(ctx, tournamentID)
+	if err != nil {
+		return false, err
+	}
+	return s.repo.IsRated(ctx, t.DisciplineID, matchID)
+}
+
+// Replay replays the pool of the tournament's discipline.
+func (s *RatingService) Replay(ctx context.Context, tournamentID int64) error {
+	t, err := s.tournaments.GetByID(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	if deferred, ok := ctx.Value(deferredRatingsKey{}).(*deferredRatings); ok {
+		deferred.add(t.DisciplineID)
+		return nil
+	}
+	_, err = s.replay(ctx, t.DisciplineID, false)
+	return err
+}
+
+type deferredRatingsKey struct{}
+
+// deferredRatings collects the pools changed by a bulk import, which are
+// replayed once when it is done instead of after every match.
+type deferredRatings struct {
+	mu          sync.Mutex
+	disciplines map[int64]bool
+}
+
+func deferRatings(ctx context.Context) (context.Context, *deferredRatings) {
+	d := &deferredRatings{disciplines: map[int64]bool{}}
+	return context.WithValue(ctx, deferredRatingsKey{}, d), d
+}
+
+func (d *deferredRatings) add(disciplineID int64) {
+	d.mu.Lock()
+	d.disciplines[disciplineID] = true
+	d.mu.Unlock()
+}
+
+// replayDeferred replays the pools collected in d. ctx must not defer
+// ratings itself.
+func (s *RatingService) replayDeferred(ctx context.Context, d *deferredRatings) error {
+	d.mu.Lock()
+	ids := make([]int64, 0, len(d.disciplines))
+	for id := range d.disciplines {
+		ids = append(ids, id)
+	}
+	d.disciplines = map[int64]bool{}
+	d.mu.Unlock()
+	slices.Sort(ids)
+	for _, id := range ids {
+		if _, err := s.replay(ctx, id, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RatingService) TeamRatings(ctx context.Context, filter models.RatingFilter) ([]models.TeamRating, int, error) {
+	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
+	return s.repo.ListTeamRatings(ctx, filter)
+}
+
+func (s *RatingService) PlayerRatings(ctx context.Context, filter models.RatingFilter) ([]models.PlayerRating, int, error) {
+	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
+	return s.repo.ListPlayerRatings(ctx, filter)
+}
+
+func (s *RatingService) TeamHistory(ctx context.Context, teamID int64, filter models.RatingHistoryFilter) ([]models.RatingHistoryPoint, error) {
+	if _, err := s.teams.GetByID(ctx, teamID); err != nil {
+		return nil, err
+	}
+	filter.TeamID, filter.PlayerID = &teamID, nil
+	return s.history(ctx, filter)
+}
+
+func (s *RatingService) PlayerHistory(ctx context.Context, playerID int64, filter models.RatingHistoryFilter) ([]models.RatingHistoryPoint, error) {
+	if _, err := s.players.GetByID(ctx, playerID); err != nil {
+		return nil, err
+	}
+	filter.TeamID, filter.PlayerID = nil, &playerID
+	return s.history(ctx, filter)
+}
+
+func (s *RatingService) history(ctx context.Context, filter models.RatingHistoryFilter) ([]models.RatingHistoryPoint, error) {
+	filter.Interval = strings.ToLower(strings.TrimSpace(filter.Interval))
+	switch filter.Interval {
+	case "", models.RatingIntervalDaily, models.RatingIntervalWeekly:
+	default:
+		return nil, ErrInvalidRatingInterval
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, ErrInvalidRatingRange
+	}
+	return s.repo.ListHistory(ctx, filter)
+}
+
+func (s *RatingService) replay(ctx context.Context, disciplineID int64, recompute bool) (*models.RatingRecompute, error) {
+	matches, err := s.repo.ListRatedMatches(ctx, disciplineID)
+	if err != nil {
+		return nil, err
+	}
+	lineups, err := s.repo.ListLineups(ctx, disciplineID)
+	if err != nil {
+		return nil, err
+	}
+	players := map[[2]int64][]int64{}
+	for _, l := range lineups {
+		key := [2]int64{l.MatchID, l.TeamID}
+		players[key] = append(players[key], l.PlayerID)
+	}
+
+	pool := rating.NewPool()
+	history := []models.RatingChange{}
+	for _, m := range matches {
+		changes := pool.Apply(ratedMatch(m, players[[2]int64{m.MatchID, m.Team1ID}], players[[2]int64{m.MatchID, m.Team2ID}]))
+		for _, c := range changes {
+			history = append(history, ratingChange(c, disciplineID, m))
+		}
+	}
+
+	teams, ratedPlayers := poolRatings(pool)
+	if err := s.repo.ReplacePool(ctx, disciplineID, teams, ratedPlayers, history, recompute); err != nil {
+		return nil, err
+	}
+	return &models.RatingRecompute{DisciplineID: disciplineID, Matches: len(matches), Teams: len(teams), Players: len(ratedPlayers)}, nil
+}
+
+// append rates one match on top of the stored entries of its teams and
+// players.
+func (s *RatingService) append(ctx context.Context, disciplineID int64, m models.RatedMatch) error {
+	lineups, err := s.repo.ListMatchLineups(ctx, m.MatchID)
+	if err != nil {
+		return err
+	}
+	players := map[int64][]int64{}
+	playerIDs := []int64{}
+	for _, l := range lineups {
+		players[l.TeamID] = append(players[l.TeamID], l.PlayerID)
+		playerIDs = append(playerIDs, l.PlayerID)
+	}
+	teams, ratedPlayers, err := s.repo.ListPoolEntries(ctx, disciplineID, []int64{m.Team1ID, m.Team2ID}, playerIDs)
+	if err != nil {
+		return err
+	}
+	pool := rating.NewPool()
+	for _, t := range teams {
+		pool.Teams[t.TeamID] = poolEntry(t.Rating, t.MatchesPlayed)
+	}
+	for _, p := range ratedPlayers {
+		pool.Players[p.PlayerID] = poolEntry(p.Rating, p.MatchesPlayed)
+	}
+	changes := pool.Apply(ratedMatch(m, players[m.Team1ID], players[m.Team2ID]))
+	history := make([]models.RatingChange, 0, len(changes))
+	for _, c := range changes {
+		history = append(history, ratingChange(c, disciplineID, m))
+	}
+	t, p := poolRatings(pool)
+	return s.repo.AppendMatch(ctx, disciplineID, t, p, history)
+}
+
+func poolEntry(r float64, matches int) *rating.Entry {
+	return &rating.Entry{Rating: r, Matches: matches}
+}
+
+func ratedMatch(m models.RatedMatch, team1Players, team2Players []int64) rating.Match {
+	score := 0.5
+	switch {
+	case m.WinnerTeamID == nil:
+	case *m.WinnerTeamID == m.Team1ID:
+		score = 1
+	default:
+		score = 0
+	}
+	return rating.Match{
+		ID:           m.MatchID,
+		PlayedAt:     m.StartTime,
+		Team1ID:      m.Team1ID,
+		Team2ID:      m.Team2ID,
+		Score:        score,
+		Team1Players: team1Players,
+		Team2Players: team2Players,
+	}
+}
+
+// poolRatings lists the entries of pool that took part in a match.
+func poolRatings(pool *rating.Pool) ([]models.TeamRating, []models.PlayerRating) {
+	teams := make([]models.TeamRating, 0, len(pool.Teams))
+	for id, e := range pool.Teams {
+		if e.LastMatchID == 0 {
+			continue
+		}
+		matchID, playedAt := e.LastMatchID, e.LastPlayedAt
+		teams = append(teams, models.TeamRating{TeamID: id, Rating: roundRating(e.Rating), MatchesPlayed: e.Matches, LastMatchID: &matchID, LastPlayedAt: &playedAt})
+	}
+	players := make([]models.PlayerRating, 0, len(pool.Players))
+	for id, e := range pool.Players {
+		if e.LastMatchID == 0 {
+			continue
+		}
+		matchID, playedAt := e.LastMatchID, e.LastPlayedAt
+		players = append(players, models.PlayerRating{PlayerID: id, Rating: roundRating(e.Rating), MatchesPlayed: e.Matches, LastMatchID: &matchID, LastPlayedAt: &playedAt})
+	}
+	return teams, players
+}
+
+func ratingChange(c rating.Change, disciplineID int64, m models.RatedMatch) models.RatingChange {
+	id, matchID, before := c.ID, m.MatchID, roundRating(c.Before)
+	change := models.RatingChange{
+		DisciplineID:   &disciplineID,
+		Rating:         roundRating(c.After),
+		PreviousRating: &before,
+		Cause:          models.RatingCauseMatch,
+		MatchID:        &matchID,
+		RecordedAt:     m.StartTime,
+	}
+	if c.Team {
+		change.TeamID = &id
+	} else {
+		change.PlayerID = &id
+	}
+	return change
+}
+
+func roundRating(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -2,18 +2,22 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 
+	"db_course_project/internal/bracket"
 	"db_course_project/internal/models"
 	"db_course_project/internal/pagination"
 	"db_course_project/internal/repository"
 )
 
 type ReportService struct {
-	repo repository.ReportRepository
+	repo        repository.ReportRepository
+	tournaments repository.TournamentRepository
 }
 
-func NewReportService(repo repository.ReportRepository) *ReportService {
-	return &ReportService{repo: repo}
+func NewReportService(repo repository.ReportRepository, tournaments repository.TournamentRepository) *ReportService {
+	return &ReportService{repo: repo, tournaments: tournaments}
 }
 
 func (s *ReportService) ActiveRosters(ctx context.Context, limit, offset int) ([]models.ActiveRosterView, int, error) {
@@ -21,6 +25,19 @@ func (s *ReportService) ActiveRosters(ctx context.Context, limit, offset int) ([
 	return s.repo.ActiveRosters(ctx, limit, offset)
 }
 
+var ErrInvalidRosterStatus = errors.New("status must be one of short_handed, over_staffed, ok")
+
+func (s *ReportService) RosterHealth(ctx context.Context, filter models.RosterHealthFilter) ([]models.RosterHealthView, int, error) {
+	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
+	filter.Status = strings.ToLower(strings.TrimSpace(filter.Status))
+	switch filter.Status {
+	case "", models.RosterShortHanded, models.RosterOverStaffed, models.RosterOK:
+	default:
+		return nil, 0, ErrInvalidRosterStatus
+	}
+	return s.repo.RosterHealth(ctx, filter)
+}
+
 func (s *ReportService) MatchResults(ctx context.Context, tournamentID *int64, limit, offset int) ([]models.MatchResultView, int, error) {
 	limit, offset = pagination.Normalize(limit, offset)
 	return s.repo.MatchResults(ctx, tournamentID, limit, offset)
@@ -31,8 +48,40 @@ func (s *ReportService) PlayerCareer(ctx context.Context, search string, limit,
 	return s.repo.PlayerCareer(ctx, search, limit, offset)
 }
 
-func (s *ReportService) TournamentStandings(ctx context.Context, tournamentID int64) ([]models.TournamentStanding, error) {
-	return s.repo.TournamentStandings(ctx, tournamentID)
+func (s *ReportService) TournamentStandings(ctx context.Context, filter models.StandingsFilter) ([]models.TournamentStanding, error) {
+	t, err := s.tournaments.GetByID(ctx, filter.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	points := bracket.DefaultPoints
+	cfg, err := bracket.ParseConfig(t.BracketConfig)
+	if err != nil && !errors.Is(err, bracket.ErrNoConfig) {
+		return nil, err
+	}
+	if err == nil {
+		points = cfg.StandingPoints()
+	}
+	if filter.PointsWin != nil {
+		points.Win = *filter.PointsWin
+	}
+	if filter.PointsDraw != nil {
+		points.Draw = *filter.PointsDraw
+	}
+	if filter.PointsLoss != nil {
+		points.Loss = *filter.PointsLoss
+	}
+	if err := points.Validate(); err != nil {
+		return nil, err
+	}
+	filter.PointsWin, filter.PointsDraw, filter.PointsLoss = &points.Win, &points.Draw, &points.Loss
+	if filter.Stage != nil {
+		stage := strings.TrimSpace(*filter.Stage)
+		filter.Stage = &stage
+		if stage == "" {
+			filter.Stage = nil
+		}
+	}
+	return s.repo.TournamentStandings(ctx, filter)
 }
 
 func (s *ReportService) PlayerKDA(ctx context.Context, playerID int64) (float64, error) {
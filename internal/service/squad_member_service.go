@@ -2,21 +2,33 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
+	"db_course_project/internal/auth"
 	"db_course_project/internal/models"
 	"db_course_project/internal/pagination"
 	"db_course_project/internal/repository"
 )
 
+var ErrRosterOversize = errors.New("active roster exceeds the discipline team_size, add the player as a standin")
+
+// rosterTx is serializable so that two concurrent roster changes cannot both
+// pass the team_size check; the loser is retried against the new roster.
+var rosterTx = &sql.TxOptions{Isolation: sql.LevelSerializable}
+
 type SquadMemberService struct {
-	repo repository.SquadMemberRepository
+	tx          *repository.TxManager
+	repo        repository.SquadMemberRepository
+	teams       repository.TeamRepository
+	disciplines repository.DisciplineRepository
 }
 
-func NewSquadMemberService(repo repository.SquadMemberRepository) *SquadMemberService {
-	return &SquadMemberService{repo: repo}
+func NewSquadMemberService(tx *repository.TxManager, repo repository.SquadMemberRepository, teams repository.TeamRepository, disciplines repository.DisciplineRepository) *SquadMemberService {
+	return &SquadMemberService{tx: tx, repo: repo, teams: teams, disciplines: disciplines}
 }
 
 func (s *SquadMemberService) validateDates(m *models.SquadMember) error {
@@ -32,7 +44,7 @@ func (s *SquadMemberService) validateDates(m *models.SquadMember) error {
 func (s *SquadMemberService) Create(ctx context.Context, m *models.SquadMember) error {
 	m.Role = strings.TrimSpace(m.Role)
 	if m.TeamID == 0 || m.PlayerID == 0 {
-		return errors.New("team_id and player_id are required")
+		return requiredError("team_id", "player_id")
 	}
 	if m.Role == "" {
 		m.Role = "Player"
@@ -43,35 +55,127 @@ func (s *SquadMemberService) Create(ctx context.Context, m *models.SquadMember)
 	if err := s.validateDates(m); err != nil {
 		return err
 	}
-	return s.repo.Create(ctx, m)
+	if err := authorizeTeam(ctx, m.TeamID); err != nil {
+		return err
+	}
+	return s.tx.DoWith(ctx, rosterTx, func(ctx context.Context) error {
+		if err := s.checkRosterSize(ctx, m); err != nil {
+			return err
+		}
+		return s.repo.Create(ctx, m)
+	})
 }
 
 func (s *SquadMemberService) Get(ctx context.Context, id int64) (*models.SquadMember, error) {
-	return s.repo.GetByID(ctx, id)
+	m, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	hideCompensation(ctx, m)
+	return m, nil
 }
 
 func (s *SquadMemberService) List(ctx context.Context, filter models.SquadMemberFilter) ([]models.SquadMember, int, error) {
 	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
-	return s.repo.List(ctx, filter)
+	rows, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range rows {
+		hideCompensation(ctx, &rows[i])
+	}
+	return rows, total, nil
+}
+
+// hideCompensation clears the salary and contract end of m unless the
+// principal of ctx is an organizer or manages the team of m. Work done without
+// a principal sees everything.
+func hideCompensation(ctx context.Context, m *models.SquadMember) {
+	p, ok := auth.FromContext(ctx)
+	if !ok || p.HasRole(auth.RoleOrganizer, auth.RoleAdmin) {
+		return
+	}
+	if p.ManagesOnly() && p.TeamID != nil && *p.TeamID == m.TeamID {
+		return
+	}
+	m.SalaryMonthly, m.ContractEndDate = nil, nil
 }
 
 func (s *SquadMemberService) Update(ctx context.Context, m *models.SquadMember) error {
 	m.Role = strings.TrimSpace(m.Role)
 	if m.TeamID == 0 || m.PlayerID == 0 {
-		return errors.New("team_id and player_id are required")
+		return requiredError("team_id", "player_id")
 	}
 	if m.Role == "" {
 		m.Role = "Player"
 	}
 	if m.JoinDate.IsZero() {
-		return errors.New("join_date is required")
+		return requiredError("join_date")
 	}
 	if err := s.validateDates(m); err != nil {
 		return err
 	}
-	return s.repo.Update(ctx, m)
+	return s.tx.DoWith(ctx, rosterTx, func(ctx context.Context) error {
+		prev, err := s.repo.GetByID(ctx, m.ID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTeam(ctx, prev.TeamID, m.TeamID); err != nil {
+			return err
+		}
+		if err := s.checkRosterSize(ctx, m); err != nil {
+			return err
+		}
+		return s.repo.Update(ctx, m)
+	})
 }
 
 func (s *SquadMemberService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		m, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTeam(ctx, m.TeamID); err != nil {
+			return err
+		}
+		return s.repo.Delete(ctx, id)
+	})
+}
+
+// checkRosterSize keeps the active non-standin roster within the discipline
+// team_size. Standins and former members are not counted.
+func (s *SquadMemberService) checkRosterSize(ctx context.Context, m *models.SquadMember) error {
+	if m.IsStandin || m.LeaveDate != nil {
+		return nil
+	}
+	size, err := teamSize(ctx, s.teams, s.disciplines, m.TeamID)
+	if err != nil || size == 0 {
+		return err
+	}
+	starters, err := s.repo.CountActiveStarters(ctx, m.TeamID, m.ID)
+	if err != nil {
+		return err
+	}
+	if starters+1 > size {
+		return fmt.Errorf("%w (team_size %d)", ErrRosterOversize, size)
+	}
+	return nil
+}
+
+// teamSize returns the team_size of the team's discipline, or 0 when the
+// discipline does not set one.
+func teamSize(ctx context.Context, teams repository.TeamRepository, disciplines repository.DisciplineRepository, teamID int64) (int, error) {
+	team, err := teams.GetByID(ctx, teamID)
+	if err != nil {
+		return 0, err
+	}
+	d, err := disciplines.GetByID(ctx, team.DisciplineID)
+	if err != nil {
+		return 0, err
+	}
+	if d.TeamSize == nil {
+		return 0, nil
+	}
+	return *d.TeamSize, nil
 }
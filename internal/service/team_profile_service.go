@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 
 	"db_course_project/internal/models"
 	"db_course_project/internal/pagination"
@@ -10,18 +9,24 @@ import (
 )
 
 type TeamProfileService struct {
+	tx   *repository.TxManager
 	repo repository.TeamProfileRepository
 }
 
-func NewTeamProfileService(repo repository.TeamProfileRepository) *TeamProfileService {
-	return &TeamProfileService{repo: repo}
+func NewTeamProfileService(tx *repository.TxManager, repo repository.TeamProfileRepository) *TeamProfileService {
+	return &TeamProfileService{tx: tx, repo: repo}
 }
 
 func (s *TeamProfileService) Create(ctx context.Context, p *models.TeamProfile) error {
 	if p.TeamID == 0 {
-		return errors.New("team_id is required")
+		return requiredError("team_id")
 	}
-	return s.repo.Create(ctx, p)
+	if err := authorizeTeam(ctx, p.TeamID); err != nil {
+		return err
+	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Create(ctx, p)
+	})
 }
 
 func (s *TeamProfileService) Get(ctx context.Context, teamID int64) (*models.TeamProfile, error) {
@@ -35,11 +40,21 @@ func (s *TeamProfileService) List(ctx context.Context, filter models.TeamProfile
 
 func (s *TeamProfileService) Update(ctx context.Context, p *models.TeamProfile) error {
 	if p.TeamID == 0 {
-		return errors.New("team_id is required")
+		return requiredError("team_id")
+	}
+	if err := authorizeTeam(ctx, p.TeamID); err != nil {
+		return err
 	}
-	return s.repo.Update(ctx, p)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Update(ctx, p)
+	})
 }
 
 func (s *TeamProfileService) Delete(ctx context.Context, teamID int64) error {
-	return s.repo.Delete(ctx, teamID)
+	if err := authorizeTeam(ctx, teamID); err != nil {
+		return err
+	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Delete(ctx, teamID)
+	})
 }
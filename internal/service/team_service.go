@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 	"strings"
 
 	"db_course_project/internal/models"
@@ -11,11 +10,13 @@ import (
 )
 
 type TeamService struct {
-	repo repository.TeamRepository
+	tx      *repository.TxManager
+	repo    repository.TeamRepository
+	ratings repository.RatingRepository
 }
 
-func NewTeamService(repo repository.TeamRepository) *TeamService {
-	return &TeamService{repo: repo}
+func NewTeamService(tx *repository.TxManager, repo repository.TeamRepository, ratings repository.RatingRepository) *TeamService {
+	return &TeamService{tx: tx, repo: repo, ratings: ratings}
 }
 
 func (s *TeamService) Create(ctx context.Context, t *models.Team) error {
@@ -23,15 +24,27 @@ func (s *TeamService) Create(ctx context.Context, t *models.Team) error {
 	t.Tag = strings.TrimSpace(t.Tag)
 	t.CountryCode = strings.TrimSpace(t.CountryCode)
 	if t.Name == "" || t.Tag == "" || t.CountryCode == "" || t.DisciplineID == 0 {
-		return errors.New("name, tag, country_code, discipline_id are required")
+		return requiredError("name", "tag", "country_code", "discipline_id")
 	}
-	return s.repo.Create(ctx, t)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, t); err != nil {
+			return err
+		}
+		if t.WorldRanking == 0 {
+			return nil
+		}
+		return s.recordManualRating(ctx, t, nil)
+	})
 }
 
 func (s *TeamService) Get(ctx context.Context, id int64) (*models.Team, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
+func (s *TeamService) GetByTag(ctx context.Context, tag string, disciplineID int64) (*models.Team, error) {
+	return s.repo.GetByTag(ctx, tag, disciplineID)
+}
+
 func (s *TeamService) List(ctx context.Context, filter models.TeamFilter) ([]models.Team, int, error) {
 	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
 	return s.repo.List(ctx, filter)
@@ -42,11 +55,37 @@ func (s *TeamService) Update(ctx context.Context, t *models.Team) error {
 	t.Tag = strings.TrimSpace(t.Tag)
 	t.CountryCode = strings.TrimSpace(t.CountryCode)
 	if t.Name == "" || t.Tag == "" || t.CountryCode == "" || t.DisciplineID == 0 {
-		return errors.New("name, tag, country_code, discipline_id are required")
+		return requiredError("name", "tag", "country_code", "discipline_id")
 	}
-	return s.repo.Update(ctx, t)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		prev, err := s.repo.GetByID(ctx, t.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, t); err != nil {
+			return err
+		}
+		if prev.WorldRanking == t.WorldRanking {
+			return nil
+		}
+		return s.recordManualRating(ctx, t, &prev.WorldRanking)
+	})
+}
+
+// recordManualRating logs a world_ranking set by hand in the rating history.
+func (s *TeamService) recordManualRating(ctx context.Context, t *models.Team, previous *float64) error {
+	id, disciplineID := t.ID, t.DisciplineID
+	return s.ratings.AddHistory(ctx, &models.RatingChange{
+		TeamID:         &id,
+		DisciplineID:   &disciplineID,
+		Rating:         t.WorldRanking,
+		PreviousRating: previous,
+		Cause:          models.RatingCauseManual,
+	})
 }
 
 func (s *TeamService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Delete(ctx, id)
+	})
 }
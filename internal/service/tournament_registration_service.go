@@ -2,53 +2,412 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"db_course_project/internal/models"
 	"db_course_project/internal/pagination"
 	"db_course_project/internal/repository"
 )
 
+var (
+	ErrRegistrationStatusManaged = errors.New("registration status is managed by the approve, reject and withdraw actions")
+	ErrRegistrationDiscipline    = errors.New("team discipline does not match the tournament discipline")
+	ErrRegistrationAction        = errors.New("action is not allowed for the current registration status")
+	ErrRegistrationLocked        = errors.New("registrations can no longer change once the tournament has started")
+	ErrRosterShortHanded         = errors.New("team has fewer active players than the discipline team_size")
+)
+
+var registrationStatuses = []string{
+	models.RegistrationPending,
+	models.RegistrationConfirmed,
+	models.RegistrationWaitlisted,
+	models.RegistrationRejected,
+	models.RegistrationWithdrawn,
+}
+
 type TournamentRegistrationService struct {
-	repo repository.TournamentRegistrationRepository
+	tx          *repository.TxManager
+	repo        repository.TournamentRegistrationRepository
+	tournaments repository.TournamentRepository
+	teams       repository.TeamRepository
+	squads      repository.SquadMemberRepository
+	disciplines repository.DisciplineRepository
 }
 
-func NewTournamentRegistrationService(repo repository.TournamentRegistrationRepository) *TournamentRegistrationService {
-	return &TournamentRegistrationService{repo: repo}
+func NewTournamentRegistrationService(tx *repository.TxManager, repo repository.TournamentRegistrationRepository, tournaments repository.TournamentRepository, teams repository.TeamRepository, squads repository.SquadMemberRepository, disciplines repository.DisciplineRepository) *TournamentRegistrationService {
+	return &TournamentRegistrationService{tx: tx, repo: repo, tournaments: tournaments, teams: teams, squads: squads, disciplines: disciplines}
 }
 
+// Create registers a team. Invited teams are confirmed right away; everyone
+// else starts as pending, or waitlisted when the tournament is full.
 func (s *TournamentRegistrationService) Create(ctx context.Context, reg *models.TournamentRegistration) error {
-	reg.Status = strings.TrimSpace(reg.Status)
-	if reg.Status == "" {
-		reg.Status = "Pending"
+	if reg.TournamentID == 0 || reg.TeamID == 0 {
+		return requiredError("tournament_id", "team_id")
+	}
+	if status := strings.TrimSpace(reg.Status); status != "" && !strings.EqualFold(status, models.RegistrationPending) {
+		return ErrRegistrationStatusManaged
 	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.enroll(ctx, reg)
+	})
+}
+
+// Restore creates a registration in the status it was exported with, for
+// imports that load an export back. The tournament may be in any status; the
+// team still has to belong to its discipline.
+func (s *TournamentRegistrationService) Restore(ctx context.Context, reg *models.TournamentRegistration) error {
 	if reg.TournamentID == 0 || reg.TeamID == 0 {
-		return errors.New("tournament_id and team_id are required")
+		return requiredError("tournament_id", "team_id")
+	}
+	status, err := normalizeRegistrationStatus(reg.Status)
+	if err != nil {
+		return err
+	}
+	reg.Status = status
+	reg.RosterSnapshot, reg.RosterLockedAt = nil, nil
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		t, err := s.tournaments.GetByID(ctx, reg.TournamentID)
+		if err != nil {
+			return err
+		}
+		if err := s.checkDiscipline(ctx, t, reg.TeamID); err != nil {
+			return err
+		}
+		return s.repo.Create(ctx, reg)
+	})
+}
+
+func (s *TournamentRegistrationService) enroll(ctx context.Context, reg *models.TournamentRegistration) error {
+	t, err := s.tournaments.GetByID(ctx, reg.TournamentID)
+	if err != nil {
+		return err
+	}
+	if t.Status != models.TournamentRegistrationOpen {
+		return ErrRegistrationClosed
+	}
+	if err := s.checkDiscipline(ctx, t, reg.TeamID); err != nil {
+		return err
 	}
-	return s.repo.Create(ctx, reg)
+	if err := s.checkRosterSize(ctx, reg.TeamID); err != nil {
+		return err
+	}
+	reg.Status = models.RegistrationPending
+	reg.RosterSnapshot, reg.RosterLockedAt = nil, nil
+	if reg.IsInvited {
+		reg.Status = models.RegistrationConfirmed
+		snapshot, err := s.captureRoster(ctx, reg.TeamID)
+		if err != nil {
+			return err
+		}
+		reg.RosterSnapshot = snapshot
+	}
+	return s.repo.Enroll(ctx, reg, !reg.IsInvited)
 }
 
 func (s *TournamentRegistrationService) Get(ctx context.Context, id int64) (*models.TournamentRegistration, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
+func (s *TournamentRegistrationService) GetByTournamentTeam(ctx context.Context, tournamentID, teamID int64) (*models.TournamentRegistration, error) {
+	return s.repo.GetByTournamentTeam(ctx, tournamentID, teamID)
+}
+
 func (s *TournamentRegistrationService) List(ctx context.Context, filter models.TournamentRegistrationFilter) ([]models.TournamentRegistration, int, error) {
 	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
 	return s.repo.List(ctx, filter)
 }
 
+// Update changes a registration while registrations can still change. The
+// status and roster are kept; they change through the status actions.
 func (s *TournamentRegistrationService) Update(ctx context.Context, reg *models.TournamentRegistration) error {
-	reg.Status = strings.TrimSpace(reg.Status)
-	if reg.Status == "" {
-		reg.Status = "Pending"
-	}
 	if reg.TournamentID == 0 || reg.TeamID == 0 {
-		return errors.New("tournament_id and team_id are required")
+		return requiredError("tournament_id", "team_id")
+	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.update(ctx, reg)
+	})
+}
+
+func (s *TournamentRegistrationService) update(ctx context.Context, reg *models.TournamentRegistration) error {
+	prev, err := s.repo.GetByIDForUpdate(ctx, reg.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkUnlocked(ctx, prev.TournamentID); err != nil {
+		return err
+	}
+	if status := strings.TrimSpace(reg.Status); status != "" && !strings.EqualFold(status, prev.Status) {
+		return ErrRegistrationStatusManaged
+	}
+	reg.Status = prev.Status
+	reg.RosterSnapshot, reg.RosterLockedAt = prev.RosterSnapshot, prev.RosterLockedAt
+	if prev.TournamentID != reg.TournamentID {
+		return errors.New("tournament_id cannot be changed, withdraw and register for the other tournament instead")
+	}
+	if prev.TeamID != reg.TeamID {
+		if prev.Status == models.RegistrationConfirmed {
+			return errors.New("team_id of a confirmed registration cannot be changed")
+		}
+		t, err := s.tournaments.GetByID(ctx, reg.TournamentID)
+		if err != nil {
+			return err
+		}
+		if err := s.checkDiscipline(ctx, t, reg.TeamID); err != nil {
+			return err
+		}
+		if err := s.checkRosterSize(ctx, reg.TeamID); err != nil {
+			return err
+		}
 	}
 	return s.repo.Update(ctx, reg)
 }
 
+// Delete removes a registration while registrations can still change. A
+// registration holding a slot is withdrawn first, so the slot goes to the
+// next waitlisted team.
 func (s *TournamentRegistrationService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		reg, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.checkUnlocked(ctx, reg.TournamentID); err != nil {
+			return err
+		}
+		if reg.Status == models.RegistrationPending || reg.Status == models.RegistrationConfirmed {
+			if _, err := s.repo.ChangeStatus(ctx, id, []string{reg.Status}, models.RegistrationWithdrawn); err != nil {
+				return err
+			}
+		}
+		return s.repo.Delete(ctx, id)
+	})
+}
+
+// Approve confirms a registration and captures its roster in one unit of work.
+func (s *TournamentRegistrationService) Approve(ctx context.Context, id int64) (*models.RegistrationStatusChange, error) {
+	var change *models.RegistrationStatusChange
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		change, err = s.approve(ctx, id)
+		return err
+	})
+	return change, err
+}
+
+func (s *TournamentRegistrationService) approve(ctx context.Context, id int64) (*models.RegistrationStatusChange, error) {
+	reg, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRosterSize(ctx, reg.TeamID); err != nil {
+		return nil, err
+	}
+	change, err := s.changeStatus(ctx, id, models.RegistrationConfirmed, models.RegistrationPending, models.RegistrationWaitlisted)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := s.captureRoster(ctx, change.Registration.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetRosterSnapshot(ctx, id, snapshot); err != nil {
+		return nil, err
+	}
+	change.Registration.RosterSnapshot = snapshot
+	return change, nil
+}
+
+func (s *TournamentRegistrationService) Reject(ctx context.Context, id int64) (*models.RegistrationStatusChange, error) {
+	var change *models.RegistrationStatusChange
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		change, err = s.changeStatus(ctx, id, models.RegistrationRejected, models.RegistrationPending, models.RegistrationWaitlisted)
+		return err
+	})
+	return change, err
+}
+
+func (s *TournamentRegistrationService) Withdraw(ctx context.Context, id int64) (*models.RegistrationStatusChange, error) {
+	var change *models.RegistrationStatusChange
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		change, err = s.changeStatus(ctx, id, models.RegistrationWithdrawn, models.RegistrationPending, models.RegistrationConfirmed, models.RegistrationWaitlisted)
+		return err
+	})
+	return change, err
+}
+
+// RefreshRoster recaptures the roster of a confirmed registration from the
+// team's current squad. It fails once the roster is locked.
+func (s *TournamentRegistrationService) RefreshRoster(ctx context.Context, id int64) (*models.TournamentRegistration, error) {
+	var reg *models.TournamentRegistration
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		reg, err = s.refreshRoster(ctx, id)
+		return err
+	})
+	return reg, err
+}
+
+func (s *TournamentRegistrationService) refreshRoster(ctx context.Context, id int64) (*models.TournamentRegistration, error) {
+	reg, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if reg.Status != models.RegistrationConfirmed {
+		return nil, fmt.Errorf("%w: only confirmed registrations have a roster snapshot", ErrRegistrationAction)
+	}
+	if reg.RosterLockedAt != nil {
+		return nil, repository.ErrRosterLocked
+	}
+	snapshot, err := s.captureRoster(ctx, reg.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetRosterSnapshot(ctx, id, snapshot); err != nil {
+		return nil, err
+	}
+	reg.RosterSnapshot = snapshot
+	return reg, nil
+}
+
+// LockRosters freezes the rosters of all confirmed registrations when the
+// tournament starts. Registrations confirmed without a usable snapshot get
+// one captured first.
+func (s *TournamentRegistrationService) LockRosters(ctx context.Context, tournamentID int64) error {
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.lockRosters(ctx, tournamentID)
+	})
+}
+
+func (s *TournamentRegistrationService) lockRosters(ctx context.Context, tournamentID int64) error {
+	regs, err := s.repo.ListByTournament(ctx, tournamentID, models.RegistrationConfirmed)
+	if err != nil {
+		return err
+	}
+	for _, reg := range regs {
+		if reg.RosterLockedAt != nil {
+			continue
+		}
+		if _, ok := parseRosterSnapshot(reg.RosterSnapshot); ok {
+			continue
+		}
+		snapshot, err := s.captureRoster(ctx, reg.TeamID)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SetRosterSnapshot(ctx, reg.ID, snapshot); err != nil {
+			return err
+		}
+	}
+	return s.repo.LockRosters(ctx, tournamentID)
+}
+
+func (s *TournamentRegistrationService) captureRoster(ctx context.Context, teamID int64) (json.RawMessage, error) {
+	players, err := s.squads.ListActiveRoster(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(models.RosterSnapshot{
+		TeamID:     teamID,
+		CapturedAt: time.Now().UTC(),
+		Players:    players,
+	})
+}
+
+func (s *TournamentRegistrationService) changeStatus(ctx context.Context, id int64, to string, from ...string) (*models.RegistrationStatusChange, error) {
+	reg, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkUnlocked(ctx, reg.TournamentID); err != nil {
+		return nil, err
+	}
+	allowed := false
+	for _, f := range from {
+		if reg.Status == f {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrRegistrationAction, reg.Status, to)
+	}
+	promoted, err := s.repo.ChangeStatus(ctx, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+	reg, err = s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.RegistrationStatusChange{Registration: *reg, Promoted: promoted}, nil
+}
+
+// checkUnlocked fails with ErrRegistrationLocked unless the tournament is
+// still taking or reviewing registrations.
+func (s *TournamentRegistrationService) checkUnlocked(ctx context.Context, tournamentID int64) error {
+	status, err := tournamentStatus(ctx, s.tournaments, tournamentID)
+	if err != nil {
+		return err
+	}
+	if status != models.TournamentRegistrationOpen && status != models.TournamentRegistrationClosed {
+		return ErrRegistrationLocked
+	}
+	return nil
+}
+
+func (s *TournamentRegistrationService) checkDiscipline(ctx context.Context, t *models.Tournament, teamID int64) error {
+	team, err := s.teams.GetByID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if team.DisciplineID != t.DisciplineID {
+		return ErrRegistrationDiscipline
+	}
+	return nil
+}
+
+// checkRosterSize requires at least team_size active players, standins
+// included, so the team can field a full lineup.
+func (s *TournamentRegistrationService) checkRosterSize(ctx context.Context, teamID int64) error {
+	size, err := teamSize(ctx, s.teams, s.disciplines, teamID)
+	if err != nil || size == 0 {
+		return err
+	}
+	players, err := s.squads.ListActiveRoster(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if len(players) < size {
+		return fmt.Errorf("%w: %d of %d", ErrRosterShortHanded, len(players), size)
+	}
+	return nil
+}
+
+func normalizeRegistrationStatus(status string) (string, error) {
+	status = strings.TrimSpace(status)
+	if status == "" {
+		return models.RegistrationPending, nil
+	}
+	for _, known := range registrationStatuses {
+		if strings.EqualFold(status, known) {
+			return known, nil
+		}
+	}
+	return "", fmt.Errorf("status must be one of %s", strings.Join(registrationStatuses, ", "))
+}
+
+// parseRosterSnapshot decodes a snapshot captured by the service. Free-form
+// JSON from older registrations is reported as not usable.
+func parseRosterSnapshot(raw json.RawMessage) (models.RosterSnapshot, bool) {
+	var snapshot models.RosterSnapshot
+	if len(raw) == 0 || json.Unmarshal(raw, &snapshot) != nil || snapshot.Players == nil {
+		return snapshot, false
+	}
+	return snapshot, true
 }
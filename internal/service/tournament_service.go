@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -11,34 +12,92 @@ import (
 	"db_course_project/internal/repository"
 )
 
+var (
+	ErrInvalidTransition        = errors.New("invalid tournament status transition")
+	ErrStatusViaTransition      = errors.New("status can only be changed through the transition endpoint")
+	ErrTournamentHasOpenMatches = errors.New("tournament still has matches without a result")
+	ErrRegistrationClosed       = errors.New("tournament is not open for registration")
+	ErrResultsNotAllowed        = errors.New("match results can only be recorded once the tournament is ongoing")
+)
+
+var tournamentStatuses = []string{
+	models.TournamentAnnounced,
+	models.TournamentRegistrationOpen,
+	models.TournamentRegistrationClosed,
+	models.TournamentOngoing,
+	models.TournamentCompleted,
+	models.TournamentCancelled,
+}
+
+var tournamentTransitions = map[string][]string{
+	models.TournamentAnnounced:          {models.TournamentRegistrationOpen, models.TournamentCancelled},
+	models.TournamentRegistrationOpen:   {models.TournamentRegistrationClosed, models.TournamentCancelled},
+	models.TournamentRegistrationClosed: {models.TournamentRegistrationOpen, models.TournamentOngoing, models.TournamentCancelled},
+	models.TournamentOngoing:            {models.TournamentCompleted, models.TournamentCancelled},
+}
+
 type TournamentService struct {
-	repo repository.TournamentRepository
+	tx            *repository.TxManager
+	repo          repository.TournamentRepository
+	matches       repository.MatchRepository
+	registrations *TournamentRegistrationService
 }
 
-func NewTournamentService(repo repository.TournamentRepository) *TournamentService {
-	return &TournamentService{repo: repo}
+func NewTournamentService(tx *repository.TxManager, repo repository.TournamentRepository, matches repository.MatchRepository, registrations *TournamentRegistrationService) *TournamentService {
+	return &TournamentService{tx: tx, repo: repo, matches: matches, registrations: registrations}
 }
 
 func (s *TournamentService) Create(ctx context.Context, t *models.Tournament) error {
+	return s.create(ctx, t, false)
+}
+
+// Restore creates a tournament in the status it was exported with, for
+// imports that load an export back.
+func (s *TournamentService) Restore(ctx context.Context, t *models.Tournament) error {
+	return s.create(ctx, t, true)
+}
+
+func (s *TournamentService) create(ctx context.Context, t *models.Tournament, restore bool) error {
 	t.Name = strings.TrimSpace(t.Name)
 	t.Currency = strings.TrimSpace(t.Currency)
-	t.Status = strings.TrimSpace(t.Status)
 	if t.Name == "" || t.DisciplineID == 0 {
-		return errors.New("name and discipline_id are required")
+		return requiredError("name", "discipline_id")
 	}
+	// New tournaments are announced; later statuses are reached through
+	// Transition, which records them and locks the rosters.
+	status, err := normalizeTournamentStatus(t.Status)
+	if err != nil {
+		return err
+	}
+	if status != models.TournamentAnnounced && !restore {
+		return ErrStatusViaTransition
+	}
+	t.Status = status
 	if t.EndDate.Before(t.StartDate) {
 		return errors.New("end_date must be after start_date")
 	}
+	if t.MaxTeams != nil && *t.MaxTeams <= 0 {
+		return errors.New("max_teams must be positive")
+	}
+	if err := validateBracketConfig(t); err != nil {
+		return err
+	}
 	if t.StartDate.Before(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)) {
 		return errors.New("start_date looks invalid")
 	}
-	return s.repo.Create(ctx, t)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Create(ctx, t)
+	})
 }
 
 func (s *TournamentService) Get(ctx context.Context, id int64) (*models.Tournament, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
+func (s *TournamentService) ListByName(ctx context.Context, name string) ([]models.Tournament, error) {
+	return s.repo.ListByName(ctx, name)
+}
+
 func (s *TournamentService) List(ctx context.Context, filter models.TournamentFilter) ([]models.Tournament, int, error) {
 	filter.Limit, filter.Offset = pagination.Normalize(filter.Limit, filter.Offset)
 	return s.repo.List(ctx, filter)
@@ -47,16 +106,117 @@ func (s *TournamentService) List(ctx context.Context, filter models.TournamentFi
 func (s *TournamentService) Update(ctx context.Context, t *models.Tournament) error {
 	t.Name = strings.TrimSpace(t.Name)
 	t.Currency = strings.TrimSpace(t.Currency)
-	t.Status = strings.TrimSpace(t.Status)
 	if t.Name == "" || t.DisciplineID == 0 {
-		return errors.New("name and discipline_id are required")
+		return requiredError("name", "discipline_id")
 	}
 	if t.EndDate.Before(t.StartDate) {
 		return errors.New("end_date must be after start_date")
 	}
-	return s.repo.Update(ctx, t)
+	if t.MaxTeams != nil && *t.MaxTeams <= 0 {
+		return errors.New("max_teams must be positive")
+	}
+	if err := validateBracketConfig(t); err != nil {
+		return err
+	}
+	current, err := s.repo.GetByID(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(t.Status) != "" {
+		status, err := normalizeTournamentStatus(t.Status)
+		if err != nil {
+			return err
+		}
+		if status != current.Status {
+			return ErrStatusViaTransition
+		}
+	}
+	t.Status = current.Status
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Update(ctx, t)
+	})
 }
 
 func (s *TournamentService) Delete(ctx context.Context, id int64) error {
-	return s.repo.Delete(ctx, id)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Delete(ctx, id)
+	})
+}
+
+func (s *TournamentService) Transition(ctx context.Context, id int64, status string) (*models.Tournament, error) {
+	to, err := normalizeTournamentStatus(status)
+	if err != nil {
+		return nil, err
+	}
+	var t *models.Tournament
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		t, err = s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !canTransition(t.Status, to) {
+			return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, t.Status, to)
+		}
+		if to == models.TournamentCompleted {
+			open, err := s.matches.CountUndecided(ctx, id)
+			if err != nil {
+				return err
+			}
+			if open > 0 {
+				return fmt.Errorf("%w: %d left", ErrTournamentHasOpenMatches, open)
+			}
+		}
+		if to == models.TournamentOngoing {
+			if err := s.registrations.LockRosters(ctx, id); err != nil {
+				return err
+			}
+		}
+		return s.repo.Transition(ctx, id, t.Status, to)
+	})
+	if err != nil {
+		return nil, err
+	}
+	t.Status = to
+	return t, nil
+}
+
+func (s *TournamentService) StatusHistory(ctx context.Context, id int64) ([]models.TournamentStatusChange, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.StatusHistory(ctx, id)
+}
+
+func canTransition(from, to string) bool {
+	for _, next := range tournamentTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeTournamentStatus maps a status to its canonical spelling. An empty
+// status means a newly announced tournament.
+func normalizeTournamentStatus(status string) (string, error) {
+	status = strings.TrimSpace(status)
+	if status == "" {
+		return models.TournamentAnnounced, nil
+	}
+	for _, known := range tournamentStatuses {
+		if strings.EqualFold(status, known) {
+			return known, nil
+		}
+	}
+	return "", fmt.Errorf("status must be one of %s", strings.Join(tournamentStatuses, ", "))
+}
+
+// tournamentStatus loads the current status of a tournament for rules that
+// depend on the lifecycle.
+func tournamentStatus(ctx context.Context, repo repository.TournamentRepository, id int64) (string, error) {
+	t, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return t.Status, nil
 }
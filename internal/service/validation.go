@@ -0,0 +1,22 @@
+package service
+
+import "strings"
+
+// RequiredError reports required fields left empty.
+type RequiredError struct {
+	Fields []string
+}
+
+func (e *RequiredError) Error() string {
+	switch len(e.Fields) {
+	case 1:
+		return e.Fields[0] + " is required"
+	case 2:
+		return e.Fields[0] + " and " + e.Fields[1] + " are required"
+	}
+	return strings.Join(e.Fields, ", ") + " are required"
+}
+
+func requiredError(fields ...string) error {
+	return &RequiredError{Fields: fields}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"math/rand"
@@ -73,6 +74,7 @@ type Tournament struct {
 	Status        string
 	IsOnline      bool
 	BracketConfig string
+	MaxTeams      int
 }
 
 type Registration struct {
@@ -83,9 +85,23 @@ type Registration struct {
 	Status       string
 	ManagerEmail string
 	RosterJSON   string
+	RosterLocked bool
 	IsInvited    bool
 }
 
+type RosterPlayer struct {
+	PlayerID  int    `json:"player_id"`
+	Nickname  string `json:"nickname"`
+	Role      string `json:"role"`
+	IsStandin bool   `json:"is_standin"`
+}
+
+type RosterSnapshot struct {
+	TeamID     int            `json:"team_id"`
+	CapturedAt time.Time      `json:"captured_at"`
+	Players    []RosterPlayer `json:"players"`
+}
+
 type Match struct {
 	ID           int
 	TournamentID int
@@ -148,7 +164,7 @@ func main() {
 	players := buildPlayers(playersCount)
 	squad := buildSquadMembers(players, teams)
 	tournamentsData := buildTournaments(tournaments, disciplines)
-	registrations := buildRegistrations(tournamentsData, teams)
+	registrations := buildRegistrations(tournamentsData, teams, squad, players)
 	matches := buildMatches(tournamentsData, registrations)
 	games := buildGames(matches)
 	stats := buildStats(games, matches, squad)
@@ -293,12 +309,21 @@ func buildTournaments(count int, disciplines []Discipline) []Tournament {
 			Status:        statuses[rand.Intn(len(statuses))],
 			IsOnline:      rand.Float64() < 0.6,
 			BracketConfig: `{"type":"double_elim","format":"bo3"}`,
+			MaxTeams:      16,
 		})
 	}
 	return tournaments
 }
 
-func buildRegistrations(tournaments []Tournament, teams []Team) []Registration {
+func buildRegistrations(tournaments []Tournament, teams []Team, squad []SquadMember, players []Player) []Registration {
+	nicknames := map[int]string{}
+	for _, p := range players {
+		nicknames[p.ID] = p.Nickname
+	}
+	rosters := map[int][]RosterPlayer{}
+	for _, sm := range squad {
+		rosters[sm.TeamID] = append(rosters[sm.TeamID], RosterPlayer{PlayerID: sm.PlayerID, Nickname: nicknames[sm.PlayerID], Role: sm.Role})
+	}
 	regs := []Registration{}
 	id := 1
 	for _, t := range tournaments {
@@ -313,12 +338,13 @@ func buildRegistrations(tournaments []Tournament, teams []Team) []Registration {
 		if len(sameDisc) < regCount {
 			regCount = len(sameDisc)
 		}
-		rosterMap := map[int][]int{}
-		for _, tm := range sameDisc[:regCount] {
-			rosterMap[tm.ID] = []int{}
-		}
 		seed := 1
 		for _, tm := range sameDisc[:regCount] {
+			roster := rosters[tm.ID]
+			if roster == nil {
+				roster = []RosterPlayer{}
+			}
+			snapshot, _ := json.Marshal(RosterSnapshot{TeamID: tm.ID, CapturedAt: t.StartDate.AddDate(0, 0, -7).UTC(), Players: roster})
 			regs = append(regs, Registration{
 				ID:           id,
 				TournamentID: t.ID,
@@ -326,7 +352,8 @@ func buildRegistrations(tournaments []Tournament, teams []Team) []Registration {
 				Seed:         seed,
 				Status:       "Confirmed",
 				ManagerEmail: fmt.Sprintf("manager+%d@%s.com", tm.ID, strings.ToLower(tm.Tag)),
-				RosterJSON:   fmt.Sprintf(`{"team_id":%d,"seed":%d}`, tm.ID, seed),
+				RosterJSON:   string(snapshot),
+				RosterLocked: t.Status == "Ongoing" || t.Status == "Completed",
 				IsInvited:    rand.Float64() < 0.4,
 			})
 			id++
@@ -344,6 +371,9 @@ func buildMatches(tournaments []Tournament, regs []Registration) []Match {
 		regsByTournament[r.TournamentID] = append(regsByTournament[r.TournamentID], r)
 	}
 	for _, t := range tournaments {
+		if t.Status == "Announced" {
+			continue
+		}
 		regsForT := regsByTournament[t.ID]
 		if len(regsForT) < 2 {
 			continue
@@ -386,14 +416,27 @@ func buildGames(matches []Match) []Game {
 		if rand.Float64() < 0.5 {
 			gameCount = 3
 		}
+		loser := m.Team1ID
+		if loser == m.WinnerID {
+			loser = m.Team2ID
+		}
+		// In a bo3 that goes the distance the loser takes one of the first two maps.
+		lostGame := 0
+		if gameCount == 3 {
+			lostGame = 1 + rand.Intn(2)
+		}
 		for g := 1; g <= gameCount; g++ {
+			gameWinner := m.WinnerID
+			if g == lostGame {
+				gameWinner = loser
+			}
 			games = append(games, Game{
 				ID:           id,
 				MatchID:      m.ID,
 				MapName:      fmt.Sprintf("Map %d", g),
 				GameNumber:   g,
 				DurationSec:  1800 + rand.Intn(1200),
-				WinnerTeamID: m.WinnerID,
+				WinnerTeamID: gameWinner,
 				ScoreTeam1:   rand.Intn(16),
 				ScoreTeam2:   rand.Intn(16),
 				StartedAt:    m.StartTime.Add(time.Duration(g-1) * time.Hour),
@@ -533,16 +576,20 @@ func writeSquadMembers(f *os.File, items []SquadMember) {
 
 func writeTournaments(f *os.File, items []Tournament) {
 	for _, t := range items {
-		fmt.Fprintf(f, "INSERT INTO tournaments (id, discipline_id, name, start_date, end_date, prize_pool, currency, status, is_online, bracket_config) OVERRIDING SYSTEM VALUE VALUES (%d, %d, '%s', '%s', '%s', %.2f, 'USD', '%s', %t, '%s');\n",
-			t.ID, t.DisciplineID, esc(t.Name), t.StartDate.Format("2006-01-02"), t.EndDate.Format("2006-01-02"), t.PrizePool, esc(t.Status), t.IsOnline, esc(t.BracketConfig))
+		fmt.Fprintf(f, "INSERT INTO tournaments (id, discipline_id, name, start_date, end_date, prize_pool, currency, status, is_online, bracket_config, max_teams) OVERRIDING SYSTEM VALUE VALUES (%d, %d, '%s', '%s', '%s', %.2f, 'USD', '%s', %t, '%s', %d);\n",
+			t.ID, t.DisciplineID, esc(t.Name), t.StartDate.Format("2006-01-02"), t.EndDate.Format("2006-01-02"), t.PrizePool, esc(t.Status), t.IsOnline, esc(t.BracketConfig), t.MaxTeams)
 	}
 	f.WriteString("\n")
 }
 
 func writeRegistrations(f *os.File, items []Registration) {
 	for _, r := range items {
-		fmt.Fprintf(f, "INSERT INTO tournament_registrations (id, tournament_id, team_id, seed_number, status, manager_contact, roster_snapshot, is_invited, registered_at) OVERRIDING SYSTEM VALUE VALUES (%d, %d, %d, %d, '%s', '%s', '%s', %t, CURRENT_TIMESTAMP);\n",
-			r.ID, r.TournamentID, r.TeamID, r.Seed, esc(r.Status), esc(r.ManagerEmail), esc(r.RosterJSON), r.IsInvited)
+		lockedAt := "NULL"
+		if r.RosterLocked {
+			lockedAt = "CURRENT_TIMESTAMP"
+		}
+		fmt.Fprintf(f, "INSERT INTO tournament_registrations (id, tournament_id, team_id, seed_number, status, manager_contact, roster_snapshot, is_invited, registered_at, roster_locked_at) OVERRIDING SYSTEM VALUE VALUES (%d, %d, %d, %d, '%s', '%s', '%s', %t, CURRENT_TIMESTAMP, %s);\n",
+			r.ID, r.TournamentID, r.TeamID, r.Seed, esc(r.Status), esc(r.ManagerEmail), esc(r.RosterJSON), r.IsInvited, lockedAt)
 	}
 	f.WriteString("\n")
 }